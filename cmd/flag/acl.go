@@ -0,0 +1,21 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type ACLFlagValues struct {
+	ShowACL bool
+}
+
+var (
+	aclFlagValues ACLFlagValues
+)
+
+func SetACLFlags(command *cobra.Command) {
+	command.Flags().BoolVarP(&aclFlagValues.ShowACL, "acl", "A", false, "Display access control lists of entries and access inheritance of collections")
+}
+
+func GetACLFlagValues() *ACLFlagValues {
+	return &aclFlagValues
+}
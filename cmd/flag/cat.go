@@ -0,0 +1,35 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type CatFlagValues struct {
+	Offset int64
+	Length int64
+	Head   int64
+	Tail   int64
+	Bytes  bool
+}
+
+var (
+	catFlagValues CatFlagValues
+)
+
+func SetCatFlags(command *cobra.Command) {
+	command.Flags().Int64Var(&catFlagValues.Offset, "offset", 0, "Start reading from the given byte offset")
+	command.Flags().Int64Var(&catFlagValues.Length, "length", -1, "Read the given number of bytes, read until the end if not given")
+	command.Flags().Int64Var(&catFlagValues.Head, "head", -1, "Display the first N lines, or bytes with --bytes")
+	command.Flags().Int64Var(&catFlagValues.Tail, "tail", -1, "Display the last N lines, or bytes with --bytes")
+	command.Flags().BoolVar(&catFlagValues.Bytes, "bytes", false, "Count --head and --tail in bytes instead of lines")
+
+	command.MarkFlagsMutuallyExclusive("head", "tail")
+	command.MarkFlagsMutuallyExclusive("head", "offset")
+	command.MarkFlagsMutuallyExclusive("head", "length")
+	command.MarkFlagsMutuallyExclusive("tail", "offset")
+	command.MarkFlagsMutuallyExclusive("tail", "length")
+}
+
+func GetCatFlagValues() *CatFlagValues {
+	return &catFlagValues
+}
@@ -1,6 +1,7 @@
 package flag
 
 import (
+	"github.com/cyverse/gocommands/commons"
 	"github.com/spf13/cobra"
 )
 
@@ -9,8 +10,15 @@ type ChecksumFlagValues struct {
 	CalculateChecksum bool
 }
 
+type ChecksumVerificationFlagValues struct {
+	Verify       bool
+	LocalPath    string
+	ThreadNumber int
+}
+
 var (
-	checksumFlagValues ChecksumFlagValues
+	checksumFlagValues             ChecksumFlagValues
+	checksumVerificationFlagValues ChecksumVerificationFlagValues
 )
 
 func SetChecksumFlags(command *cobra.Command, addCalculateChecksumFlag bool) {
@@ -24,3 +32,13 @@ func SetChecksumFlags(command *cobra.Command, addCalculateChecksumFlag bool) {
 func GetChecksumFlagValues() *ChecksumFlagValues {
 	return &checksumFlagValues
 }
+
+func SetChecksumVerificationFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&checksumVerificationFlagValues.Verify, "verify", false, "Verify registered checksums against the content of each replica")
+	command.Flags().StringVar(&checksumVerificationFlagValues.LocalPath, "local", "", "Compare checksums against files in the given local directory")
+	command.Flags().IntVar(&checksumVerificationFlagValues.ThreadNumber, "thread_num", commons.TransferThreadNumDefault, "Specify the number of threads")
+}
+
+func GetChecksumVerificationFlagValues() *ChecksumVerificationFlagValues {
+	return &checksumVerificationFlagValues
+}
@@ -0,0 +1,22 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type ChmodFlagValues struct {
+	ThreadNumber int
+}
+
+var (
+	chmodFlagValues ChmodFlagValues
+)
+
+func SetChmodFlags(command *cobra.Command) {
+	command.Flags().IntVar(&chmodFlagValues.ThreadNumber, "thread_num", commons.TransferThreadNumDefault, "Specify the number of threads")
+}
+
+func GetChmodFlagValues() *ChmodFlagValues {
+	return &chmodFlagValues
+}
@@ -41,6 +41,9 @@ func SetCommonFlags(command *cobra.Command, noResource bool) {
 	command.Flags().StringVar(&commonFlagValues.logLevelInput, "log_level", "", "Set log level")
 	command.Flags().IntVarP(&commonFlagValues.SessionID, "session", "s", os.Getppid(), "Set session ID")
 
+	// output format is global, commands without structured output print as usual
+	SetOutputFlags(command)
+
 	if !noResource {
 		command.Flags().StringVarP(&commonFlagValues.Resource, "resource", "R", "", "Set resource server")
 	}
@@ -94,7 +97,6 @@ func ProcessCommonFlags(command *cobra.Command) (bool, error) {
 	})
 
 	myCommonFlagValues := GetCommonFlagValues(command)
-	retryFlagValues := GetRetryFlagValues()
 
 	setLogLevel(command)
 
@@ -163,14 +165,6 @@ func ProcessCommonFlags(command *cobra.Command) (bool, error) {
 	// re-configure level
 	setLogLevel(command)
 
-	if retryFlagValues.RetryChild {
-		// read from stdin
-		err := commons.InputMissingFieldsFromStdin()
-		if err != nil {
-			return false, xerrors.Errorf("failed to load config from stdin: %w", err) // stop here
-		}
-	}
-
 	appConfig := commons.GetConfig()
 
 	syncAccount := false
@@ -0,0 +1,21 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type ContinueOnErrorFlagValues struct {
+	ContinueOnError bool
+}
+
+var (
+	continueOnErrorFlagValues ContinueOnErrorFlagValues
+)
+
+func SetContinueOnErrorFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&continueOnErrorFlagValues.ContinueOnError, "continue_on_error", false, "Keep transferring other files when some fail, and print a summary of failures at the end")
+}
+
+func GetContinueOnErrorFlagValues() *ContinueOnErrorFlagValues {
+	return &continueOnErrorFlagValues
+}
@@ -0,0 +1,23 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type DiffFlagValues struct {
+	JSON          bool
+	ShowIdentical bool
+}
+
+var (
+	diffFlagValues DiffFlagValues
+)
+
+func SetDiffFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&diffFlagValues.JSON, "json", false, "Output results in JSON lines")
+	command.Flags().BoolVar(&diffFlagValues.ShowIdentical, "show_identical", false, "Also output identical files and directories")
+}
+
+func GetDiffFlagValues() *DiffFlagValues {
+	return &diffFlagValues
+}
@@ -1,12 +1,20 @@
 package flag
 
 import (
+	"time"
+
+	"github.com/cyverse/gocommands/commons"
 	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
 )
 
 type DifferentialTransferFlagValues struct {
 	DifferentialTransfer bool
 	NoHash               bool
+	Mode                 commons.DiffMode
+	modeInput            string
+	MtimeTolerance       time.Duration
+	mtimeToleranceInput  string
 }
 
 var (
@@ -19,8 +27,49 @@ func SetDifferentialTransferFlags(command *cobra.Command, addDiffFlag bool) {
 	}
 
 	command.Flags().BoolVar(&differentialTransferFlagValues.NoHash, "no_hash", false, "Compare files without using hash")
+	command.Flags().StringVar(&differentialTransferFlagValues.modeInput, "diff_mode", "", "Compare files by 'size', 'mtime', 'size+mtime', or 'checksum', implies --diff")
+	command.Flags().StringVar(&differentialTransferFlagValues.mtimeToleranceInput, "mtime_tolerance", "2s", "Tolerance for comparing modification time in 'mtime' and 'size+mtime' modes")
+
+	command.MarkFlagsMutuallyExclusive("no_hash", "diff_mode")
 }
 
 func GetDifferentialTransferFlagValues() *DifferentialTransferFlagValues {
+	if len(differentialTransferFlagValues.modeInput) > 0 {
+		differentialTransferFlagValues.DifferentialTransfer = true
+		differentialTransferFlagValues.Mode = commons.GetDiffMode(differentialTransferFlagValues.modeInput)
+	} else if differentialTransferFlagValues.NoHash {
+		differentialTransferFlagValues.Mode = commons.DiffModeSize
+	} else {
+		differentialTransferFlagValues.Mode = commons.DiffModeChecksum
+	}
+
+	differentialTransferFlagValues.MtimeTolerance = 0
+	if len(differentialTransferFlagValues.mtimeToleranceInput) > 0 {
+		tolerance, err := commons.ParseTime(differentialTransferFlagValues.mtimeToleranceInput)
+		if err == nil {
+			differentialTransferFlagValues.MtimeTolerance = time.Duration(tolerance) * time.Second
+		}
+	}
+
 	return &differentialTransferFlagValues
 }
+
+// Validate checks if the diff mode and the mtime tolerance given are valid
+func (values *DifferentialTransferFlagValues) Validate() error {
+	if values.Mode == commons.DiffModeUnknown {
+		return xerrors.Errorf("invalid diff mode %q, must be one of 'size', 'mtime', 'size+mtime', or 'checksum'", values.modeInput)
+	}
+
+	if len(values.mtimeToleranceInput) > 0 {
+		tolerance, err := commons.ParseTime(values.mtimeToleranceInput)
+		if err != nil {
+			return xerrors.Errorf("invalid mtime tolerance %q: %w", values.mtimeToleranceInput, err)
+		}
+
+		if tolerance < 0 {
+			return xerrors.Errorf("invalid mtime tolerance %q, must not be negative", values.mtimeToleranceInput)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package flag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifferentialTransfer(t *testing.T) {
+	t.Run("test MtimeTolerance", testMtimeTolerance)
+	t.Run("test DiffMode", testDiffMode)
+}
+
+func parseDifferentialTransferFlags(t *testing.T, args ...string) *DifferentialTransferFlagValues {
+	differentialTransferFlagValues = DifferentialTransferFlagValues{}
+
+	command := &cobra.Command{Use: "test"}
+	SetDifferentialTransferFlags(command, true)
+
+	err := command.ParseFlags(args)
+	assert.NoError(t, err)
+
+	return GetDifferentialTransferFlagValues()
+}
+
+func testMtimeTolerance(t *testing.T) {
+	values := parseDifferentialTransferFlags(t)
+	assert.NoError(t, values.Validate())
+	assert.Equal(t, 2*time.Second, values.MtimeTolerance)
+
+	values = parseDifferentialTransferFlags(t, "--mtime_tolerance", "1m")
+	assert.NoError(t, values.Validate())
+	assert.Equal(t, time.Minute, values.MtimeTolerance)
+
+	for _, input := range []string{"  ", "2x", "-2s"} {
+		values = parseDifferentialTransferFlags(t, "--mtime_tolerance", input)
+		assert.Error(t, values.Validate(), "tolerance %q", input)
+	}
+}
+
+func testDiffMode(t *testing.T) {
+	values := parseDifferentialTransferFlags(t)
+	assert.NoError(t, values.Validate())
+	assert.False(t, values.DifferentialTransfer)
+	assert.Equal(t, commons.DiffModeChecksum, values.Mode)
+
+	values = parseDifferentialTransferFlags(t, "--diff_mode", "size+mtime")
+	assert.NoError(t, values.Validate())
+	assert.True(t, values.DifferentialTransfer)
+	assert.Equal(t, commons.DiffModeSizeMtime, values.Mode)
+
+	values = parseDifferentialTransferFlags(t, "--no_hash")
+	assert.NoError(t, values.Validate())
+	assert.Equal(t, commons.DiffModeSize, values.Mode)
+
+	values = parseDifferentialTransferFlags(t, "--diff_mode", "length")
+	assert.Error(t, values.Validate())
+}
@@ -0,0 +1,26 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type DownloadMetaFlagValues struct {
+	SidecarFormat commons.OutputFormat
+	Xattrs        bool
+	XattrsPrefix  string
+}
+
+var (
+	downloadMetaFlagValues DownloadMetaFlagValues
+)
+
+func SetDownloadMetaFlags(command *cobra.Command) {
+	command.Flags().Var(&outputFormatValue{format: &downloadMetaFlagValues.SidecarFormat}, "meta_sidecar", "Write metadata of downloaded data objects to sidecar files <file>.meta.<format> and of collections to .collection.meta.<format>, json, csv or yaml")
+	command.Flags().BoolVar(&downloadMetaFlagValues.Xattrs, "xattrs", false, "Write metadata of downloaded data objects to user.* extended attributes of files")
+	command.Flags().StringVar(&downloadMetaFlagValues.XattrsPrefix, "xattrs_prefix", "", "Write only metadata whose attribute starts with the given prefix to extended attributes")
+}
+
+func GetDownloadMetaFlagValues() *DownloadMetaFlagValues {
+	return &downloadMetaFlagValues
+}
@@ -0,0 +1,21 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type FilesFromFlagValues struct {
+	Path string
+}
+
+var (
+	filesFromFlagValues FilesFromFlagValues
+)
+
+func SetFilesFromFlags(command *cobra.Command) {
+	command.Flags().StringVar(&filesFromFlagValues.Path, "files_from", "", "Read source paths from the given file, one per line, '-' for stdin")
+}
+
+func GetFilesFromFlagValues() *FilesFromFlagValues {
+	return &filesFromFlagValues
+}
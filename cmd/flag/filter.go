@@ -0,0 +1,76 @@
+package flag
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+type FilterFlagValues struct {
+	Filter *commons.PathFilter
+}
+
+var (
+	filterFlagValues = FilterFlagValues{
+		Filter: commons.NewPathFilter(),
+	}
+)
+
+// filterRuleValue appends rules to the shared filter in the order they are given
+type filterRuleValue struct {
+	ruleType commons.PathFilterRuleType
+	fromFile bool
+	values   []string
+}
+
+func (value *filterRuleValue) String() string {
+	return "[" + strings.Join(value.values, ",") + "]"
+}
+
+func (value *filterRuleValue) Set(val string) error {
+	value.values = append(value.values, val)
+
+	if !value.fromFile {
+		return filterFlagValues.Filter.AddRule(value.ruleType, val)
+	}
+
+	data, err := os.ReadFile(val)
+	if err != nil {
+		return xerrors.Errorf("failed to read file %q: %w", val, err)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			// skip empty lines and comments
+			continue
+		}
+
+		err = filterFlagValues.Filter.AddRule(value.ruleType, line)
+		if err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func (value *filterRuleValue) Type() string {
+	return "string"
+}
+
+func SetFilterFlags(command *cobra.Command) {
+	command.Flags().Var(&filterRuleValue{ruleType: commons.PathFilterRuleInclude}, "include", "Include files or directories matching the pattern, can be given multiple times")
+	command.Flags().Var(&filterRuleValue{ruleType: commons.PathFilterRuleExclude}, "exclude", "Exclude files or directories matching the pattern, can be given multiple times")
+	command.Flags().Var(&filterRuleValue{ruleType: commons.PathFilterRuleExclude, fromFile: true}, "exclude_from", "Read exclude patterns from the file, one per line")
+}
+
+func GetFilterFlagValues() *FilterFlagValues {
+	return &filterFlagValues
+}
@@ -0,0 +1,35 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type FindFlagValues struct {
+	Name       string
+	IgnoreCase bool
+	Type       string
+	Size       string
+	ModifyTime string
+	Metas      []string
+	Exec       string
+	Print0     bool
+}
+
+var (
+	findFlagValues FindFlagValues
+)
+
+func SetFindFlags(command *cobra.Command) {
+	command.Flags().StringVar(&findFlagValues.Name, "name", "", "Find entries whose name matches the glob pattern")
+	command.Flags().BoolVar(&findFlagValues.IgnoreCase, "iname", false, "Match name pattern case-insensitively")
+	command.Flags().StringVar(&findFlagValues.Type, "type", "", "Find only data objects (f) or collections (d)")
+	command.Flags().StringVar(&findFlagValues.Size, "size", "", "Find data objects by size, +N for larger than N, -N for smaller than N, e.g., +10G")
+	command.Flags().StringVar(&findFlagValues.ModifyTime, "mtime", "", "Find entries by modification time, -N for modified within N, +N for modified more than N ago, e.g., -7d")
+	command.Flags().StringArrayVar(&findFlagValues.Metas, "meta", []string{}, "Find entries having metadata attr=value, can be given multiple times")
+	command.Flags().StringVar(&findFlagValues.Exec, "exec", "", "Run the command for each entry found, {} is replaced with the entry path")
+	command.Flags().BoolVar(&findFlagValues.Print0, "print0", false, "Separate output paths with null characters")
+}
+
+func GetFindFlagValues() *FindFlagValues {
+	return &findFlagValues
+}
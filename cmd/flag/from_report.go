@@ -0,0 +1,27 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type FromReportFlagValues struct {
+	ReportPath   string
+	OnlyFailed   bool
+	IncludeNotes []string
+	ExcludeNotes []string
+}
+
+var (
+	fromReportFlagValues FromReportFlagValues
+)
+
+func SetFromReportFlags(command *cobra.Command) {
+	command.Flags().StringVar(&fromReportFlagValues.ReportPath, "from_report", "", "Transfer files recorded in the given transfer report (JSON lines) instead of source paths")
+	command.Flags().BoolVar(&fromReportFlagValues.OnlyFailed, "only_failed", false, "Transfer only files failed in the transfer report given with --from_report")
+	command.Flags().StringSliceVar(&fromReportFlagValues.IncludeNotes, "report_note", []string{}, "Transfer only files having any of the given notes in the transfer report, e.g., 'skip'")
+	command.Flags().StringSliceVar(&fromReportFlagValues.ExcludeNotes, "report_exclude_note", []string{}, "Do not transfer files having any of the given notes in the transfer report")
+}
+
+func GetFromReportFlagValues() *FromReportFlagValues {
+	return &fromReportFlagValues
+}
@@ -0,0 +1,24 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type MetaExportFlagValues struct {
+	Format commons.OutputFormat
+}
+
+var (
+	metaExportFlagValues = MetaExportFlagValues{
+		Format: commons.OutputFormatCSV,
+	}
+)
+
+func SetMetaExportFlags(command *cobra.Command) {
+	command.Flags().Var(&outputFormatValue{format: &metaExportFlagValues.Format}, "format", "Set output format, csv, json or yaml")
+}
+
+func GetMetaExportFlagValues() *MetaExportFlagValues {
+	return &metaExportFlagValues
+}
@@ -0,0 +1,51 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type MetaImportFlagValues struct {
+	Format       commons.OutputFormat
+	Operation    commons.MetaOperation
+	ThreadNumber int
+}
+
+var (
+	metaImportFlagValues = MetaImportFlagValues{
+		Operation: commons.MetaOperationAdd,
+	}
+)
+
+// metaOperationValue validates metadata operation when the flag is parsed
+type metaOperationValue struct {
+	operation *commons.MetaOperation
+}
+
+func (value *metaOperationValue) String() string {
+	return string(*value.operation)
+}
+
+func (value *metaOperationValue) Set(val string) error {
+	operation, err := commons.GetMetaOperation(val)
+	if err != nil {
+		return err
+	}
+
+	*value.operation = operation
+	return nil
+}
+
+func (value *metaOperationValue) Type() string {
+	return "string"
+}
+
+func SetMetaImportFlags(command *cobra.Command) {
+	command.Flags().Var(&outputFormatValue{format: &metaImportFlagValues.Format}, "format", "Set format of the file, csv, json or yaml (default: detected from the file extension)")
+	command.Flags().Var(&metaOperationValue{operation: &metaImportFlagValues.Operation}, "operation", "Set operation for records without an operation, add, set or remove")
+	command.Flags().IntVar(&metaImportFlagValues.ThreadNumber, "thread_num", commons.TransferThreadNumDefault, "Specify the number of threads")
+}
+
+func GetMetaImportFlagValues() *MetaImportFlagValues {
+	return &metaImportFlagValues
+}
@@ -0,0 +1,35 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type MetaMatchFlagValues struct {
+	ValueUpdated bool
+	Value        string
+	UnitUpdated  bool
+	Unit         string
+	Wildcard     bool
+}
+
+var (
+	metaMatchFlagValues MetaMatchFlagValues
+)
+
+func SetMetaMatchFlags(command *cobra.Command) {
+	command.Flags().StringVar(&metaMatchFlagValues.Value, "value", "", "Match metadata by value too")
+	command.Flags().StringVar(&metaMatchFlagValues.Unit, "unit", "", "Match metadata by unit too")
+	command.Flags().BoolVar(&metaMatchFlagValues.Wildcard, "wildcard", false, "Match attribute names, values and units as patterns, % for any string and _ for any character")
+}
+
+func GetMetaMatchFlagValues(command *cobra.Command) *MetaMatchFlagValues {
+	if command.Flags().Changed("value") {
+		metaMatchFlagValues.ValueUpdated = true
+	}
+
+	if command.Flags().Changed("unit") {
+		metaMatchFlagValues.UnitUpdated = true
+	}
+
+	return &metaMatchFlagValues
+}
@@ -0,0 +1,42 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type MetaQueryFlagValues struct {
+	Target          commons.MetaQueryTarget
+	dataObjectInput bool
+	collectionInput bool
+	userInput       bool
+	resourceInput   bool
+}
+
+var (
+	metaQueryFlagValues MetaQueryFlagValues
+)
+
+func SetMetaQueryFlags(command *cobra.Command) {
+	// -d is taken by debug flag, data objects are queried by default
+	command.Flags().BoolVar(&metaQueryFlagValues.dataObjectInput, "data_object", false, "Query data objects (default)")
+	command.Flags().BoolVarP(&metaQueryFlagValues.collectionInput, "collection", "C", false, "Query collections")
+	command.Flags().BoolVarP(&metaQueryFlagValues.userInput, "user", "u", false, "Query users")
+	command.Flags().BoolVarP(&metaQueryFlagValues.resourceInput, "resource", "R", false, "Query resources")
+
+	command.MarkFlagsMutuallyExclusive("data_object", "collection", "user", "resource")
+}
+
+func GetMetaQueryFlagValues() *MetaQueryFlagValues {
+	metaQueryFlagValues.Target = commons.MetaQueryTargetDataObject
+
+	if metaQueryFlagValues.collectionInput {
+		metaQueryFlagValues.Target = commons.MetaQueryTargetCollection
+	} else if metaQueryFlagValues.userInput {
+		metaQueryFlagValues.Target = commons.MetaQueryTargetUser
+	} else if metaQueryFlagValues.resourceInput {
+		metaQueryFlagValues.Target = commons.MetaQueryTargetResource
+	}
+
+	return &metaQueryFlagValues
+}
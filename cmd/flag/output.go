@@ -0,0 +1,47 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type OutputFlagValues struct {
+	Format commons.OutputFormat
+}
+
+var (
+	outputFlagValues = OutputFlagValues{
+		Format: commons.OutputFormatTable,
+	}
+)
+
+// outputFormatValue validates output format when the flag is parsed
+type outputFormatValue struct {
+	format *commons.OutputFormat
+}
+
+func (value *outputFormatValue) String() string {
+	return string(*value.format)
+}
+
+func (value *outputFormatValue) Set(val string) error {
+	format, err := commons.GetOutputFormat(val)
+	if err != nil {
+		return err
+	}
+
+	*value.format = format
+	return nil
+}
+
+func (value *outputFormatValue) Type() string {
+	return "string"
+}
+
+func SetOutputFlags(command *cobra.Command) {
+	command.Flags().Var(&outputFormatValue{format: &outputFlagValues.Format}, "output", "Set output format of listing and info commands, json, yaml, csv or table")
+}
+
+func GetOutputFlagValues() *OutputFlagValues {
+	return &outputFlagValues
+}
@@ -0,0 +1,33 @@
+package flag
+
+import (
+	"testing"
+
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput(t *testing.T) {
+	t.Run("test OutputIsCommonFlag", testOutputIsCommonFlag)
+}
+
+func testOutputIsCommonFlag(t *testing.T) {
+	defer func() {
+		outputFlagValues = OutputFlagValues{Format: commons.OutputFormatTable}
+	}()
+
+	// any command with common flags accepts the output format
+	command := &cobra.Command{Use: "test"}
+	SetCommonFlags(command, true)
+
+	err := command.ParseFlags([]string{"--output", "json"})
+	assert.NoError(t, err)
+	assert.Equal(t, commons.OutputFormatJSON, GetOutputFlagValues().Format)
+
+	command = &cobra.Command{Use: "test"}
+	SetCommonFlags(command, false)
+
+	err = command.ParseFlags([]string{"--output", "xml"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,21 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type PreserveFlagValues struct {
+	Preserve bool
+}
+
+var (
+	preserveFlagValues PreserveFlagValues
+)
+
+func SetPreserveFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&preserveFlagValues.Preserve, "preserve", false, "Preserve modification time and POSIX mode of files")
+}
+
+func GetPreserveFlagValues() *PreserveFlagValues {
+	return &preserveFlagValues
+}
@@ -0,0 +1,23 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type ProvenanceFlagValues struct {
+	Provenance bool
+	Fields     []string
+}
+
+var (
+	provenanceFlagValues ProvenanceFlagValues
+)
+
+func SetProvenanceFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&provenanceFlagValues.Provenance, "provenance", false, "Set provenance metadata gocommands::provenance::<field> to uploaded data objects")
+	command.Flags().StringSliceVar(&provenanceFlagValues.Fields, "provenance_fields", []string{"source_host", "source_path", "source_mtime", "client_checksum", "client_version", "run_id"}, "Set provenance fields to record, comma-separated")
+}
+
+func GetProvenanceFlagValues() *ProvenanceFlagValues {
+	return &provenanceFlagValues
+}
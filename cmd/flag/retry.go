@@ -1,13 +1,17 @@
 package flag
 
 import (
+	"time"
+
+	"github.com/cyverse/gocommands/commons"
 	"github.com/spf13/cobra"
 )
 
 type RetryFlagValues struct {
-	RetryNumber          int
-	RetryIntervalSeconds int
-	RetryChild           bool
+	RetryNumber             int
+	RetryIntervalSeconds    int
+	RetryMaxIntervalSeconds int
+	RetryPolicy             *commons.RetryPolicy
 }
 
 var (
@@ -15,14 +19,16 @@ var (
 )
 
 func SetRetryFlags(command *cobra.Command) {
-	command.Flags().IntVar(&retryFlagValues.RetryNumber, "retry", 0, "Retry if fails")
-	command.Flags().IntVar(&retryFlagValues.RetryIntervalSeconds, "retry_interval", 60, "Retry interval in seconds")
-
-	// this is hidden
-	command.Flags().BoolVar(&retryFlagValues.RetryChild, "retry_child", false, "Set this to retry child process")
-	command.Flags().MarkHidden("retry_child")
+	command.Flags().IntVar(&retryFlagValues.RetryNumber, "retry", 0, "Retry failed files up to the given number of times, only for transient errors")
+	command.Flags().IntVar(&retryFlagValues.RetryIntervalSeconds, "retry_interval", int(commons.RetryInitialBackoffDefault/time.Second), "Initial retry interval in seconds, doubled on every retry")
+	command.Flags().IntVar(&retryFlagValues.RetryMaxIntervalSeconds, "retry_max_interval", int(commons.RetryMaxBackoffDefault/time.Second), "Max retry interval in seconds")
 }
 
 func GetRetryFlagValues() *RetryFlagValues {
+	retryFlagValues.RetryPolicy = nil
+	if retryFlagValues.RetryNumber > 0 {
+		retryFlagValues.RetryPolicy = commons.NewRetryPolicy(retryFlagValues.RetryNumber, time.Duration(retryFlagValues.RetryIntervalSeconds)*time.Second, time.Duration(retryFlagValues.RetryMaxIntervalSeconds)*time.Second)
+	}
+
 	return &retryFlagValues
 }
@@ -0,0 +1,26 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type StreamFlagValues struct {
+	BufferSize      int
+	bufferSizeInput string
+}
+
+var (
+	streamFlagValues StreamFlagValues
+)
+
+func SetStreamFlags(command *cobra.Command) {
+	command.Flags().StringVar(&streamFlagValues.bufferSizeInput, "buffer_size", commons.StreamBufferSizeStringDefault, "Specify buffer size for streaming from stdin or to stdout ('-')")
+}
+
+func GetStreamFlagValues() *StreamFlagValues {
+	size, _ := commons.ParseSize(streamFlagValues.bufferSizeInput)
+	streamFlagValues.BufferSize = int(size)
+
+	return &streamFlagValues
+}
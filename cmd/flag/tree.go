@@ -0,0 +1,21 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type TreeFlagValues struct {
+	Depth int
+}
+
+var (
+	treeFlagValues TreeFlagValues
+)
+
+func SetTreeFlags(command *cobra.Command) {
+	command.Flags().IntVar(&treeFlagValues.Depth, "depth", 0, "Descend at most the given levels of collections, 0 for unlimited")
+}
+
+func GetTreeFlagValues() *TreeFlagValues {
+	return &treeFlagValues
+}
@@ -0,0 +1,25 @@
+package flag
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type UploadMetaFlagValues struct {
+	Metas   []string
+	Sidecar bool
+	Xattrs  bool
+}
+
+var (
+	uploadMetaFlagValues UploadMetaFlagValues
+)
+
+func SetUploadMetaFlags(command *cobra.Command) {
+	command.Flags().StringArrayVar(&uploadMetaFlagValues.Metas, "meta", []string{}, "Add metadata attr=value[=unit] to uploaded data objects, can be given multiple times")
+	command.Flags().BoolVar(&uploadMetaFlagValues.Sidecar, "meta_sidecar", false, "Add metadata in sidecar files <file>.meta.json, .meta.csv or .meta.yaml to uploaded data objects, and in .collection.meta.* to collections, sidecar files are not uploaded")
+	command.Flags().BoolVar(&uploadMetaFlagValues.Xattrs, "xattrs", false, "Add user.* extended attributes of files to uploaded data objects as metadata, without the user. prefix")
+}
+
+func GetUploadMetaFlagValues() *UploadMetaFlagValues {
+	return &uploadMetaFlagValues
+}
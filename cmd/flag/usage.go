@@ -0,0 +1,46 @@
+package flag
+
+import (
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+)
+
+type UsageFlagValues struct {
+	Summarize          bool
+	HumanReadableSizes bool
+	Depth              int
+	GroupBy            commons.UsageGroupBy
+	byResourceInput    bool
+	byOwnerInput       bool
+}
+
+var (
+	usageFlagValues UsageFlagValues
+)
+
+func SetUsageFlags(command *cobra.Command) {
+	command.Flags().BoolVar(&usageFlagValues.Summarize, "summarize", false, "Display only a total for each collection given")
+	command.Flags().BoolVarP(&usageFlagValues.HumanReadableSizes, "human_readable", "H", false, "Display sizes in human-readable format")
+	command.Flags().IntVar(&usageFlagValues.Depth, "depth", -1, "Display totals of sub-collections at most the given levels below, -1 for unlimited")
+	command.Flags().BoolVar(&usageFlagValues.byResourceInput, "by_resource", false, "Break down totals per resource of replicas")
+	command.Flags().BoolVar(&usageFlagValues.byOwnerInput, "by_owner", false, "Break down totals per owner of replicas")
+
+	command.MarkFlagsMutuallyExclusive("summarize", "depth")
+	command.MarkFlagsMutuallyExclusive("by_resource", "by_owner")
+}
+
+func GetUsageFlagValues() *UsageFlagValues {
+	if usageFlagValues.byResourceInput {
+		usageFlagValues.GroupBy = commons.UsageGroupByResource
+	} else if usageFlagValues.byOwnerInput {
+		usageFlagValues.GroupBy = commons.UsageGroupByOwner
+	} else {
+		usageFlagValues.GroupBy = commons.UsageGroupByNone
+	}
+
+	if usageFlagValues.Summarize {
+		usageFlagValues.Depth = 0
+	}
+
+	return &usageFlagValues
+}
@@ -18,6 +18,7 @@ var rootCmd = &cobra.Command{
 	Short:         "Gocommands, a command-line iRODS client",
 	Long:          `Gocommands, a command-line iRODS client.`,
 	RunE:          processCommand,
+	Args:          cobra.NoArgs,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	CompletionOptions: cobra.CompletionOptions{
@@ -53,6 +54,28 @@ func processCommand(command *cobra.Command, args []string) error {
 	return nil
 }
 
+// setUsageErrors makes errors from invalid arguments and flags of the command and its subcommands usage errors
+func setUsageErrors(command *cobra.Command) {
+	command.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return commons.NewUsageError(cmd.CommandPath(), err)
+	})
+
+	if command.Args != nil {
+		validateArgs := command.Args
+		command.Args = func(cmd *cobra.Command, args []string) error {
+			err := validateArgs(cmd, args)
+			if err != nil {
+				return commons.NewUsageError(cmd.CommandPath(), err)
+			}
+			return nil
+		}
+	}
+
+	for _, subCommand := range command.Commands() {
+		setUsageErrors(subCommand)
+	}
+}
+
 func main() {
 	commons.InitTerminalOutput()
 
@@ -79,6 +102,9 @@ func main() {
 	subcmd.AddPwdCommand(rootCmd)
 	subcmd.AddCdCommand(rootCmd)
 	subcmd.AddLsCommand(rootCmd)
+	subcmd.AddTreeCommand(rootCmd)
+	subcmd.AddFindCommand(rootCmd)
+	subcmd.AddDuCommand(rootCmd)
 	subcmd.AddTouchCommand(rootCmd)
 	subcmd.AddCpCommand(rootCmd)
 	subcmd.AddMvCommand(rootCmd)
@@ -89,6 +115,8 @@ func main() {
 	subcmd.AddMkdirCommand(rootCmd)
 	subcmd.AddRmCommand(rootCmd)
 	subcmd.AddRmdirCommand(rootCmd)
+	subcmd.AddChmodCommand(rootCmd)
+	subcmd.AddInheritCommand(rootCmd)
 	subcmd.AddBunCommand(rootCmd)
 	subcmd.AddBputCommand(rootCmd)
 	subcmd.AddSvrinfoCommand(rootCmd)
@@ -96,14 +124,22 @@ func main() {
 	subcmd.AddLsmetaCommand(rootCmd)
 	subcmd.AddAddmetaCommand(rootCmd)
 	subcmd.AddRmmetaCommand(rootCmd)
+	subcmd.AddSetmetaCommand(rootCmd)
+	subcmd.AddModmetaCommand(rootCmd)
+	subcmd.AddMetadataCommand(rootCmd)
+	subcmd.AddQmetaCommand(rootCmd)
 	subcmd.AddCopySftpIdCommand(rootCmd)
 	subcmd.AddLsticketCommand(rootCmd)
 	subcmd.AddRmticketCommand(rootCmd)
 	subcmd.AddMkticketCommand(rootCmd)
 	subcmd.AddModticketCommand(rootCmd)
 	subcmd.AddBcleanCommand(rootCmd)
+	subcmd.AddChecksumCommand(rootCmd)
+	subcmd.AddDiffCommand(rootCmd)
 	subcmd.AddUpgradeCommand(rootCmd)
 
+	setUsageErrors(rootCmd)
+
 	err := Execute()
 	if err != nil {
 		logger.Errorf("%+v", err)
@@ -112,8 +148,19 @@ func main() {
 			commons.PrintErrorf("%+v\n", err)
 		}
 
-		if os.IsNotExist(err) {
+		exitCode := commons.ExitCodeError
+
+		if commons.IsUsageError(err) {
+			var usageError *commons.UsageError
+			if errors.As(err, &usageError) {
+				commons.PrintErrorf("%s\nRun '%s --help' for usage.\n", usageError.Err.Error(), usageError.CommandPath)
+			} else {
+				commons.PrintErrorf("Invalid arguments or flags!\n")
+			}
+			exitCode = commons.ExitCodeUsage
+		} else if os.IsNotExist(err) {
 			commons.PrintErrorf("File or directory not found!\n")
+			exitCode = commons.ExitCodeNotFound
 		} else if irodsclient_types.IsConnectionConfigError(err) {
 			var connectionConfigError *irodsclient_types.ConnectionConfigError
 			if errors.As(err, &connectionConfigError) {
@@ -121,8 +168,10 @@ func main() {
 			} else {
 				commons.PrintErrorf("Failed to establish a connection to iRODS server!\n")
 			}
+			exitCode = commons.ExitCodeConnection
 		} else if irodsclient_types.IsConnectionError(err) {
 			commons.PrintErrorf("Failed to establish a connection to iRODS server!\n")
+			exitCode = commons.ExitCodeConnection
 		} else if irodsclient_types.IsConnectionPoolFullError(err) {
 			var connectionPoolFullError *irodsclient_types.ConnectionPoolFullError
 			if errors.As(err, &connectionPoolFullError) {
@@ -130,6 +179,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("Failed to establish a new connection to iRODS server as connection pool is full!\n")
 			}
+			exitCode = commons.ExitCodeConnection
 		} else if irodsclient_types.IsAuthError(err) {
 			var authError *irodsclient_types.AuthError
 			if errors.As(err, &authError) {
@@ -137,6 +187,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("Authentication failed!\n")
 			}
+			exitCode = commons.ExitCodeAuth
 		} else if irodsclient_types.IsFileNotFoundError(err) {
 			var fileNotFoundError *irodsclient_types.FileNotFoundError
 			if errors.As(err, &fileNotFoundError) {
@@ -144,6 +195,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("File or directory is not found!\n")
 			}
+			exitCode = commons.ExitCodeNotFound
 		} else if irodsclient_types.IsCollectionNotEmptyError(err) {
 			var collectionNotEmptyError *irodsclient_types.CollectionNotEmptyError
 			if errors.As(err, &collectionNotEmptyError) {
@@ -151,6 +203,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("Directory is not empty!\n")
 			}
+			exitCode = commons.ExitCodeAlreadyExists
 		} else if irodsclient_types.IsFileAlreadyExistError(err) {
 			var fileAlreadyExistError *irodsclient_types.FileAlreadyExistError
 			if errors.As(err, &fileAlreadyExistError) {
@@ -158,6 +211,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("File or directory already exists!\n")
 			}
+			exitCode = commons.ExitCodeAlreadyExists
 		} else if irodsclient_types.IsTicketNotFoundError(err) {
 			var ticketNotFoundError *irodsclient_types.TicketNotFoundError
 			if errors.As(err, &ticketNotFoundError) {
@@ -165,6 +219,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("Ticket is not found!\n")
 			}
+			exitCode = commons.ExitCodeNotFound
 		} else if irodsclient_types.IsUserNotFoundError(err) {
 			var userNotFoundError *irodsclient_types.UserNotFoundError
 			if errors.As(err, &userNotFoundError) {
@@ -172,6 +227,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("User is not found!\n")
 			}
+			exitCode = commons.ExitCodeNotFound
 		} else if irodsclient_types.IsIRODSError(err) {
 			var irodsError *irodsclient_types.IRODSError
 			if errors.As(err, &irodsError) {
@@ -186,6 +242,7 @@ func main() {
 			} else {
 				commons.PrintErrorf("Destination is not a directory!\n")
 			}
+			exitCode = commons.ExitCodeUsage
 		} else if commons.IsNotFileError(err) {
 			var notFileError *commons.NotFileError
 			if errors.As(err, &notFileError) {
@@ -193,10 +250,29 @@ func main() {
 			} else {
 				commons.PrintErrorf("Destination is not a file!\n")
 			}
+			exitCode = commons.ExitCodeUsage
+		} else if commons.IsChecksumMismatchError(err) {
+			var checksumMismatchError *commons.ChecksumMismatchError
+			if errors.As(err, &checksumMismatchError) {
+				commons.PrintErrorf("Checksum of %q does not match (%s)!\n", checksumMismatchError.Path, checksumMismatchError.Reason)
+			} else {
+				commons.PrintErrorf("Checksum does not match!\n")
+			}
+			exitCode = commons.ExitCodeChecksumMismatch
+		} else if commons.IsJobFailuresError(err) {
+			var jobFailuresError *commons.JobFailuresError
+			if errors.As(err, &jobFailuresError) {
+				commons.PrintJobFailures(jobFailuresError.Failures)
+			} else {
+				commons.PrintErrorf("Failed to process some files!\n")
+			}
+			exitCode = commons.ExitCodePartialFailure
+		} else if commons.IsDifferenceFoundError(err) {
+			// differences are already printed
 		} else {
 			commons.PrintErrorf("Unexpected error!\nError Trace:\n  - %+v\n", err)
 		}
 
-		os.Exit(1)
+		os.Exit(int(exitCode))
 	}
 }
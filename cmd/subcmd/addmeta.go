@@ -2,6 +2,7 @@ package subcmd
 
 import (
 	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
 	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/cyverse/gocommands/cmd/flag"
 	"github.com/cyverse/gocommands/commons"
@@ -14,7 +15,7 @@ var addmetaCmd = &cobra.Command{
 	Use:     "addmeta [attribute name] [attribute value] [attribute unit (optional)]",
 	Aliases: []string{"add_meta", "add_metadata"},
 	Short:   "Add a metadata",
-	Long:    `This adds a metadata to the given collection, data object, user, or a resource.`,
+	Long:    `This adds a metadata to the given collection, data object, user, or a resource. Use -r to add it to all data objects and collections under the collection too.`,
 	RunE:    processAddmetaCommand,
 	Args:    cobra.RangeArgs(2, 3),
 }
@@ -24,6 +25,7 @@ func AddAddmetaCommand(rootCmd *cobra.Command) {
 	flag.SetCommonFlags(addmetaCmd, true)
 
 	flag.SetTargetObjectFlags(addmetaCmd)
+	flag.SetRecursiveFlags(addmetaCmd, false)
 
 	rootCmd.AddCommand(addmetaCmd)
 }
@@ -41,6 +43,7 @@ type AddMetaCommand struct {
 	command *cobra.Command
 
 	targetObjectFlagValues *flag.TargetObjectFlagValues
+	recursiveFlagValues    *flag.RecursiveFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -55,6 +58,11 @@ func NewAddMetaCommand(command *cobra.Command, args []string) (*AddMetaCommand,
 		command: command,
 
 		targetObjectFlagValues: flag.GetTargetObjectFlagValues(command),
+		recursiveFlagValues:    flag.GetRecursiveFlagValues(),
+	}
+
+	if addMeta.recursiveFlagValues.Recursive && !addMeta.targetObjectFlagValues.PathUpdated {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("recursive is only supported for a path"))
 	}
 
 	// get avu
@@ -128,6 +136,10 @@ func (addMeta *AddMetaCommand) addMetaToPath(targetPath string, attribute string
 	zone := commons.GetZone()
 	targetPath = commons.MakeIRODSPath(cwd, home, zone, targetPath)
 
+	if addMeta.recursiveFlagValues.Recursive {
+		return addMeta.addMetaToPathRecursively(targetPath, attribute, value, unit)
+	}
+
 	logger.Debugf("add metadata to path %q (attr %q, value %q, unit %q)", targetPath, attribute, value, unit)
 
 	err := addMeta.filesystem.AddMetadata(targetPath, attribute, value, unit)
@@ -138,6 +150,42 @@ func (addMeta *AddMetaCommand) addMetaToPath(targetPath string, attribute string
 	return nil
 }
 
+func (addMeta *AddMetaCommand) addMetaToPathRecursively(targetPath string, attribute string, value string, unit string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "AddMetaCommand",
+		"function": "addMetaToPathRecursively",
+	})
+
+	targets, err := commons.ListMetaTargets(addMeta.filesystem, targetPath, true)
+	if err != nil {
+		return xerrors.Errorf("failed to list entries in %q: %w", targetPath, err)
+	}
+
+	connection, err := addMeta.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer addMeta.filesystem.ReturnMetadataConnection(connection)
+
+	for _, target := range targets {
+		logger.Debugf("add metadata to path %q (attr %q, value %q, unit %q)", target.Name, attribute, value, unit)
+
+		err = commons.AddMetadata(connection, target, attribute, value, unit)
+		if err != nil {
+			if irodsclient_types.GetIRODSErrorCode(err) == irodsclient_common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME {
+				// already has the same metadata
+				logger.Debugf("skip adding existing metadata to path %q", target.Name)
+				continue
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (addMeta *AddMetaCommand) addMetaToUser(username string, attribute string, value string, unit string) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
@@ -24,6 +24,7 @@ func AddBcleanCommand(rootCmd *cobra.Command) {
 
 	flag.SetBundleTransferFlags(bcleanCmd, false)
 	flag.SetForceFlags(bcleanCmd, false)
+	flag.SetDryRunFlags(bcleanCmd)
 
 	rootCmd.AddCommand(bcleanCmd)
 }
@@ -42,6 +43,7 @@ type BcleanCommand struct {
 
 	forceFlagValues          *flag.ForceFlagValues
 	bundleTransferFlagValues *flag.BundleTransferFlagValues
+	dryRunFlagValues         *flag.DryRunFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -55,6 +57,7 @@ func NewBcleanCommand(command *cobra.Command, args []string) (*BcleanCommand, er
 
 		forceFlagValues:          flag.GetForceFlagValues(),
 		bundleTransferFlagValues: flag.GetBundleTransferFlagValues(),
+		dryRunFlagValues:         flag.GetDryRunFlagValues(),
 	}
 
 	// path
@@ -95,17 +98,17 @@ func (bclean *BcleanCommand) Process() error {
 
 	// run
 	// clear local
-	commons.CleanUpOldLocalBundles(bclean.bundleTransferFlagValues.LocalTempPath, bclean.forceFlagValues.Force)
+	commons.CleanUpOldLocalBundles(bclean.bundleTransferFlagValues.LocalTempPath, bclean.forceFlagValues.Force, bclean.dryRunFlagValues.DryRun)
 
 	// clear remote
 	if len(bclean.bundleTransferFlagValues.IRODSTempPath) > 0 {
 		logger.Debugf("clearing an irods temp directory %q", bclean.bundleTransferFlagValues.IRODSTempPath)
 
-		commons.CleanUpOldIRODSBundles(bclean.filesystem, bclean.bundleTransferFlagValues.IRODSTempPath, true, bclean.forceFlagValues.Force)
+		commons.CleanUpOldIRODSBundles(bclean.filesystem, bclean.bundleTransferFlagValues.IRODSTempPath, true, bclean.forceFlagValues.Force, bclean.dryRunFlagValues.DryRun)
 	} else {
 		userHome := commons.GetHomeDir()
 		homeStagingDir := commons.GetDefaultStagingDir(userHome)
-		commons.CleanUpOldIRODSBundles(bclean.filesystem, homeStagingDir, true, bclean.forceFlagValues.Force)
+		commons.CleanUpOldIRODSBundles(bclean.filesystem, homeStagingDir, true, bclean.forceFlagValues.Force, bclean.dryRunFlagValues.DryRun)
 	}
 
 	for _, targetPath := range bclean.targetPaths {
@@ -130,12 +133,12 @@ func (bclean *BcleanCommand) cleanOne(targetPath string) {
 	if commons.IsStagingDirInTargetPath(targetPath) {
 		// target is staging dir
 		logger.Debugf("clearing an irods target directory %q", targetPath)
-		commons.CleanUpOldIRODSBundles(bclean.filesystem, targetPath, true, bclean.forceFlagValues.Force)
+		commons.CleanUpOldIRODSBundles(bclean.filesystem, targetPath, true, bclean.forceFlagValues.Force, bclean.dryRunFlagValues.DryRun)
 		return
 	}
 
 	stagingDirPath := commons.GetDefaultStagingDirInTargetPath(targetPath)
 	logger.Debugf("clearing an irods target directory %q", stagingDirPath)
 
-	commons.CleanUpOldIRODSBundles(bclean.filesystem, stagingDirPath, true, bclean.forceFlagValues.Force)
+	commons.CleanUpOldIRODSBundles(bclean.filesystem, stagingDirPath, true, bclean.forceFlagValues.Force, bclean.dryRunFlagValues.DryRun)
 }
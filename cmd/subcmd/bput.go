@@ -25,7 +25,7 @@ var bputCmd = &cobra.Command{
 	Use:     "bput [local file1] [local file2] [local dir1] ... [collection]",
 	Aliases: []string{"bundle_put"},
 	Short:   "Bundle-upload files or directories",
-	Long:    `This uploads files or directories to the given iRODS collection. The files or directories are bundled with TAR to maximize data transfer bandwidth, then extracted in the iRODS.`,
+	Long:    `This uploads files or directories to the given iRODS collection. The files or directories are bundled with TAR to maximize data transfer bandwidth, then extracted in the iRODS. Use --provenance to record provenance metadata on every uploaded data object.`,
 	RunE:    processBputCommand,
 	Args:    cobra.MinimumNArgs(1),
 }
@@ -40,11 +40,15 @@ func AddBputCommand(rootCmd *cobra.Command) {
 	flag.SetRecursiveFlags(bputCmd, true)
 	flag.SetProgressFlags(bputCmd)
 	flag.SetRetryFlags(bputCmd)
+	flag.SetContinueOnErrorFlags(bputCmd)
 	flag.SetDifferentialTransferFlags(bputCmd, true)
 	flag.SetNoRootFlags(bputCmd)
 	flag.SetSyncFlags(bputCmd, false)
 	flag.SetHiddenFileFlags(bputCmd)
+	flag.SetFilterFlags(bputCmd)
+	flag.SetDryRunFlags(bputCmd)
 	flag.SetTransferReportFlags(bputCmd)
+	flag.SetProvenanceFlags(bputCmd)
 
 	rootCmd.AddCommand(bputCmd)
 }
@@ -66,13 +70,17 @@ type BputCommand struct {
 	parallelTransferFlagValues     *flag.ParallelTransferFlagValues
 	progressFlagValues             *flag.ProgressFlagValues
 	retryFlagValues                *flag.RetryFlagValues
+	continueOnErrorFlagValues      *flag.ContinueOnErrorFlagValues
 	differentialTransferFlagValues *flag.DifferentialTransferFlagValues
 	checksumFlagValues             *flag.ChecksumFlagValues
 	noRootFlagValues               *flag.NoRootFlagValues
 	syncFlagValues                 *flag.SyncFlagValues
 	postTransferFlagValues         *flag.PostTransferFlagValues
 	hiddenFileFlagValues           *flag.HiddenFileFlagValues
+	filterFlagValues               *flag.FilterFlagValues
+	dryRunFlagValues               *flag.DryRunFlagValues
 	transferReportFlagValues       *flag.TransferReportFlagValues
+	provenanceFlagValues           *flag.ProvenanceFlagValues
 
 	maxConnectionNum int
 
@@ -81,6 +89,7 @@ type BputCommand struct {
 
 	sourcePaths []string
 	targetPath  string
+	provenance  *commons.Provenance
 
 	bundleTransferManager *commons.BundleTransferManager
 	transferReportManager *commons.TransferReportManager
@@ -96,13 +105,17 @@ func NewBputCommand(command *cobra.Command, args []string) (*BputCommand, error)
 		parallelTransferFlagValues:     flag.GetParallelTransferFlagValues(),
 		progressFlagValues:             flag.GetProgressFlagValues(),
 		retryFlagValues:                flag.GetRetryFlagValues(),
+		continueOnErrorFlagValues:      flag.GetContinueOnErrorFlagValues(),
 		differentialTransferFlagValues: flag.GetDifferentialTransferFlagValues(),
 		checksumFlagValues:             flag.GetChecksumFlagValues(),
 		noRootFlagValues:               flag.GetNoRootFlagValues(),
 		syncFlagValues:                 flag.GetSyncFlagValues(),
 		postTransferFlagValues:         flag.GetPostTransferFlagValues(),
 		hiddenFileFlagValues:           flag.GetHiddenFileFlagValues(),
+		filterFlagValues:               flag.GetFilterFlagValues(),
+		dryRunFlagValues:               flag.GetDryRunFlagValues(),
 		transferReportFlagValues:       flag.GetTransferReportFlagValues(command),
+		provenanceFlagValues:           flag.GetProvenanceFlagValues(),
 
 		updatedPathMap: map[string]bool{},
 	}
@@ -122,6 +135,18 @@ func NewBputCommand(command *cobra.Command, args []string) (*BputCommand, error)
 		return nil, xerrors.Errorf("failed to put multiple source collections without creating root directory")
 	}
 
+	err := bput.differentialTransferFlagValues.Validate()
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
+	// provenance
+	provenance, err := newProvenance(command, bput.provenanceFlagValues)
+	if err != nil {
+		return nil, err
+	}
+	bput.provenance = provenance
+
 	return bput, nil
 }
 
@@ -150,16 +175,7 @@ func (bput *BputCommand) Process() error {
 	// clear local
 	// delete local bundles before entering to retry
 	if bput.bundleTransferFlagValues.ClearOld {
-		commons.CleanUpOldLocalBundles(bput.bundleTransferFlagValues.LocalTempPath, true)
-	}
-
-	// handle retry
-	if bput.retryFlagValues.RetryNumber > 0 && !bput.retryFlagValues.RetryChild {
-		err = commons.RunWithRetry(bput.retryFlagValues.RetryNumber, bput.retryFlagValues.RetryIntervalSeconds)
-		if err != nil {
-			return xerrors.Errorf("failed to run with retry %d: %w", bput.retryFlagValues.RetryNumber, err)
-		}
-		return nil
+		commons.CleanUpOldLocalBundles(bput.bundleTransferFlagValues.LocalTempPath, true, bput.dryRunFlagValues.DryRun)
 	}
 
 	// Create a file system
@@ -171,12 +187,17 @@ func (bput *BputCommand) Process() error {
 	defer bput.filesystem.Release()
 
 	// transfer report
-	bput.transferReportManager, err = commons.NewTransferReportManager(bput.transferReportFlagValues.Report, bput.transferReportFlagValues.ReportPath, bput.transferReportFlagValues.ReportToStdout)
+	bput.transferReportManager, err = commons.NewTransferReportManager(bput.transferReportFlagValues.Report, bput.transferReportFlagValues.ReportPath, bput.transferReportFlagValues.ReportToStdout, bput.dryRunFlagValues.DryRun)
 	if err != nil {
 		return xerrors.Errorf("failed to create transfer report manager: %w", err)
 	}
 	defer bput.transferReportManager.Release()
 
+	if bput.provenance != nil {
+		logger.Infof("recording provenance with run ID %q", bput.provenance.RunID)
+		bput.transferReportManager.SetRunID(bput.provenance.RunID)
+	}
+
 	// run
 	// target must be a dir
 	err = bput.ensureTargetIsDir(bput.targetPath)
@@ -185,17 +206,21 @@ func (bput *BputCommand) Process() error {
 	}
 
 	// get staging path
-	stagingDirPath, err := bput.getStagingDir(bput.targetPath)
-	if err != nil {
-		return err
-	}
-
-	// clear old irods bundles
-	if bput.bundleTransferFlagValues.ClearOld {
-		logger.Debugf("clearing an irods temp directory %q", stagingDirPath)
-		err = commons.CleanUpOldIRODSBundles(bput.filesystem, stagingDirPath, false, true)
+	// staging path is not used in dry run
+	stagingDirPath := ""
+	if !bput.dryRunFlagValues.DryRun {
+		stagingDirPath, err = bput.getStagingDir(bput.targetPath)
 		if err != nil {
-			return xerrors.Errorf("failed to clean up old irods bundle files in %q: %w", stagingDirPath, err)
+			return err
+		}
+
+		// clear old irods bundles
+		if bput.bundleTransferFlagValues.ClearOld {
+			logger.Debugf("clearing an irods temp directory %q", stagingDirPath)
+			err = commons.CleanUpOldIRODSBundles(bput.filesystem, stagingDirPath, false, true, false)
+			if err != nil {
+				return xerrors.Errorf("failed to clean up old irods bundle files in %q: %w", stagingDirPath, err)
+			}
 		}
 	}
 
@@ -213,7 +238,12 @@ func (bput *BputCommand) Process() error {
 
 	// bundle transfer manager
 	bput.bundleTransferManager = commons.NewBundleTransferManager(bput.filesystem, bput.transferReportManager, bput.targetPath, localBundleRootPath, bput.bundleTransferFlagValues.MinFileNum, bput.bundleTransferFlagValues.MaxFileNum, bput.bundleTransferFlagValues.MaxFileSize, bput.parallelTransferFlagValues.SingleThread, bput.parallelTransferFlagValues.ThreadNumber, bput.parallelTransferFlagValues.RedirectToResource, bput.parallelTransferFlagValues.Icat, bput.bundleTransferFlagValues.LocalTempPath, stagingDirPath, bput.bundleTransferFlagValues.NoBulkRegistration, bput.progressFlagValues.ShowProgress, bput.progressFlagValues.ShowFullPath)
-	bput.bundleTransferManager.Start()
+	bput.bundleTransferManager.SetRetryPolicy(bput.retryFlagValues.RetryPolicy)
+	bput.bundleTransferManager.SetContinueOnError(bput.continueOnErrorFlagValues.ContinueOnError)
+	bput.bundleTransferManager.SetProvenance(bput.provenance)
+	if !bput.dryRunFlagValues.DryRun {
+		bput.bundleTransferManager.Start()
+	}
 
 	// run
 	for _, sourcePath := range bput.sourcePaths {
@@ -223,10 +253,12 @@ func (bput *BputCommand) Process() error {
 		}
 	}
 
-	bput.bundleTransferManager.DoneScheduling()
-	err = bput.bundleTransferManager.Wait()
-	if err != nil {
-		return xerrors.Errorf("failed to bundle-put: %w", err)
+	if !bput.dryRunFlagValues.DryRun {
+		bput.bundleTransferManager.DoneScheduling()
+		err = bput.bundleTransferManager.Wait()
+		if err != nil {
+			return xerrors.Errorf("failed to bundle-put: %w", err)
+		}
 	}
 
 	// delete on success
@@ -377,6 +409,12 @@ func (bput *BputCommand) getStagingDir(targetPath string) (string, error) {
 }
 
 func (bput *BputCommand) bputOne(sourcePath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "BputCommand",
+		"function": "bputOne",
+	})
+
 	sourcePath = commons.MakeLocalPath(sourcePath)
 
 	sourceStat, err := os.Stat(sourcePath)
@@ -388,13 +426,25 @@ func (bput *BputCommand) bputOne(sourcePath string) error {
 		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
 	}
 
+	// target paths are relative to the bundle root, so are filter paths
+	filterRootPath := bput.bundleTransferManager.GetLocalBundleRootPath()
+	if bput.filterFlagValues.Filter.IsExcluded(filterRootPath, sourcePath, sourceStat.IsDir()) {
+		logger.Debugf("skip uploading %q, excluded by filters", sourcePath)
+		return nil
+	}
+
 	if sourceStat.IsDir() {
 		// dir
-		return bput.putDir(sourceStat, sourcePath)
+		return bput.putDir(sourceStat, sourcePath, filterRootPath)
 	}
 
 	// file
-	return bput.putFile(sourceStat, sourcePath)
+	err = bput.putFile(sourceStat, sourcePath)
+	if err != nil {
+		return bput.handleScanFailure(sourcePath, false, err)
+	}
+
+	return nil
 }
 
 func (bput *BputCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string) error {
@@ -404,6 +454,28 @@ func (bput *BputCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string)
 		"function": "schedulePut",
 	})
 
+	if bput.dryRunFlagValues.DryRun {
+		targetPath, err := bput.bundleTransferManager.GetTargetPath(sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to get target path for source %q: %w", sourcePath, err)
+		}
+
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodBput,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourcePath,
+			SourceSize: sourceStat.Size(),
+			DestPath:   targetPath,
+		}
+
+		bput.transferReportManager.AddFile(reportFile)
+
+		logger.Debugf("skip scheduling a file upload %q, dry run", sourcePath)
+		return nil
+	}
+
 	err := bput.bundleTransferManager.Schedule(sourceStat, sourcePath)
 	if err != nil {
 		return xerrors.Errorf("failed to schedule a file %q: %w", sourcePath, err)
@@ -444,7 +516,10 @@ func (bput *BputCommand) putFile(sourceStat fs.FileInfo, sourcePath string) erro
 		if bput.syncFlagValues.Sync {
 			// if it is sync, remove
 			if bput.forceFlagValues.Force {
-				removeErr := bput.filesystem.RemoveDir(targetPath, true, true)
+				var removeErr error
+				if !bput.dryRunFlagValues.DryRun {
+					removeErr = bput.filesystem.RemoveDir(targetPath, true, true)
+				}
 
 				now := time.Now()
 				reportFile := &commons.TransferReportFile{
@@ -465,7 +540,10 @@ func (bput *BputCommand) putFile(sourceStat fs.FileInfo, sourcePath string) erro
 				// ask
 				overwrite := commons.InputYN(fmt.Sprintf("overwriting a file %q, but directory exists. Overwrite?", targetPath))
 				if overwrite {
-					removeErr := bput.filesystem.RemoveDir(targetPath, true, true)
+					var removeErr error
+					if !bput.dryRunFlagValues.DryRun {
+						removeErr = bput.filesystem.RemoveDir(targetPath, true, true)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -492,7 +570,8 @@ func (bput *BputCommand) putFile(sourceStat fs.FileInfo, sourcePath string) erro
 	}
 
 	if bput.differentialTransferFlagValues.DifferentialTransfer {
-		if bput.differentialTransferFlagValues.NoHash {
+		switch bput.differentialTransferFlagValues.Mode {
+		case commons.DiffModeSize:
 			if targetEntry.Size == sourceStat.Size() {
 				// skip
 				now := time.Now()
@@ -515,7 +594,33 @@ func (bput *BputCommand) putFile(sourceStat fs.FileInfo, sourcePath string) erro
 				logger.Debugf("skip uploading a file %q to %q. The file already exists!", sourcePath, targetPath)
 				return nil
 			}
-		} else {
+		case commons.DiffModeMtime, commons.DiffModeSizeMtime:
+			if bput.differentialTransferFlagValues.Mode == commons.DiffModeSizeMtime && targetEntry.Size != sourceStat.Size() {
+				break
+			}
+
+			if commons.IsModTimeUpToDate(sourceStat.ModTime(), targetEntry.ModifyTime, bput.differentialTransferFlagValues.MtimeTolerance) {
+				// skip
+				now := time.Now()
+				reportFile := &commons.TransferReportFile{
+					Method:            commons.TransferMethodPut,
+					StartAt:           now,
+					EndAt:             now,
+					SourcePath:        sourcePath,
+					SourceSize:        sourceStat.Size(),
+					DestPath:          targetEntry.Path,
+					DestSize:          targetEntry.Size,
+					ChecksumAlgorithm: string(targetEntry.CheckSumAlgorithm),
+					Notes:             []string{"differential", string(bput.differentialTransferFlagValues.Mode), "not older", "skip"},
+				}
+
+				bput.transferReportManager.AddFile(reportFile)
+
+				commons.Printf("skip uploading a file %q to %q. The data object is up to date!\n", sourcePath, targetPath)
+				logger.Debugf("skip uploading a file %q to %q. The data object is up to date!", sourcePath, targetPath)
+				return nil
+			}
+		default:
 			if targetEntry.Size == sourceStat.Size() {
 				// compare hash
 				if len(targetEntry.CheckSum) > 0 {
@@ -583,7 +688,99 @@ func (bput *BputCommand) putFile(sourceStat fs.FileInfo, sourcePath string) erro
 	return bput.schedulePut(sourceStat, sourcePath)
 }
 
-func (bput *BputCommand) putDir(sourceStat fs.FileInfo, sourcePath string) error {
+// handleScanFailure adds a file or a directory failed before it is scheduled to the report and failures of bundles
+// it returns nil to continue with other files if continue_on_error is set, or the error otherwise
+func (bput *BputCommand) handleScanFailure(sourcePath string, isDir bool, err error) error {
+	notes := []string{"failed", "scan"}
+	if isDir {
+		notes = append(notes, "directory")
+	}
+
+	// target path is left empty if it cannot be determined
+	targetPath, _ := bput.bundleTransferManager.GetTargetPath(sourcePath)
+
+	now := time.Now()
+	reportFile := &commons.TransferReportFile{
+		Method:     commons.TransferMethodBput,
+		StartAt:    now,
+		EndAt:      now,
+		SourcePath: sourcePath,
+		DestPath:   targetPath,
+		Error:      err,
+		Notes:      notes,
+	}
+
+	bput.transferReportManager.AddFile(reportFile)
+
+	return bput.bundleTransferManager.AddFailure(sourcePath, err)
+}
+
+func (bput *BputCommand) putDir(sourceStat fs.FileInfo, sourcePath string, filterRootPath string) error {
+	err := bput.prepareTargetDir(sourcePath)
+	if err != nil {
+		return bput.handleScanFailure(sourcePath, true, err)
+	}
+
+	// get entries
+	entries, err := os.ReadDir(sourcePath)
+	if err != nil {
+		return bput.handleScanFailure(sourcePath, true, xerrors.Errorf("failed to read a directory %q: %w", sourcePath, err))
+	}
+
+	for _, entry := range entries {
+		if bput.hiddenFileFlagValues.Exclude {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+		}
+
+		entryPath := filepath.Join(sourcePath, entry.Name())
+
+		entryStat, err := os.Stat(entryPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				err = irodsclient_types.NewFileNotFoundError(entryPath)
+			} else {
+				err = xerrors.Errorf("failed to stat %q: %w", entryPath, err)
+			}
+
+			err = bput.handleScanFailure(entryPath, false, err)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if bput.filterFlagValues.Filter.IsExcluded(filterRootPath, entryPath, entryStat.IsDir()) {
+			continue
+		}
+
+		if entryStat.IsDir() {
+			// dir, failures under it are handled in it
+			err = bput.putDir(entryStat, entryPath, filterRootPath)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// file
+		err = bput.putFile(entryStat, entryPath)
+		if err != nil {
+			err = bput.handleScanFailure(entryPath, false, err)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// prepareTargetDir makes the target collection of the directory, or replaces a data object in the way on sync
+func (bput *BputCommand) prepareTargetDir(sourcePath string) error {
 	targetPath, err := bput.bundleTransferManager.GetTargetPath(sourcePath)
 	if err != nil {
 		return xerrors.Errorf("failed to get target path for source %q: %w", sourcePath, err)
@@ -595,9 +792,11 @@ func (bput *BputCommand) putDir(sourceStat fs.FileInfo, sourcePath string) error
 	if err != nil {
 		if irodsclient_types.IsFileNotFoundError(err) {
 			// target does not exist
-			err = bput.filesystem.MakeDir(targetPath, true)
-			if err != nil {
-				return xerrors.Errorf("failed to make a collection %q: %w", targetPath, err)
+			if !bput.dryRunFlagValues.DryRun {
+				err = bput.filesystem.MakeDir(targetPath, true)
+				if err != nil {
+					return xerrors.Errorf("failed to make a collection %q: %w", targetPath, err)
+				}
 			}
 
 			now := time.Now()
@@ -620,7 +819,10 @@ func (bput *BputCommand) putDir(sourceStat fs.FileInfo, sourcePath string) error
 			if bput.syncFlagValues.Sync {
 				// if it is sync, remove
 				if bput.forceFlagValues.Force {
-					removeErr := bput.filesystem.RemoveFile(targetPath, true)
+					var removeErr error
+					if !bput.dryRunFlagValues.DryRun {
+						removeErr = bput.filesystem.RemoveFile(targetPath, true)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -641,7 +843,10 @@ func (bput *BputCommand) putDir(sourceStat fs.FileInfo, sourcePath string) error
 					// ask
 					overwrite := commons.InputYN(fmt.Sprintf("overwriting a directory %q, but file exists. Overwrite?", targetPath))
 					if overwrite {
-						removeErr := bput.filesystem.RemoveFile(targetPath, true)
+						var removeErr error
+						if !bput.dryRunFlagValues.DryRun {
+							removeErr = bput.filesystem.RemoveFile(targetPath, true)
+						}
 
 						now := time.Now()
 						reportFile := &commons.TransferReportFile{
@@ -668,45 +873,6 @@ func (bput *BputCommand) putDir(sourceStat fs.FileInfo, sourcePath string) error
 		}
 	}
 
-	// get entries
-	entries, err := os.ReadDir(sourcePath)
-	if err != nil {
-		return xerrors.Errorf("failed to read a directory %q: %w", sourcePath, err)
-	}
-
-	for _, entry := range entries {
-		if bput.hiddenFileFlagValues.Exclude {
-			if strings.HasPrefix(entry.Name(), ".") {
-				continue
-			}
-		}
-
-		entryPath := filepath.Join(sourcePath, entry.Name())
-
-		entryStat, err := os.Stat(entryPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return irodsclient_types.NewFileNotFoundError(entryPath)
-			}
-
-			return xerrors.Errorf("failed to stat %q: %w", entryPath, err)
-		}
-
-		if entryStat.IsDir() {
-			// dir
-			err = bput.putDir(entryStat, entryPath)
-			if err != nil {
-				return err
-			}
-		} else {
-			// file
-			err = bput.putFile(entryStat, entryPath)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
 	return nil
 }
 
@@ -716,6 +882,20 @@ func (bput *BputCommand) deleteOnSuccess(sourcePath string) error {
 		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
 	}
 
+	if bput.dryRunFlagValues.DryRun {
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodDelete,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourcePath,
+			Notes:      []string{"delete_on_success"},
+		}
+
+		bput.transferReportManager.AddFile(reportFile)
+		return nil
+	}
+
 	if sourceStat.IsDir() {
 		return os.RemoveAll(sourcePath)
 	}
@@ -729,10 +909,11 @@ func (bput *BputCommand) deleteExtra(targetPath string) error {
 	zone := commons.GetZone()
 	targetPath = commons.MakeIRODSPath(cwd, home, zone, targetPath)
 
-	return bput.deleteExtraInternal(targetPath)
+	// paths under the target are compared with the source paths relative to the transfer root
+	return bput.deleteExtraInternal(targetPath, targetPath)
 }
 
-func (bput *BputCommand) deleteExtraInternal(targetPath string) error {
+func (bput *BputCommand) deleteExtraInternal(targetPath string, filterRootPath string) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
 		"struct":   "BputCommand",
@@ -741,6 +922,11 @@ func (bput *BputCommand) deleteExtraInternal(targetPath string) error {
 
 	targetEntry, err := bput.filesystem.Stat(targetPath)
 	if err != nil {
+		if bput.dryRunFlagValues.DryRun && irodsclient_types.IsFileNotFoundError(err) {
+			// the target is not created in dry run, nothing to delete
+			return nil
+		}
+
 		return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
 	}
 
@@ -750,7 +936,10 @@ func (bput *BputCommand) deleteExtraInternal(targetPath string) error {
 			// extra file
 			logger.Debugf("removing an extra data object %q", targetPath)
 
-			removeErr := bput.filesystem.RemoveFile(targetPath, true)
+			var removeErr error
+			if !bput.dryRunFlagValues.DryRun {
+				removeErr = bput.filesystem.RemoveFile(targetPath, true)
+			}
 
 			now := time.Now()
 			reportFile := &commons.TransferReportFile{
@@ -777,7 +966,10 @@ func (bput *BputCommand) deleteExtraInternal(targetPath string) error {
 		// extra dir
 		logger.Debugf("removing an extra collection %q", targetPath)
 
-		removeErr := bput.filesystem.RemoveDir(targetPath, true, true)
+		var removeErr error
+		if !bput.dryRunFlagValues.DryRun {
+			removeErr = bput.filesystem.RemoveDir(targetPath, true, true)
+		}
 
 		now := time.Now()
 		reportFile := &commons.TransferReportFile{
@@ -804,7 +996,12 @@ func (bput *BputCommand) deleteExtraInternal(targetPath string) error {
 
 		for _, entry := range entries {
 			newTargetPath := path.Join(targetPath, entry.Name)
-			err = bput.deleteExtraInternal(newTargetPath)
+			if bput.filterFlagValues.Filter.IsExcluded(filterRootPath, newTargetPath, entry.IsDir()) {
+				// excluded paths are not transferred, keep them
+				continue
+			}
+
+			err = bput.deleteExtraInternal(newTargetPath, filterRootPath)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,55 @@
+package subcmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBput(t *testing.T) {
+	t.Run("test ScanFailure", testBputScanFailure)
+}
+
+func testBputScanFailure(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	commons.SetDefaultConfigIfEmpty()
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	reportManager, err := commons.NewTransferReportManager(true, reportPath, false, false)
+	assert.NoError(t, err)
+
+	bundleManager := commons.NewBundleTransferManager(nil, reportManager, "/zone/home/user/target", "/data", 0, 0, 0, false, 1, false, false, "", "", false, false, false)
+	bundleManager.SetContinueOnError(true)
+
+	bput := &BputCommand{
+		transferReportManager: reportManager,
+		bundleTransferManager: bundleManager,
+	}
+
+	sourcePath := "/data/dir/a.txt"
+	scanErr := irodsclient_types.NewFileNotFoundError(sourcePath)
+
+	// continues with other files, the failure is recorded in the report
+	err = bput.handleScanFailure(sourcePath, false, scanErr)
+	assert.NoError(t, err)
+	reportManager.Release()
+
+	files, err := commons.ReadTransferReportFiles(reportPath)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, commons.TransferMethodBput, files[0].Method)
+	assert.Equal(t, sourcePath, files[0].SourcePath)
+	assert.Equal(t, "/zone/home/user/target/dir/a.txt", files[0].DestPath)
+	assert.True(t, files[0].HasNote("scan"))
+
+	// the error is given back without continue_on_error
+	bundleManager.SetContinueOnError(false)
+	bput.transferReportManager, err = commons.NewTransferReportManager(false, "", false, false)
+	assert.NoError(t, err)
+
+	err = bput.handleScanFailure(sourcePath, false, scanErr)
+	assert.True(t, irodsclient_types.IsFileNotFoundError(err))
+}
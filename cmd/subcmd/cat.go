@@ -12,6 +12,10 @@ import (
 	"golang.org/x/xerrors"
 )
 
+const (
+	catBufferSize int = 64 * 1024 // 64KB
+)
+
 var catCmd = &cobra.Command{
 	Use:     "cat [data-object]",
 	Aliases: []string{"icat"},
@@ -26,6 +30,7 @@ func AddCatCommand(rootCmd *cobra.Command) {
 	flag.SetCommonFlags(catCmd, false)
 
 	flag.SetTicketAccessFlags(catCmd)
+	flag.SetCatFlags(catCmd)
 
 	rootCmd.AddCommand(catCmd)
 }
@@ -43,6 +48,7 @@ type CatCommand struct {
 	command *cobra.Command
 
 	ticketAccessFlagValues *flag.TicketAccessFlagValues
+	catFlagValues          *flag.CatFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -55,14 +61,42 @@ func NewCatCommand(command *cobra.Command, args []string) (*CatCommand, error) {
 		command: command,
 
 		ticketAccessFlagValues: flag.GetTicketAccessFlagValues(),
+		catFlagValues:          flag.GetCatFlagValues(),
 	}
 
 	// path
 	cat.sourcePaths = args
 
+	err := cat.validateFlags()
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
 	return cat, nil
 }
 
+// validateFlags rejects negative values, -1 defaults of --length, --head and --tail mean they are not given
+func (cat *CatCommand) validateFlags() error {
+	if cat.catFlagValues.Offset < 0 {
+		return xerrors.Errorf("invalid offset %d, must not be negative", cat.catFlagValues.Offset)
+	}
+
+	flags := cat.command.Flags()
+	if flags.Changed("length") && cat.catFlagValues.Length < 0 {
+		return xerrors.Errorf("invalid length %d, must not be negative", cat.catFlagValues.Length)
+	}
+
+	if flags.Changed("head") && cat.catFlagValues.Head < 0 {
+		return xerrors.Errorf("invalid head %d, must not be negative", cat.catFlagValues.Head)
+	}
+
+	if flags.Changed("tail") && cat.catFlagValues.Tail < 0 {
+		return xerrors.Errorf("invalid tail %d, must not be negative", cat.catFlagValues.Tail)
+	}
+
+	return nil
+}
+
 func (cat *CatCommand) Process() error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
@@ -142,16 +176,185 @@ func (cat *CatCommand) catOne(sourcePath string) error {
 	}
 	defer fh.Close()
 
-	buf := make([]byte, 10240) // 10KB buffer
+	if cat.command.Flags().Changed("head") {
+		if cat.catFlagValues.Bytes {
+			return cat.catRange(fh, 0, cat.catFlagValues.Head)
+		}
+
+		return cat.catHeadLines(fh, cat.catFlagValues.Head)
+	}
+
+	if cat.command.Flags().Changed("tail") {
+		if cat.catFlagValues.Bytes {
+			offset := sourceEntry.Size - cat.catFlagValues.Tail
+			if offset < 0 {
+				offset = 0
+			}
+
+			return cat.catRange(fh, offset, -1)
+		}
+
+		offset, err := cat.findTailLinesOffset(fh, sourceEntry.Size, cat.catFlagValues.Tail)
+		if err != nil {
+			return xerrors.Errorf("failed to find the last %d lines of %q: %w", cat.catFlagValues.Tail, sourcePath, err)
+		}
+
+		return cat.catRange(fh, offset, -1)
+	}
+
+	return cat.catRange(fh, cat.catFlagValues.Offset, cat.catFlagValues.Length)
+}
+
+// catRange writes length bytes from offset, until EOF if length is negative
+func (cat *CatCommand) catRange(fh io.ReadSeeker, offset int64, length int64) error {
+	if offset > 0 {
+		_, err := fh.Seek(offset, io.SeekStart)
+		if err != nil {
+			return xerrors.Errorf("failed to seek to %d: %w", offset, err)
+		}
+	}
+
+	writer := commons.GetTerminalWriter()
+	remaining := length
+
+	buf := make([]byte, catBufferSize)
+	for remaining != 0 {
+		readBuf := buf
+		if remaining > 0 && remaining < int64(len(buf)) {
+			readBuf = buf[:remaining]
+		}
+
+		readLen, err := fh.Read(readBuf)
+		if readLen > 0 {
+			_, writeErr := writer.Write(readBuf[:readLen])
+			if writeErr != nil {
+				return xerrors.Errorf("failed to write: %w", writeErr)
+			}
+
+			if remaining > 0 {
+				remaining -= int64(readLen)
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return xerrors.Errorf("failed to read: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// catHeadLines writes the first lines lines
+func (cat *CatCommand) catHeadLines(fh io.Reader, lines int64) error {
+	if lines == 0 {
+		return nil
+	}
+
+	writer := commons.GetTerminalWriter()
+	linesFound := int64(0)
+
+	buf := make([]byte, catBufferSize)
 	for {
 		readLen, err := fh.Read(buf)
 		if readLen > 0 {
-			commons.Printf("%s", string(buf[:readLen]))
+			end := readLen
+			for idx := 0; idx < readLen; idx++ {
+				if buf[idx] == '\n' {
+					linesFound++
+					if linesFound == lines {
+						end = idx + 1
+						break
+					}
+				}
+			}
+
+			_, writeErr := writer.Write(buf[:end])
+			if writeErr != nil {
+				return xerrors.Errorf("failed to write: %w", writeErr)
+			}
+
+			if linesFound == lines {
+				return nil
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return xerrors.Errorf("failed to read: %w", err)
 		}
+	}
+}
+
+// findTailLinesOffset reads backward from the end and returns the offset where the last lines lines start
+func (cat *CatCommand) findTailLinesOffset(fh io.ReaderAt, size int64, lines int64) (int64, error) {
+	if lines == 0 {
+		return size, nil
+	}
+
+	buf := make([]byte, catBufferSize)
+	linesFound := int64(0)
+	end := size
+	first := true
+
+	for end > 0 {
+		start := end - int64(len(buf))
+		if start < 0 {
+			start = 0
+		}
+
+		chunk := buf[:end-start]
+		err := readFullAt(fh, chunk, start)
+		if err != nil {
+			return 0, err
+		}
+
+		idx := len(chunk) - 1
+		if first {
+			first = false
+			// a newline at the end of the file does not start a new line
+			if idx >= 0 && chunk[idx] == '\n' {
+				idx--
+			}
+		}
+
+		for ; idx >= 0; idx-- {
+			if chunk[idx] == '\n' {
+				linesFound++
+				if linesFound == lines {
+					return start + int64(idx) + 1, nil
+				}
+			}
+		}
+
+		end = start
+	}
+
+	return 0, nil
+}
+
+func readFullAt(fh io.ReaderAt, buf []byte, offset int64) error {
+	read := 0
+	for read < len(buf) {
+		readLen, err := fh.ReadAt(buf[read:], offset+int64(read))
+		read += readLen
+
+		if err == nil && readLen == 0 {
+			err = io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			if err == io.EOF && read == len(buf) {
+				return nil
+			}
 
-		if err == io.EOF {
-			// EOF
-			break
+			return xerrors.Errorf("failed to read at %d: %w", offset+int64(read), err)
 		}
 	}
 
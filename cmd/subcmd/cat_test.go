@@ -0,0 +1,158 @@
+package subcmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCat(t *testing.T) {
+	t.Run("test FindTailLinesOffset", testFindTailLinesOffset)
+	t.Run("test CatHeadLines", testCatHeadLines)
+	t.Run("test CatRange", testCatRange)
+	t.Run("test ValidateCatFlags", testValidateCatFlags)
+}
+
+// makeCatTestLines returns count lines, long enough to span multiple read buffers
+func makeCatTestLines(count int) string {
+	sb := strings.Builder{}
+	for i := 0; i < count; i++ {
+		sb.WriteString(fmt.Sprintf("line %05d %s\n", i, strings.Repeat("x", 100)))
+	}
+	return sb.String()
+}
+
+func testFindTailLinesOffset(t *testing.T) {
+	largeContent := makeCatTestLines(2000)
+	largeLines := strings.SplitAfter(largeContent, "\n")
+
+	tests := []struct {
+		name     string
+		content  string
+		lines    int64
+		expected string
+	}{
+		{"zero lines", "a\nb\nc\n", 0, ""},
+		{"last line", "a\nb\nc\n", 1, "c\n"},
+		{"last two lines", "a\nb\nc\n", 2, "b\nc\n"},
+		{"more lines than the file", "a\nb\nc\n", 10, "a\nb\nc\n"},
+		{"no trailing newline", "a\nb\nc", 1, "c"},
+		{"no trailing newline, two lines", "a\nb\nc", 2, "b\nc"},
+		{"empty lines", "a\n\n\n", 2, "\n\n"},
+		{"empty file", "", 3, ""},
+		{"single line", "abc", 1, "abc"},
+		{"across buffers", largeContent, 1500, strings.Join(largeLines[500:], "")},
+	}
+
+	cat := &CatCommand{}
+	for _, test := range tests {
+		reader := strings.NewReader(test.content)
+		offset, err := cat.findTailLinesOffset(reader, int64(len(test.content)), test.lines)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, test.content[offset:], test.name)
+	}
+}
+
+func testCatHeadLines(t *testing.T) {
+	commons.InitTerminalOutput()
+	defer commons.InitTerminalOutput()
+
+	largeContent := makeCatTestLines(2000)
+	largeLines := strings.SplitAfter(largeContent, "\n")
+
+	tests := []struct {
+		name     string
+		content  string
+		lines    int64
+		expected string
+	}{
+		{"zero lines", "a\nb\nc\n", 0, ""},
+		{"first line", "a\nb\nc\n", 1, "a\n"},
+		{"first two lines", "a\nb\nc\n", 2, "a\nb\n"},
+		{"more lines than the file", "a\nb\nc\n", 10, "a\nb\nc\n"},
+		{"no trailing newline", "a\nb\nc", 3, "a\nb\nc"},
+		{"empty file", "", 3, ""},
+		{"across buffers", largeContent, 1500, strings.Join(largeLines[:1500], "")},
+	}
+
+	cat := &CatCommand{}
+	for _, test := range tests {
+		buffer := &bytes.Buffer{}
+		commons.SetTerminalOutput(buffer)
+
+		err := cat.catHeadLines(strings.NewReader(test.content), test.lines)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, buffer.String(), test.name)
+	}
+}
+
+func testCatRange(t *testing.T) {
+	commons.InitTerminalOutput()
+	defer commons.InitTerminalOutput()
+
+	content := "0123456789"
+
+	tests := []struct {
+		name     string
+		offset   int64
+		length   int64
+		expected string
+	}{
+		{"all", 0, -1, content},
+		{"from offset", 7, -1, "789"},
+		{"range", 2, 3, "234"},
+		{"past the end", 8, 10, "89"},
+		{"zero length", 2, 0, ""},
+	}
+
+	cat := &CatCommand{}
+	for _, test := range tests {
+		buffer := &bytes.Buffer{}
+		commons.SetTerminalOutput(buffer)
+
+		err := cat.catRange(strings.NewReader(content), test.offset, test.length)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, buffer.String(), test.name)
+	}
+}
+
+func testValidateCatFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		valid bool
+	}{
+		{"defaults", []string{}, true},
+		{"offset and length", []string{"--offset", "10", "--length", "5"}, true},
+		{"zero length", []string{"--length", "0"}, true},
+		{"head", []string{"--head", "5"}, true},
+		{"tail", []string{"--tail", "0"}, true},
+		{"negative offset", []string{"--offset", "-1"}, false},
+		{"negative length", []string{"--length", "-5"}, false},
+		{"negative head", []string{"--head", "-5"}, false},
+		{"negative tail", []string{"--tail", "-5"}, false},
+		{"explicit -1 head", []string{"--head", "-1"}, false},
+	}
+
+	for _, test := range tests {
+		command := &cobra.Command{Use: "cat"}
+		flag.SetCatFlags(command)
+
+		err := command.ParseFlags(test.args)
+		assert.NoError(t, err, test.name)
+
+		_, err = NewCatCommand(command, []string{"/zone/home/user/a.txt"})
+		if test.valid {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+			assert.True(t, commons.IsUsageError(err), test.name)
+		}
+	}
+}
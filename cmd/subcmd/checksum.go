@@ -0,0 +1,392 @@
+package subcmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/jedib0t/go-pretty/v6/progress"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var checksumCmd = &cobra.Command{
+	Use:     "checksum [data-object1] [data-object2] [collection1] ...",
+	Aliases: []string{"ichksum", "chksum"},
+	Short:   "Compute, register and verify checksums of iRODS data-objects",
+	Long:    `This asks the server to compute and register missing checksums of iRODS data-objects or collections. It can also verify registered checksums against replicas and compare them against a local directory. Use --continue_on_error to check all data-objects even if some of them do not match.`,
+	RunE:    processChecksumCommand,
+	Args:    cobra.MinimumNArgs(1),
+}
+
+func AddChecksumCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(checksumCmd, false)
+
+	flag.SetRecursiveFlags(checksumCmd, false)
+	flag.SetTicketAccessFlags(checksumCmd)
+	flag.SetChecksumVerificationFlags(checksumCmd)
+	flag.SetHiddenFileFlags(checksumCmd)
+	flag.SetFilterFlags(checksumCmd)
+	flag.SetProgressFlags(checksumCmd)
+	flag.SetRetryFlags(checksumCmd)
+	flag.SetContinueOnErrorFlags(checksumCmd)
+	flag.SetTransferReportFlags(checksumCmd)
+
+	rootCmd.AddCommand(checksumCmd)
+}
+
+func processChecksumCommand(command *cobra.Command, args []string) error {
+	checksum, err := NewChecksumCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return checksum.Process()
+}
+
+type ChecksumCommand struct {
+	command *cobra.Command
+
+	recursiveFlagValues            *flag.RecursiveFlagValues
+	ticketAccessFlagValues         *flag.TicketAccessFlagValues
+	checksumVerificationFlagValues *flag.ChecksumVerificationFlagValues
+	hiddenFileFlagValues           *flag.HiddenFileFlagValues
+	filterFlagValues               *flag.FilterFlagValues
+	progressFlagValues             *flag.ProgressFlagValues
+	retryFlagValues                *flag.RetryFlagValues
+	continueOnErrorFlagValues      *flag.ContinueOnErrorFlagValues
+	transferReportFlagValues       *flag.TransferReportFlagValues
+
+	maxConnectionNum int
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	sourcePaths []string
+
+	parallelJobManager    *commons.ParallelJobManager
+	transferReportManager *commons.TransferReportManager
+}
+
+func NewChecksumCommand(command *cobra.Command, args []string) (*ChecksumCommand, error) {
+	checksum := &ChecksumCommand{
+		command: command,
+
+		recursiveFlagValues:            flag.GetRecursiveFlagValues(),
+		ticketAccessFlagValues:         flag.GetTicketAccessFlagValues(),
+		checksumVerificationFlagValues: flag.GetChecksumVerificationFlagValues(),
+		hiddenFileFlagValues:           flag.GetHiddenFileFlagValues(),
+		filterFlagValues:               flag.GetFilterFlagValues(),
+		progressFlagValues:             flag.GetProgressFlagValues(),
+		retryFlagValues:                flag.GetRetryFlagValues(),
+		continueOnErrorFlagValues:      flag.GetContinueOnErrorFlagValues(),
+		transferReportFlagValues:       flag.GetTransferReportFlagValues(command),
+	}
+
+	checksum.maxConnectionNum = checksum.checksumVerificationFlagValues.ThreadNumber
+
+	// path
+	checksum.sourcePaths = args
+
+	return checksum, nil
+}
+
+func (checksum *ChecksumCommand) Process() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "ChecksumCommand",
+		"function": "Process",
+	})
+
+	cont, err := flag.ProcessCommonFlags(checksum.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// config
+	appConfig := commons.GetConfig()
+	syncAccount := false
+	if len(checksum.ticketAccessFlagValues.Name) > 0 {
+		logger.Debugf("use ticket %q", checksum.ticketAccessFlagValues.Name)
+		appConfig.Ticket = checksum.ticketAccessFlagValues.Name
+		syncAccount = true
+	}
+
+	if syncAccount {
+		err := commons.SyncAccount()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create a file system
+	checksum.account = commons.GetAccount()
+	checksum.filesystem, err = commons.GetIRODSFSClientAdvanced(checksum.account, checksum.maxConnectionNum, commons.TcpBufferSizeDefault)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer checksum.filesystem.Release()
+
+	// transfer report
+	checksum.transferReportManager, err = commons.NewTransferReportManager(checksum.transferReportFlagValues.Report, checksum.transferReportFlagValues.ReportPath, checksum.transferReportFlagValues.ReportToStdout, false)
+	if err != nil {
+		return xerrors.Errorf("failed to create transfer report manager: %w", err)
+	}
+	defer checksum.transferReportManager.Release()
+
+	// parallel job manager
+	checksum.parallelJobManager = commons.NewParallelJobManager(checksum.filesystem, checksum.checksumVerificationFlagValues.ThreadNumber, checksum.progressFlagValues.ShowProgress, checksum.progressFlagValues.ShowFullPath)
+	checksum.parallelJobManager.SetRetryPolicy(checksum.retryFlagValues.RetryPolicy)
+	checksum.parallelJobManager.SetContinueOnError(checksum.continueOnErrorFlagValues.ContinueOnError)
+	checksum.parallelJobManager.Start()
+
+	// run
+	for _, sourcePath := range checksum.sourcePaths {
+		err = checksum.checksumOne(sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to compute checksum of %q: %w", sourcePath, err)
+		}
+	}
+
+	checksum.parallelJobManager.DoneScheduling()
+	err = checksum.parallelJobManager.Wait()
+	if err != nil {
+		return xerrors.Errorf("failed to perform parallel jobs: %w", err)
+	}
+
+	return nil
+}
+
+func (checksum *ChecksumCommand) checksumOne(sourcePath string) error {
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	sourcePath = commons.MakeIRODSPath(cwd, home, zone, sourcePath)
+
+	sourceEntry, err := checksum.filesystem.Stat(sourcePath)
+	if err != nil {
+		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
+	}
+
+	localPath := ""
+	if len(checksum.checksumVerificationFlagValues.LocalPath) > 0 {
+		localPath = commons.MakeLocalPath(checksum.checksumVerificationFlagValues.LocalPath)
+	}
+
+	filterRootPath := commons.GetFilterRootPath(sourceEntry.Path, false)
+
+	if sourceEntry.IsDir() {
+		// dir
+		if !checksum.recursiveFlagValues.Recursive {
+			return xerrors.Errorf("cannot compute checksums of a collection, recurse is not set")
+		}
+
+		return checksum.checksumDir(sourceEntry, localPath, filterRootPath)
+	}
+
+	// file
+	if len(localPath) > 0 {
+		localStat, err := os.Stat(localPath)
+		if err == nil && localStat.IsDir() {
+			localPath = filepath.Join(localPath, sourceEntry.Name)
+		}
+	}
+
+	return checksum.scheduleChecksum(sourceEntry, localPath)
+}
+
+func (checksum *ChecksumCommand) checksumDir(sourceEntry *irodsclient_fs.Entry, localPath string, filterRootPath string) error {
+	entries, err := checksum.filesystem.List(sourceEntry.Path)
+	if err != nil {
+		return xerrors.Errorf("failed to list dir %q: %w", sourceEntry.Path, err)
+	}
+
+	for _, entry := range entries {
+		if checksum.hiddenFileFlagValues.Exclude {
+			// exclude hidden
+			if strings.HasPrefix(entry.Name, ".") {
+				continue
+			}
+		}
+
+		if checksum.filterFlagValues.Filter.IsExcluded(filterRootPath, entry.Path, entry.IsDir()) {
+			continue
+		}
+
+		entryLocalPath := ""
+		if len(localPath) > 0 {
+			entryLocalPath = filepath.Join(localPath, entry.Name)
+		}
+
+		if entry.IsDir() {
+			// dir
+			err = checksum.checksumDir(entry, entryLocalPath, filterRootPath)
+			if err != nil {
+				return err
+			}
+		} else {
+			// file
+			err = checksum.scheduleChecksum(entry, entryLocalPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (checksum *ChecksumCommand) scheduleChecksum(sourceEntry *irodsclient_fs.Entry, localPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "ChecksumCommand",
+		"function": "scheduleChecksum",
+	})
+
+	checksumTask := func(job *commons.ParallelJob) error {
+		manager := job.GetManager()
+		fs := manager.GetFilesystem()
+
+		job.Progress(0, 1, false)
+
+		logger.Debugf("computing a checksum of a data object %q", sourceEntry.Path)
+
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodChecksum,
+			StartAt:    time.Now(),
+			SourcePath: sourceEntry.Path,
+			SourceSize: sourceEntry.Size,
+			Notes:      []string{},
+		}
+
+		checkErr := checksum.checkDataObject(fs, sourceEntry, localPath, reportFile)
+
+		reportFile.EndAt = time.Now()
+		reportFile.Error = checkErr
+
+		err := checksum.transferReportManager.AddFile(reportFile)
+		if err != nil {
+			job.Progress(-1, 1, true)
+			return xerrors.Errorf("failed to add transfer report: %w", err)
+		}
+
+		if checkErr != nil {
+			// other data objects are checked with --continue_on_error
+			logger.WithError(checkErr).Debugf("failed to check a checksum of a data object %q", sourceEntry.Path)
+
+			job.Progress(-1, 1, true)
+			return checkErr
+		}
+
+		if !checksum.transferReportFlagValues.Report || !checksum.transferReportFlagValues.ReportToStdout {
+			commons.Printf("%s\t%s:%s\n", sourceEntry.Path, reportFile.ChecksumAlgorithm, reportFile.SourceChecksum)
+		}
+
+		logger.Debugf("computed a checksum of a data object %q", sourceEntry.Path)
+		job.Progress(1, 1, false)
+
+		job.Done()
+		return nil
+	}
+
+	err := checksum.parallelJobManager.Schedule(sourceEntry.Path, checksumTask, 1, progress.UnitsDefault)
+	if err != nil {
+		return xerrors.Errorf("failed to schedule checksum %q: %w", sourceEntry.Path, err)
+	}
+
+	logger.Debugf("scheduled checksum of a data object %q", sourceEntry.Path)
+
+	return nil
+}
+
+// checkDataObject computes, verifies and compares checksum of a data object, and fills the report
+func (checksum *ChecksumCommand) checkDataObject(fs *irodsclient_fs.FileSystem, sourceEntry *irodsclient_fs.Entry, localPath string, reportFile *commons.TransferReportFile) error {
+	verify := checksum.checksumVerificationFlagValues.Verify
+
+	irodsChecksum, err := commons.ComputeDataObjectChecksum(fs, sourceEntry.Path, verify)
+	if err != nil {
+		return err
+	}
+
+	reportFile.SourceChecksum = hex.EncodeToString(irodsChecksum.Checksum)
+	reportFile.ChecksumAlgorithm = string(irodsChecksum.Algorithm)
+
+	if len(sourceEntry.CheckSum) == 0 {
+		reportFile.Notes = append(reportFile.Notes, "registered")
+	}
+
+	if verify {
+		replicas, err := commons.GetDataObjectReplicas(fs, sourceEntry.Path)
+		if err != nil {
+			return err
+		}
+
+		mismatches := commons.VerifyReplicaChecksums(replicas, irodsChecksum)
+		if len(mismatches) > 0 {
+			return commons.NewChecksumMismatchError(sourceEntry.Path, fmt.Sprintf("replicas on %v differ", mismatches))
+		}
+
+		reportFile.Notes = append(reportFile.Notes, "verified")
+	}
+
+	if len(localPath) > 0 {
+		err = compareLocalChecksum(sourceEntry.Path, irodsChecksum, localPath, reportFile)
+		if err != nil {
+			return err
+		}
+
+		reportFile.Notes = append(reportFile.Notes, "local")
+	}
+
+	return nil
+}
+
+// compareLocalChecksum compares the checksum of the data object with the hash of the local file, and fills the report
+func compareLocalChecksum(irodsPath string, irodsChecksum *irodsclient_types.IRODSChecksum, localPath string, reportFile *commons.TransferReportFile) error {
+	reportFile.DestPath = localPath
+
+	localStat, err := os.Stat(localPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return irodsclient_types.NewFileNotFoundError(localPath)
+		}
+
+		return xerrors.Errorf("failed to stat %q: %w", localPath, err)
+	}
+
+	reportFile.DestSize = localStat.Size()
+
+	localChecksum, err := irodsclient_util.HashLocalFile(localPath, string(irodsChecksum.Algorithm))
+	if err != nil {
+		return xerrors.Errorf("failed to get hash of %q: %w", localPath, err)
+	}
+
+	reportFile.DestChecksum = hex.EncodeToString(localChecksum)
+
+	if !bytes.Equal(irodsChecksum.Checksum, localChecksum) {
+		return commons.NewChecksumMismatchError(irodsPath, fmt.Sprintf("local file %q differs", localPath))
+	}
+
+	return nil
+}
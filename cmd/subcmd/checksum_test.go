@@ -0,0 +1,67 @@
+package subcmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestChecksum(t *testing.T) {
+	t.Run("test CompareLocalChecksum", testCompareLocalChecksum)
+	t.Run("test ChecksumMismatchError", testChecksumMismatchError)
+}
+
+func testCompareLocalChecksum(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "a.txt")
+	err := os.WriteFile(localPath, []byte("checksum data"), 0644)
+	assert.NoError(t, err)
+
+	hash, err := irodsclient_util.HashLocalFile(localPath, string(irodsclient_types.ChecksumAlgorithmSHA256))
+	assert.NoError(t, err)
+
+	irodsChecksum := &irodsclient_types.IRODSChecksum{
+		Algorithm: irodsclient_types.ChecksumAlgorithmSHA256,
+		Checksum:  hash,
+	}
+
+	reportFile := &commons.TransferReportFile{}
+	err = compareLocalChecksum("/zone/home/user/a.txt", irodsChecksum, localPath, reportFile)
+	assert.NoError(t, err)
+	assert.Equal(t, localPath, reportFile.DestPath)
+	assert.Equal(t, int64(13), reportFile.DestSize)
+	assert.NotEmpty(t, reportFile.DestChecksum)
+
+	// modified local file
+	err = os.WriteFile(localPath, []byte("modified data"), 0644)
+	assert.NoError(t, err)
+
+	err = compareLocalChecksum("/zone/home/user/a.txt", irodsChecksum, localPath, &commons.TransferReportFile{})
+	assert.Error(t, err)
+	assert.True(t, commons.IsChecksumMismatchError(err))
+
+	// missing local file
+	err = compareLocalChecksum("/zone/home/user/a.txt", irodsChecksum, filepath.Join(t.TempDir(), "missing.txt"), &commons.TransferReportFile{})
+	assert.Error(t, err)
+	assert.False(t, commons.IsChecksumMismatchError(err))
+	assert.True(t, irodsclient_types.IsFileNotFoundError(err))
+}
+
+func testChecksumMismatchError(t *testing.T) {
+	err := commons.NewChecksumMismatchError("/zone/home/user/a.txt", "replicas on [demoResc] differ")
+
+	// the error is classified through wrapping, so the exit code is not the one for unexpected errors
+	wrapped := xerrors.Errorf("failed to perform parallel jobs: %w", err)
+	assert.True(t, commons.IsChecksumMismatchError(wrapped))
+	assert.Equal(t, "checksum mismatch", commons.GetErrorType(wrapped))
+
+	// mismatches are not retried
+	assert.False(t, commons.IsTransientError(wrapped))
+
+	assert.False(t, commons.IsChecksumMismatchError(xerrors.Errorf("other error")))
+}
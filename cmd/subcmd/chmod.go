@@ -0,0 +1,237 @@
+package subcmd
+
+import (
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/jedib0t/go-pretty/v6/progress"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var chmodCmd = &cobra.Command{
+	Use:     "chmod [null|read|write|own] [user or group] [data-object1] [collection1] ...",
+	Aliases: []string{"ichmod"},
+	Short:   "Change access of iRODS data-objects and collections",
+	Long:    `This changes access of a user or a group to iRODS data-objects and collections. Use null to remove the access. A user in another zone is given as "user#zone". Use -r to change access of all data-objects and collections under the collection too.`,
+	RunE:    processChmodCommand,
+	Args:    cobra.MinimumNArgs(3),
+}
+
+func AddChmodCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(chmodCmd, false)
+
+	flag.SetRecursiveFlags(chmodCmd, false)
+	flag.SetChmodFlags(chmodCmd)
+	flag.SetProgressFlags(chmodCmd)
+	flag.SetRetryFlags(chmodCmd)
+	flag.SetContinueOnErrorFlags(chmodCmd)
+
+	rootCmd.AddCommand(chmodCmd)
+}
+
+func processChmodCommand(command *cobra.Command, args []string) error {
+	chmod, err := NewChmodCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return chmod.Process()
+}
+
+type ChmodCommand struct {
+	command *cobra.Command
+
+	recursiveFlagValues       *flag.RecursiveFlagValues
+	chmodFlagValues           *flag.ChmodFlagValues
+	progressFlagValues        *flag.ProgressFlagValues
+	retryFlagValues           *flag.RetryFlagValues
+	continueOnErrorFlagValues *flag.ContinueOnErrorFlagValues
+
+	maxConnectionNum int
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	accessLevel irodsclient_types.IRODSAccessLevelType
+	user        string
+	zone        string
+	targetPaths []string
+
+	parallelJobManager *commons.ParallelJobManager
+}
+
+func NewChmodCommand(command *cobra.Command, args []string) (*ChmodCommand, error) {
+	chmod := &ChmodCommand{
+		command: command,
+
+		recursiveFlagValues:       flag.GetRecursiveFlagValues(),
+		chmodFlagValues:           flag.GetChmodFlagValues(),
+		progressFlagValues:        flag.GetProgressFlagValues(),
+		retryFlagValues:           flag.GetRetryFlagValues(),
+		continueOnErrorFlagValues: flag.GetContinueOnErrorFlagValues(),
+	}
+
+	chmod.maxConnectionNum = chmod.chmodFlagValues.ThreadNumber
+
+	// access level
+	accessLevel, err := commons.GetAccessLevel(args[0])
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+	chmod.accessLevel = accessLevel
+
+	// user or group
+	chmod.user, chmod.zone = commons.SplitUserZone(args[1])
+	if len(chmod.user) == 0 {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("user or group is empty"))
+	}
+
+	// path
+	chmod.targetPaths = args[2:]
+
+	return chmod, nil
+}
+
+func (chmod *ChmodCommand) Process() error {
+	cont, err := flag.ProcessCommonFlags(chmod.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	chmod.account = commons.GetAccount()
+	chmod.filesystem, err = commons.GetIRODSFSClientAdvanced(chmod.account, chmod.maxConnectionNum, commons.TcpBufferSizeDefault)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer chmod.filesystem.Release()
+
+	// parallel job manager
+	chmod.parallelJobManager = commons.NewParallelJobManager(chmod.filesystem, chmod.chmodFlagValues.ThreadNumber, chmod.progressFlagValues.ShowProgress, chmod.progressFlagValues.ShowFullPath)
+	chmod.parallelJobManager.SetRetryPolicy(chmod.retryFlagValues.RetryPolicy)
+	chmod.parallelJobManager.SetContinueOnError(chmod.continueOnErrorFlagValues.ContinueOnError)
+	chmod.parallelJobManager.Start()
+
+	// run
+	for _, targetPath := range chmod.targetPaths {
+		err = chmod.chmodOne(targetPath)
+		if err != nil {
+			return xerrors.Errorf("failed to change access of %q: %w", targetPath, err)
+		}
+	}
+
+	chmod.parallelJobManager.DoneScheduling()
+	err = chmod.parallelJobManager.Wait()
+	if err != nil {
+		return xerrors.Errorf("failed to perform parallel jobs: %w", err)
+	}
+
+	return nil
+}
+
+func (chmod *ChmodCommand) chmodOne(targetPath string) error {
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	targetPath = commons.MakeIRODSPath(cwd, home, zone, targetPath)
+
+	targetEntry, err := chmod.filesystem.Stat(targetPath)
+	if err != nil {
+		return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
+	}
+
+	if !targetEntry.IsDir() || !chmod.recursiveFlagValues.Recursive {
+		return chmod.scheduleChmod(targetEntry.Path, targetEntry.IsDir())
+	}
+
+	// change each entry under the collection in a parallel job instead of a single recursive request, to show progress per entry
+	connection, err := chmod.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer chmod.filesystem.ReturnMetadataConnection(connection)
+
+	// collections include the collection itself
+	collections, err := commons.FindCollections(connection, targetEntry.Path, commons.NewFindCondition())
+	if err != nil {
+		return xerrors.Errorf("failed to list sub-collections of %q: %w", targetEntry.Path, err)
+	}
+
+	dataObjects, err := commons.FindDataObjects(connection, targetEntry.Path, commons.NewFindCondition())
+	if err != nil {
+		return xerrors.Errorf("failed to list data-objects in %q: %w", targetEntry.Path, err)
+	}
+
+	for _, collection := range collections {
+		err = chmod.scheduleChmod(collection.Path, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, dataObject := range dataObjects {
+		err = chmod.scheduleChmod(dataObject.Path, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (chmod *ChmodCommand) scheduleChmod(targetPath string, isDir bool) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "ChmodCommand",
+		"function": "scheduleChmod",
+	})
+
+	chmodTask := func(job *commons.ParallelJob) error {
+		manager := job.GetManager()
+		fs := manager.GetFilesystem()
+
+		job.Progress(0, 1, false)
+
+		connection, err := fs.GetMetadataConnection()
+		if err != nil {
+			job.Progress(-1, 1, true)
+			return xerrors.Errorf("failed to get connection: %w", err)
+		}
+		defer fs.ReturnMetadataConnection(connection)
+
+		logger.Debugf("change access of %q to %q for %q (zone %q)", targetPath, chmod.accessLevel.ChmodString(), chmod.user, chmod.zone)
+
+		err = commons.ChangeAccess(connection, targetPath, isDir, chmod.accessLevel, chmod.user, chmod.zone)
+		if err != nil {
+			job.Progress(-1, 1, true)
+			return err
+		}
+
+		job.Progress(1, 1, false)
+		job.Done()
+		return nil
+	}
+
+	err := chmod.parallelJobManager.Schedule(targetPath, chmodTask, 1, progress.UnitsDefault)
+	if err != nil {
+		return xerrors.Errorf("failed to schedule access change of %q: %w", targetPath, err)
+	}
+
+	logger.Debugf("scheduled access change of %q", targetPath)
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"path"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -22,9 +23,9 @@ var cpCmd = &cobra.Command{
 	Use:     "cp [data-object1] [data-object2] [collection1] ... [target collection]",
 	Aliases: []string{"icp", "copy"},
 	Short:   "Copy iRODS data-objects or collections to target collection",
-	Long:    `This copies iRODS data-objects or collections to the given target collection.`,
+	Long:    `This copies iRODS data-objects or collections to the given target collection. Use --from_report to copy data-objects recorded in a transfer report again.`,
 	RunE:    processCpCommand,
-	Args:    cobra.MinimumNArgs(2),
+	Args:    cobra.ArbitraryArgs,
 }
 
 func AddCpCommand(rootCmd *cobra.Command) {
@@ -35,11 +36,15 @@ func AddCpCommand(rootCmd *cobra.Command) {
 	flag.SetRecursiveFlags(cpCmd, false)
 	flag.SetProgressFlags(cpCmd)
 	flag.SetRetryFlags(cpCmd)
+	flag.SetContinueOnErrorFlags(cpCmd)
 	flag.SetDifferentialTransferFlags(cpCmd, true)
 	flag.SetNoRootFlags(cpCmd)
 	flag.SetSyncFlags(cpCmd, false)
 	flag.SetHiddenFileFlags(cpCmd)
+	flag.SetFilterFlags(cpCmd)
+	flag.SetDryRunFlags(cpCmd)
 	flag.SetTransferReportFlags(cpCmd)
+	flag.SetFromReportFlags(cpCmd)
 
 	rootCmd.AddCommand(cpCmd)
 }
@@ -61,11 +66,15 @@ type CpCommand struct {
 	forceFlagValues                *flag.ForceFlagValues
 	progressFlagValues             *flag.ProgressFlagValues
 	retryFlagValues                *flag.RetryFlagValues
+	continueOnErrorFlagValues      *flag.ContinueOnErrorFlagValues
 	differentialTransferFlagValues *flag.DifferentialTransferFlagValues
 	noRootFlagValues               *flag.NoRootFlagValues
 	syncFlagValues                 *flag.SyncFlagValues
 	hiddenFileFlagValues           *flag.HiddenFileFlagValues
+	filterFlagValues               *flag.FilterFlagValues
+	dryRunFlagValues               *flag.DryRunFlagValues
 	transferReportFlagValues       *flag.TransferReportFlagValues
+	fromReportFlagValues           *flag.FromReportFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -87,23 +96,55 @@ func NewCpCommand(command *cobra.Command, args []string) (*CpCommand, error) {
 		forceFlagValues:                flag.GetForceFlagValues(),
 		progressFlagValues:             flag.GetProgressFlagValues(),
 		retryFlagValues:                flag.GetRetryFlagValues(),
+		continueOnErrorFlagValues:      flag.GetContinueOnErrorFlagValues(),
 		differentialTransferFlagValues: flag.GetDifferentialTransferFlagValues(),
 		noRootFlagValues:               flag.GetNoRootFlagValues(),
 		syncFlagValues:                 flag.GetSyncFlagValues(),
 		hiddenFileFlagValues:           flag.GetHiddenFileFlagValues(),
+		filterFlagValues:               flag.GetFilterFlagValues(),
+		dryRunFlagValues:               flag.GetDryRunFlagValues(),
 		transferReportFlagValues:       flag.GetTransferReportFlagValues(command),
+		fromReportFlagValues:           flag.GetFromReportFlagValues(),
 
 		updatedPathMap: map[string]bool{},
 	}
 
 	// path
-	cp.targetPath = args[len(args)-1]
-	cp.sourcePaths = args[:len(args)-1]
+	cp.targetPath = ""
+	cp.sourcePaths = []string{}
+
+	if len(args) >= 2 {
+		cp.targetPath = args[len(args)-1]
+		cp.sourcePaths = args[:len(args)-1]
+	} else if len(cp.fromReportFlagValues.ReportPath) == 0 {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("requires at least 2 arg(s), only received %d", len(args)))
+	}
+
+	if len(cp.fromReportFlagValues.ReportPath) > 0 {
+		if len(args) > 0 {
+			return nil, xerrors.Errorf("failed to copy from report, source and target paths must not be given")
+		}
+
+		if cp.syncFlagValues.Delete {
+			return nil, xerrors.Errorf("failed to copy from report, deleting extra files is not supported")
+		}
+
+		if cp.transferReportFlagValues.Report && !cp.transferReportFlagValues.ReportToStdout && filepath.Clean(cp.transferReportFlagValues.ReportPath) == filepath.Clean(cp.fromReportFlagValues.ReportPath) {
+			return nil, xerrors.Errorf("failed to copy from report, new transfer report must be written to a different file")
+		}
+	} else if cp.fromReportFlagValues.OnlyFailed {
+		return nil, xerrors.Errorf("failed to copy only failed files, transfer report must be given with --from_report")
+	}
 
 	if cp.noRootFlagValues.NoRoot && len(cp.sourcePaths) > 1 {
 		return nil, xerrors.Errorf("failed to copy multiple source collections without creating root directory")
 	}
 
+	err := cp.differentialTransferFlagValues.Validate()
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
 	return cp, nil
 }
 
@@ -129,15 +170,6 @@ func (cp *CpCommand) Process() error {
 		return xerrors.Errorf("failed to input missing fields: %w", err)
 	}
 
-	// handle retry
-	if cp.retryFlagValues.RetryNumber > 0 && !cp.retryFlagValues.RetryChild {
-		err = commons.RunWithRetry(cp.retryFlagValues.RetryNumber, cp.retryFlagValues.RetryIntervalSeconds)
-		if err != nil {
-			return xerrors.Errorf("failed to run with retry %d: %w", cp.retryFlagValues.RetryNumber, err)
-		}
-		return nil
-	}
-
 	// Create a file system
 	cp.account = commons.GetAccount()
 	cp.filesystem, err = commons.GetIRODSFSClient(cp.account)
@@ -147,7 +179,7 @@ func (cp *CpCommand) Process() error {
 	defer cp.filesystem.Release()
 
 	// transfer report
-	cp.transferReportManager, err = commons.NewTransferReportManager(cp.transferReportFlagValues.Report, cp.transferReportFlagValues.ReportPath, cp.transferReportFlagValues.ReportToStdout)
+	cp.transferReportManager, err = commons.NewTransferReportManager(cp.transferReportFlagValues.Report, cp.transferReportFlagValues.ReportPath, cp.transferReportFlagValues.ReportToStdout, cp.dryRunFlagValues.DryRun)
 	if err != nil {
 		return xerrors.Errorf("failed to create transfer report manager: %w", err)
 	}
@@ -155,21 +187,31 @@ func (cp *CpCommand) Process() error {
 
 	// parallel job manager
 	cp.parallelJobManager = commons.NewParallelJobManager(cp.filesystem, commons.TransferThreadNumDefault, cp.progressFlagValues.ShowProgress, cp.progressFlagValues.ShowFullPath)
+	cp.parallelJobManager.SetRetryPolicy(cp.retryFlagValues.RetryPolicy)
+	cp.parallelJobManager.SetRetryCallback(cp.transferReportManager.GetRetryCallback(commons.TransferMethodCopy))
+	cp.parallelJobManager.SetContinueOnError(cp.continueOnErrorFlagValues.ContinueOnError)
 	cp.parallelJobManager.Start()
 
 	// run
-	if len(cp.sourcePaths) >= 2 {
-		// multi-source, target must be a dir
-		err = cp.ensureTargetIsDir(cp.targetPath)
+	if len(cp.fromReportFlagValues.ReportPath) > 0 {
+		err = cp.copyFromReport(cp.fromReportFlagValues.ReportPath)
 		if err != nil {
-			return err
+			return xerrors.Errorf("failed to copy from report %q: %w", cp.fromReportFlagValues.ReportPath, err)
+		}
+	} else {
+		if len(cp.sourcePaths) >= 2 {
+			// multi-source, target must be a dir
+			err = cp.ensureTargetIsDir(cp.targetPath)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	for _, sourcePath := range cp.sourcePaths {
-		err = cp.copyOne(sourcePath, cp.targetPath)
-		if err != nil {
-			return xerrors.Errorf("failed to copy %q to %q: %w", sourcePath, cp.targetPath, err)
+		for _, sourcePath := range cp.sourcePaths {
+			err = cp.copyOne(sourcePath, cp.targetPath)
+			if err != nil {
+				return xerrors.Errorf("failed to copy %q to %q: %w", sourcePath, cp.targetPath, err)
+			}
 		}
 	}
 
@@ -215,7 +257,54 @@ func (cp *CpCommand) ensureTargetIsDir(targetPath string) error {
 	return nil
 }
 
+// copyFromReport copys files recorded in the transfer report again
+func (cp *CpCommand) copyFromReport(reportPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "CpCommand",
+		"function": "copyFromReport",
+	})
+
+	reportFiles, err := commons.ReadTransferReportFiles(reportPath)
+	if err != nil {
+		return xerrors.Errorf("failed to read report file %q: %w", reportPath, err)
+	}
+
+	reportFilter := &commons.TransferReportFilter{
+		Method:       commons.TransferMethodCopy,
+		OnlyFailed:   cp.fromReportFlagValues.OnlyFailed,
+		IncludeNotes: cp.fromReportFlagValues.IncludeNotes,
+		ExcludeNotes: cp.fromReportFlagValues.ExcludeNotes,
+	}
+
+	reportFiles = reportFilter.Filter(reportFiles)
+	logger.Debugf("copy %d files in the report %q", len(reportFiles), reportPath)
+
+	for _, reportFile := range reportFiles {
+		if reportFile.HasNote("directory") || len(reportFile.DestPath) == 0 {
+			// directories are created with files in them
+			logger.Debugf("skip %q in the report", reportFile.SourcePath)
+			continue
+		}
+
+		cp.sourcePaths = append(cp.sourcePaths, reportFile.SourcePath)
+
+		err = cp.copyOne(reportFile.SourcePath, reportFile.DestPath)
+		if err != nil {
+			return xerrors.Errorf("failed to copy %q to %q: %w", reportFile.SourcePath, reportFile.DestPath, err)
+		}
+	}
+
+	return nil
+}
+
 func (cp *CpCommand) copyOne(sourcePath string, targetPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "CpCommand",
+		"function": "copyOne",
+	})
+
 	cwd := commons.GetCWD()
 	home := commons.GetHomeDir()
 	zone := commons.GetZone()
@@ -227,6 +316,12 @@ func (cp *CpCommand) copyOne(sourcePath string, targetPath string) error {
 		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
 	}
 
+	filterRootPath := commons.GetFilterRootPath(sourceEntry.Path, sourceEntry.IsDir() && cp.noRootFlagValues.NoRoot)
+	if cp.filterFlagValues.Filter.IsExcluded(filterRootPath, sourceEntry.Path, sourceEntry.IsDir()) {
+		logger.Debugf("skip copying %q, excluded by filters", sourceEntry.Path)
+		return nil
+	}
+
 	if sourceEntry.IsDir() {
 		// dir
 		if !cp.recursiveFlagValues.Recursive {
@@ -237,12 +332,17 @@ func (cp *CpCommand) copyOne(sourcePath string, targetPath string) error {
 			targetPath = commons.MakeTargetIRODSFilePath(cp.filesystem, sourcePath, targetPath)
 		}
 
-		return cp.copyDir(sourceEntry, targetPath)
+		return cp.copyDir(sourceEntry, targetPath, filterRootPath)
 	}
 
 	// file
 	targetPath = commons.MakeTargetIRODSFilePath(cp.filesystem, sourcePath, targetPath)
-	return cp.copyFile(sourceEntry, targetPath)
+	err = cp.copyFile(sourceEntry, targetPath)
+	if err != nil {
+		return cp.handleScanFailure(sourceEntry, targetPath, err)
+	}
+
+	return nil
 }
 
 func (cp *CpCommand) scheduleCopy(sourceEntry *irodsclient_fs.Entry, targetPath string, targetEntry *irodsclient_fs.Entry) error {
@@ -252,6 +352,23 @@ func (cp *CpCommand) scheduleCopy(sourceEntry *irodsclient_fs.Entry, targetPath
 		"function": "scheduleCopy",
 	})
 
+	if cp.dryRunFlagValues.DryRun {
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodCopy,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourceEntry.Path,
+			SourceSize: sourceEntry.Size,
+			DestPath:   targetPath,
+		}
+
+		cp.transferReportManager.AddFile(reportFile)
+
+		logger.Debugf("skip copying a data object %q to %q, dry run", sourceEntry.Path, targetPath)
+		return nil
+	}
+
 	copyTask := func(job *commons.ParallelJob) error {
 		manager := job.GetManager()
 		fs := manager.GetFilesystem()
@@ -293,7 +410,8 @@ func (cp *CpCommand) scheduleCopy(sourceEntry *irodsclient_fs.Entry, targetPath
 		return nil
 	}
 
-	err := cp.parallelJobManager.Schedule(sourceEntry.Path, copyTask, 1, progress.UnitsDefault)
+	reportedCopyTask := cp.transferReportManager.WrapTask(commons.TransferMethodCopy, sourceEntry.Path, sourceEntry.Size, targetPath, copyTask)
+	err := cp.parallelJobManager.Schedule(sourceEntry.Path, reportedCopyTask, 1, progress.UnitsDefault)
 	if err != nil {
 		return xerrors.Errorf("failed to schedule copy %q to %q: %w", sourceEntry.Path, targetPath, err)
 	}
@@ -329,7 +447,10 @@ func (cp *CpCommand) copyFile(sourceEntry *irodsclient_fs.Entry, targetPath stri
 		if cp.syncFlagValues.Sync {
 			// if it is sync, remove
 			if cp.forceFlagValues.Force {
-				removeErr := cp.filesystem.RemoveDir(targetPath, true, true)
+				var removeErr error
+				if !cp.dryRunFlagValues.DryRun {
+					removeErr = cp.filesystem.RemoveDir(targetPath, true, true)
+				}
 
 				now := time.Now()
 				reportFile := &commons.TransferReportFile{
@@ -350,7 +471,10 @@ func (cp *CpCommand) copyFile(sourceEntry *irodsclient_fs.Entry, targetPath stri
 				// ask
 				overwrite := commons.InputYN(fmt.Sprintf("overwriting a file %q, but directory exists. Overwrite?", targetPath))
 				if overwrite {
-					removeErr := cp.filesystem.RemoveDir(targetPath, true, true)
+					var removeErr error
+					if !cp.dryRunFlagValues.DryRun {
+						removeErr = cp.filesystem.RemoveDir(targetPath, true, true)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -377,7 +501,8 @@ func (cp *CpCommand) copyFile(sourceEntry *irodsclient_fs.Entry, targetPath stri
 	}
 
 	if cp.differentialTransferFlagValues.DifferentialTransfer {
-		if cp.differentialTransferFlagValues.NoHash {
+		switch cp.differentialTransferFlagValues.Mode {
+		case commons.DiffModeSize:
 			if targetEntry.Size == sourceEntry.Size {
 				// skip
 				now := time.Now()
@@ -401,7 +526,35 @@ func (cp *CpCommand) copyFile(sourceEntry *irodsclient_fs.Entry, targetPath stri
 				logger.Debugf("skip copying a file %q to %q. The file already exists!", sourceEntry.Path, targetPath)
 				return nil
 			}
-		} else {
+		case commons.DiffModeMtime, commons.DiffModeSizeMtime:
+			if cp.differentialTransferFlagValues.Mode == commons.DiffModeSizeMtime && targetEntry.Size != sourceEntry.Size {
+				break
+			}
+
+			if commons.IsModTimeUpToDate(sourceEntry.ModifyTime, targetEntry.ModifyTime, cp.differentialTransferFlagValues.MtimeTolerance) {
+				// skip
+				now := time.Now()
+				reportFile := &commons.TransferReportFile{
+					Method:            commons.TransferMethodCopy,
+					StartAt:           now,
+					EndAt:             now,
+					SourcePath:        sourceEntry.Path,
+					SourceSize:        sourceEntry.Size,
+					SourceChecksum:    hex.EncodeToString(sourceEntry.CheckSum),
+					DestPath:          targetPath,
+					DestSize:          targetEntry.Size,
+					DestChecksum:      hex.EncodeToString(targetEntry.CheckSum),
+					ChecksumAlgorithm: string(sourceEntry.CheckSumAlgorithm),
+					Notes:             []string{"differential", string(cp.differentialTransferFlagValues.Mode), "not older", "skip"},
+				}
+
+				cp.transferReportManager.AddFile(reportFile)
+
+				commons.Printf("skip copying a file %q to %q. The data object is up to date!\n", sourceEntry.Path, targetPath)
+				logger.Debugf("skip copying a file %q to %q. The data object is up to date!", sourceEntry.Path, targetPath)
+				return nil
+			}
+		default:
 			if targetEntry.Size == sourceEntry.Size {
 				// compare hash
 				if len(sourceEntry.CheckSum) > 0 && bytes.Equal(sourceEntry.CheckSum, targetEntry.CheckSum) {
@@ -461,7 +614,81 @@ func (cp *CpCommand) copyFile(sourceEntry *irodsclient_fs.Entry, targetPath stri
 	return cp.scheduleCopy(sourceEntry, targetPath, targetEntry)
 }
 
-func (cp *CpCommand) copyDir(sourceEntry *irodsclient_fs.Entry, targetPath string) error {
+// handleScanFailure adds a file or a collection failed before it is scheduled to the report and failures of jobs
+// it returns nil to continue with other files if continue_on_error is set, or the error otherwise
+func (cp *CpCommand) handleScanFailure(sourceEntry *irodsclient_fs.Entry, targetPath string, err error) error {
+	notes := []string{"failed", "scan"}
+	if sourceEntry.IsDir() {
+		notes = append(notes, "directory")
+	}
+
+	now := time.Now()
+	reportFile := &commons.TransferReportFile{
+		Method:     commons.TransferMethodCopy,
+		StartAt:    now,
+		EndAt:      now,
+		SourcePath: sourceEntry.Path,
+		SourceSize: sourceEntry.Size,
+		DestPath:   targetPath,
+		Error:      err,
+		Notes:      notes,
+	}
+
+	cp.transferReportManager.AddFile(reportFile)
+
+	return cp.parallelJobManager.AddFailure(sourceEntry.Path, err)
+}
+
+func (cp *CpCommand) copyDir(sourceEntry *irodsclient_fs.Entry, targetPath string, filterRootPath string) error {
+	err := cp.prepareTargetDir(sourceEntry, targetPath)
+	if err != nil {
+		return cp.handleScanFailure(sourceEntry, targetPath, err)
+	}
+
+	// copy entries
+	entries, err := cp.filesystem.List(sourceEntry.Path)
+	if err != nil {
+		return cp.handleScanFailure(sourceEntry, targetPath, xerrors.Errorf("failed to list a directory %q: %w", sourceEntry.Path, err))
+	}
+
+	for _, entry := range entries {
+		if cp.hiddenFileFlagValues.Exclude {
+			if strings.HasPrefix(entry.Name, ".") {
+				continue
+			}
+		}
+
+		if cp.filterFlagValues.Filter.IsExcluded(filterRootPath, entry.Path, entry.IsDir()) {
+			continue
+		}
+
+		newEntryPath := commons.MakeTargetIRODSFilePath(cp.filesystem, entry.Path, targetPath)
+
+		if entry.IsDir() {
+			// dir, failures under it are handled in it
+			err = cp.copyDir(entry, newEntryPath, filterRootPath)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// file
+		err = cp.copyFile(entry, newEntryPath)
+		if err != nil {
+			err = cp.handleScanFailure(entry, newEntryPath, err)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// prepareTargetDir makes the target collection, or replaces a data object in the way on sync
+func (cp *CpCommand) prepareTargetDir(sourceEntry *irodsclient_fs.Entry, targetPath string) error {
 	commons.MarkPathMap(cp.updatedPathMap, targetPath)
 
 	targetEntry, err := cp.filesystem.Stat(targetPath)
@@ -469,9 +696,11 @@ func (cp *CpCommand) copyDir(sourceEntry *irodsclient_fs.Entry, targetPath strin
 		if irodsclient_types.IsFileNotFoundError(err) {
 			// target does not exist
 			// target must be a directory with new name
-			err = cp.filesystem.MakeDir(targetPath, true)
-			if err != nil {
-				return xerrors.Errorf("failed to make a directory %q: %w", targetPath, err)
+			if !cp.dryRunFlagValues.DryRun {
+				err = cp.filesystem.MakeDir(targetPath, true)
+				if err != nil {
+					return xerrors.Errorf("failed to make a directory %q: %w", targetPath, err)
+				}
 			}
 
 			now := time.Now()
@@ -494,7 +723,10 @@ func (cp *CpCommand) copyDir(sourceEntry *irodsclient_fs.Entry, targetPath strin
 			if cp.syncFlagValues.Sync {
 				// if it is sync, remove
 				if cp.forceFlagValues.Force {
-					removeErr := cp.filesystem.RemoveFile(targetPath, true)
+					var removeErr error
+					if !cp.dryRunFlagValues.DryRun {
+						removeErr = cp.filesystem.RemoveFile(targetPath, true)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -515,7 +747,10 @@ func (cp *CpCommand) copyDir(sourceEntry *irodsclient_fs.Entry, targetPath strin
 					// ask
 					overwrite := commons.InputYN(fmt.Sprintf("overwriting a directory %q, but file exists. Overwrite?", targetPath))
 					if overwrite {
-						removeErr := cp.filesystem.RemoveFile(targetPath, true)
+						var removeErr error
+						if !cp.dryRunFlagValues.DryRun {
+							removeErr = cp.filesystem.RemoveFile(targetPath, true)
+						}
 
 						now := time.Now()
 						reportFile := &commons.TransferReportFile{
@@ -542,36 +777,6 @@ func (cp *CpCommand) copyDir(sourceEntry *irodsclient_fs.Entry, targetPath strin
 		}
 	}
 
-	// copy entries
-	entries, err := cp.filesystem.List(sourceEntry.Path)
-	if err != nil {
-		return xerrors.Errorf("failed to list a directory %q: %w", sourceEntry.Path, err)
-	}
-
-	for _, entry := range entries {
-		if cp.hiddenFileFlagValues.Exclude {
-			if strings.HasPrefix(entry.Name, ".") {
-				continue
-			}
-		}
-
-		newEntryPath := commons.MakeTargetIRODSFilePath(cp.filesystem, entry.Path, targetPath)
-
-		if entry.IsDir() {
-			// dir
-			err = cp.copyDir(entry, newEntryPath)
-			if err != nil {
-				return err
-			}
-		} else {
-			// file
-			err = cp.copyFile(entry, newEntryPath)
-			if err != nil {
-				return err
-			}
-		}
-	}
-
 	return nil
 }
 
@@ -581,10 +786,11 @@ func (cp *CpCommand) deleteExtra(targetPath string) error {
 	zone := commons.GetZone()
 	targetPath = commons.MakeIRODSPath(cwd, home, zone, targetPath)
 
-	return cp.deleteExtraInternal(targetPath)
+	// paths under the target are compared with the source paths relative to the transfer root
+	return cp.deleteExtraInternal(targetPath, targetPath)
 }
 
-func (cp *CpCommand) deleteExtraInternal(targetPath string) error {
+func (cp *CpCommand) deleteExtraInternal(targetPath string, filterRootPath string) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
 		"struct":   "CpCommand",
@@ -593,6 +799,11 @@ func (cp *CpCommand) deleteExtraInternal(targetPath string) error {
 
 	targetEntry, err := cp.filesystem.Stat(targetPath)
 	if err != nil {
+		if cp.dryRunFlagValues.DryRun && irodsclient_types.IsFileNotFoundError(err) {
+			// the target is not created in dry run, nothing to delete
+			return nil
+		}
+
 		return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
 	}
 
@@ -602,7 +813,10 @@ func (cp *CpCommand) deleteExtraInternal(targetPath string) error {
 			// extra file
 			logger.Debugf("removing an extra data object %q", targetPath)
 
-			removeErr := cp.filesystem.RemoveFile(targetPath, true)
+			var removeErr error
+			if !cp.dryRunFlagValues.DryRun {
+				removeErr = cp.filesystem.RemoveFile(targetPath, true)
+			}
 
 			now := time.Now()
 			reportFile := &commons.TransferReportFile{
@@ -629,7 +843,10 @@ func (cp *CpCommand) deleteExtraInternal(targetPath string) error {
 		// extra dir
 		logger.Debugf("removing an extra collection %q", targetPath)
 
-		removeErr := cp.filesystem.RemoveDir(targetPath, true, true)
+		var removeErr error
+		if !cp.dryRunFlagValues.DryRun {
+			removeErr = cp.filesystem.RemoveDir(targetPath, true, true)
+		}
 
 		now := time.Now()
 		reportFile := &commons.TransferReportFile{
@@ -656,7 +873,12 @@ func (cp *CpCommand) deleteExtraInternal(targetPath string) error {
 
 		for _, entry := range entries {
 			newTargetPath := path.Join(targetPath, entry.Name)
-			err = cp.deleteExtraInternal(newTargetPath)
+			if cp.filterFlagValues.Filter.IsExcluded(filterRootPath, newTargetPath, entry.IsDir()) {
+				// excluded paths are not transferred, keep them
+				continue
+			}
+
+			err = cp.deleteExtraInternal(newTargetPath, filterRootPath)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,59 @@
+package subcmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCp(t *testing.T) {
+	t.Run("test ScanFailure", testCpScanFailure)
+}
+
+func testCpScanFailure(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	reportManager, err := commons.NewTransferReportManager(true, reportPath, false, false)
+	assert.NoError(t, err)
+
+	jobManager := commons.NewParallelJobManager(nil, 1, false, false)
+	jobManager.SetContinueOnError(true)
+	jobManager.Start()
+
+	cp := &CpCommand{
+		transferReportManager: reportManager,
+		parallelJobManager:    jobManager,
+	}
+
+	sourceEntry := &irodsclient_fs.Entry{Path: "/zone/home/user/dir", Type: irodsclient_fs.DirectoryEntry}
+	targetPath := "/zone/home/user/copied"
+	scanErr := commons.NewNotDirError(targetPath)
+
+	// continues with other entries and reports the failure at the end
+	err = cp.handleScanFailure(sourceEntry, targetPath, scanErr)
+	assert.NoError(t, err)
+
+	jobManager.DoneScheduling()
+	err = jobManager.Wait()
+	assert.True(t, commons.IsJobFailuresError(err))
+	reportManager.Release()
+
+	files, err := commons.ReadTransferReportFiles(reportPath)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, commons.TransferMethodCopy, files[0].Method)
+	assert.Equal(t, sourceEntry.Path, files[0].SourcePath)
+	assert.Equal(t, targetPath, files[0].DestPath)
+	assert.True(t, files[0].HasNote("scan"))
+	assert.True(t, files[0].HasNote("directory"))
+
+	// the error is given back without continue_on_error
+	cp.parallelJobManager = commons.NewParallelJobManager(nil, 1, false, false)
+	cp.transferReportManager, err = commons.NewTransferReportManager(false, "", false, false)
+	assert.NoError(t, err)
+
+	err = cp.handleScanFailure(sourceEntry, targetPath, scanErr)
+	assert.True(t, commons.IsNotDirError(err))
+}
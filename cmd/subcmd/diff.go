@@ -0,0 +1,531 @@
+package subcmd
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [local dir] i:[collection] or diff i:[collection1] i:[collection2]",
+	Short: "Compare a local directory with an iRODS collection",
+	Long:  `This compares a local directory with an iRODS collection, or two iRODS collections, without transferring data. It exits with a non-zero code if they differ.`,
+	RunE:  processDiffCommand,
+	Args:  cobra.ExactArgs(2),
+}
+
+func AddDiffCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(diffCmd, false)
+
+	flag.SetTicketAccessFlags(diffCmd)
+	flag.SetDifferentialTransferFlags(diffCmd, false)
+	flag.SetHiddenFileFlags(diffCmd)
+	flag.SetFilterFlags(diffCmd)
+	flag.SetDiffFlags(diffCmd)
+
+	rootCmd.AddCommand(diffCmd)
+}
+
+func processDiffCommand(command *cobra.Command, args []string) error {
+	diff, err := NewDiffCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return diff.Process()
+}
+
+// diffEntry is a file or a directory in a local or iRODS tree
+type diffEntry struct {
+	Path              string
+	Local             bool
+	IsDir             bool
+	Size              int64
+	ModTime           time.Time
+	ChecksumAlgorithm irodsclient_types.ChecksumAlgorithm
+	Checksum          []byte
+}
+
+type DiffCommand struct {
+	command *cobra.Command
+
+	ticketAccessFlagValues         *flag.TicketAccessFlagValues
+	differentialTransferFlagValues *flag.DifferentialTransferFlagValues
+	hiddenFileFlagValues           *flag.HiddenFileFlagValues
+	filterFlagValues               *flag.FilterFlagValues
+	diffFlagValues                 *flag.DiffFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	sourcePath  string
+	sourceLocal bool
+	targetPath  string
+	targetLocal bool
+}
+
+func NewDiffCommand(command *cobra.Command, args []string) (*DiffCommand, error) {
+	diff := &DiffCommand{
+		command: command,
+
+		ticketAccessFlagValues:         flag.GetTicketAccessFlagValues(),
+		differentialTransferFlagValues: flag.GetDifferentialTransferFlagValues(),
+		hiddenFileFlagValues:           flag.GetHiddenFileFlagValues(),
+		filterFlagValues:               flag.GetFilterFlagValues(),
+		diffFlagValues:                 flag.GetDiffFlagValues(),
+	}
+
+	// path
+	diff.sourcePath, diff.sourceLocal = diff.parsePath(args[0])
+	diff.targetPath, diff.targetLocal = diff.parsePath(args[1])
+
+	if diff.sourceLocal && diff.targetLocal {
+		return nil, xerrors.Errorf("failed to compare two local paths, at least one path must start with 'i:'")
+	}
+
+	err := diff.differentialTransferFlagValues.Validate()
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
+	return diff, nil
+}
+
+func (diff *DiffCommand) parsePath(p string) (string, bool) {
+	if strings.HasPrefix(p, "i:") {
+		return p[2:], false
+	}
+
+	return p, true
+}
+
+func (diff *DiffCommand) Process() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "DiffCommand",
+		"function": "Process",
+	})
+
+	cont, err := flag.ProcessCommonFlags(diff.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// config
+	appConfig := commons.GetConfig()
+	syncAccount := false
+	if len(diff.ticketAccessFlagValues.Name) > 0 {
+		logger.Debugf("use ticket %q", diff.ticketAccessFlagValues.Name)
+		appConfig.Ticket = diff.ticketAccessFlagValues.Name
+		syncAccount = true
+	}
+
+	if syncAccount {
+		err := commons.SyncAccount()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create a file system
+	diff.account = commons.GetAccount()
+	diff.filesystem, err = commons.GetIRODSFSClient(diff.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer diff.filesystem.Release()
+
+	// run
+	sourceEntries, err := diff.listEntries(diff.sourcePath, diff.sourceLocal)
+	if err != nil {
+		return err
+	}
+
+	targetEntries, err := diff.listEntries(diff.targetPath, diff.targetLocal)
+	if err != nil {
+		return err
+	}
+
+	relPaths := []string{}
+	for relPath := range sourceEntries {
+		relPaths = append(relPaths, relPath)
+	}
+
+	for relPath := range targetEntries {
+		if _, ok := sourceEntries[relPath]; !ok {
+			relPaths = append(relPaths, relPath)
+		}
+	}
+
+	sort.Strings(relPaths)
+
+	differences := 0
+	for _, relPath := range relPaths {
+		result, err := diff.compare(relPath, sourceEntries[relPath], targetEntries[relPath])
+		if err != nil {
+			return xerrors.Errorf("failed to compare %q: %w", relPath, err)
+		}
+
+		if result.Status != commons.DiffStatusIdentical {
+			differences++
+		}
+
+		err = diff.printResult(result)
+		if err != nil {
+			return err
+		}
+	}
+
+	if differences > 0 {
+		return commons.NewDifferenceFoundError(differences)
+	}
+
+	return nil
+}
+
+func (diff *DiffCommand) printResult(result *commons.DiffResult) error {
+	if result.Status == commons.DiffStatusIdentical && !diff.diffFlagValues.ShowIdentical {
+		return nil
+	}
+
+	if diff.diffFlagValues.JSON {
+		jsonBytes, err := json.Marshal(result)
+		if err != nil {
+			return xerrors.Errorf("failed to marshal diff result to json: %w", err)
+		}
+
+		commons.Printf("%s\n", string(jsonBytes))
+		return nil
+	}
+
+	commons.Printf("%-16s %s\n", result.Status, result.Path)
+	return nil
+}
+
+// listEntries returns entries in the tree keyed by relative path
+// if the root is a file, it is keyed by its name
+func (diff *DiffCommand) listEntries(rootPath string, local bool) (map[string]*diffEntry, error) {
+	if local {
+		return diff.listLocalEntries(commons.MakeLocalPath(rootPath))
+	}
+
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	return diff.listIRODSEntries(commons.MakeIRODSPath(cwd, home, zone, rootPath))
+}
+
+// isExcluded matches filters relative to the compared root, so both trees are filtered the same way
+func (diff *DiffCommand) isExcluded(rootPath string, name string, entryPath string, isDir bool) bool {
+	if diff.hiddenFileFlagValues.Exclude && strings.HasPrefix(name, ".") {
+		return true
+	}
+
+	return diff.filterFlagValues.Filter.IsExcluded(rootPath, entryPath, isDir)
+}
+
+func (diff *DiffCommand) listLocalEntries(rootPath string) (map[string]*diffEntry, error) {
+	rootStat, err := os.Stat(rootPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, irodsclient_types.NewFileNotFoundError(rootPath)
+		}
+
+		return nil, xerrors.Errorf("failed to stat %q: %w", rootPath, err)
+	}
+
+	entries := map[string]*diffEntry{}
+
+	if !rootStat.IsDir() {
+		entries[rootStat.Name()] = &diffEntry{
+			Path:    rootPath,
+			Local:   true,
+			IsDir:   false,
+			Size:    rootStat.Size(),
+			ModTime: rootStat.ModTime(),
+		}
+		return entries, nil
+	}
+
+	err = filepath.WalkDir(rootPath, func(entryPath string, dirEntry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+
+		if entryPath == rootPath {
+			return nil
+		}
+
+		if diff.isExcluded(rootPath, dirEntry.Name(), entryPath, dirEntry.IsDir()) {
+			if dirEntry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		entryStat, err := os.Stat(entryPath)
+		if err != nil {
+			return xerrors.Errorf("failed to stat %q: %w", entryPath, err)
+		}
+
+		relPath, err := filepath.Rel(rootPath, entryPath)
+		if err != nil {
+			return xerrors.Errorf("failed to compute relative path of %q to %q: %w", entryPath, rootPath, err)
+		}
+
+		entries[filepath.ToSlash(relPath)] = &diffEntry{
+			Path:    entryPath,
+			Local:   true,
+			IsDir:   entryStat.IsDir(),
+			Size:    entryStat.Size(),
+			ModTime: entryStat.ModTime(),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to walk %q: %w", rootPath, err)
+	}
+
+	return entries, nil
+}
+
+func (diff *DiffCommand) listIRODSEntries(rootPath string) (map[string]*diffEntry, error) {
+	rootEntry, err := diff.filesystem.Stat(rootPath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to stat %q: %w", rootPath, err)
+	}
+
+	entries := map[string]*diffEntry{}
+
+	if !rootEntry.IsDir() {
+		entries[rootEntry.Name] = diff.newIRODSDiffEntry(rootEntry)
+		return entries, nil
+	}
+
+	err = diff.walkIRODSEntries(rootPath, rootPath, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (diff *DiffCommand) walkIRODSEntries(rootPath string, dirPath string, entries map[string]*diffEntry) error {
+	dirEntries, err := diff.filesystem.List(dirPath)
+	if err != nil {
+		return xerrors.Errorf("failed to list dir %q: %w", dirPath, err)
+	}
+
+	for _, entry := range dirEntries {
+		if diff.isExcluded(rootPath, entry.Name, entry.Path, entry.IsDir()) {
+			continue
+		}
+
+		relPath := strings.TrimPrefix(entry.Path[len(rootPath):], "/")
+		entries[relPath] = diff.newIRODSDiffEntry(entry)
+
+		if entry.IsDir() {
+			err = diff.walkIRODSEntries(rootPath, entry.Path, entries)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (diff *DiffCommand) newIRODSDiffEntry(entry *irodsclient_fs.Entry) *diffEntry {
+	return &diffEntry{
+		Path:              entry.Path,
+		Local:             false,
+		IsDir:             entry.IsDir(),
+		Size:              entry.Size,
+		ModTime:           entry.ModifyTime,
+		ChecksumAlgorithm: entry.CheckSumAlgorithm,
+		Checksum:          entry.CheckSum,
+	}
+}
+
+func (diff *DiffCommand) compare(relPath string, sourceEntry *diffEntry, targetEntry *diffEntry) (*commons.DiffResult, error) {
+	result := &commons.DiffResult{
+		Path: relPath,
+	}
+
+	if sourceEntry != nil {
+		result.SourcePath = sourceEntry.Path
+		result.SourceSize = sourceEntry.Size
+	}
+
+	if targetEntry != nil {
+		result.TargetPath = targetEntry.Path
+		result.TargetSize = targetEntry.Size
+	}
+
+	if targetEntry == nil {
+		result.Status = diff.getOnlyStatus(sourceEntry, commons.DiffStatusOnlySource)
+		return result, nil
+	}
+
+	if sourceEntry == nil {
+		result.Status = diff.getOnlyStatus(targetEntry, commons.DiffStatusOnlyTarget)
+		return result, nil
+	}
+
+	if sourceEntry.IsDir != targetEntry.IsDir {
+		result.Status = commons.DiffStatusTypeDiffers
+		return result, nil
+	}
+
+	if sourceEntry.IsDir {
+		result.Status = commons.DiffStatusIdentical
+		return result, nil
+	}
+
+	mode := diff.differentialTransferFlagValues.Mode
+	switch mode {
+	case commons.DiffModeSize:
+		if sourceEntry.Size != targetEntry.Size {
+			result.Status = commons.DiffStatusSizeDiffers
+			return result, nil
+		}
+	case commons.DiffModeMtime, commons.DiffModeSizeMtime:
+		if mode == commons.DiffModeSizeMtime && sourceEntry.Size != targetEntry.Size {
+			result.Status = commons.DiffStatusSizeDiffers
+			return result, nil
+		}
+
+		if commons.IsModTimeDifferent(sourceEntry.ModTime, targetEntry.ModTime, diff.differentialTransferFlagValues.MtimeTolerance) {
+			result.Status = commons.DiffStatusMtimeDiffers
+			return result, nil
+		}
+	default:
+		if sourceEntry.Size != targetEntry.Size {
+			result.Status = commons.DiffStatusSizeDiffers
+			return result, nil
+		}
+
+		sourceChecksum, targetChecksum, algorithm, err := diff.getChecksums(sourceEntry, targetEntry)
+		if err != nil {
+			return nil, err
+		}
+
+		result.SourceChecksum = hex.EncodeToString(sourceChecksum)
+		result.TargetChecksum = hex.EncodeToString(targetChecksum)
+		result.ChecksumAlgorithm = string(algorithm)
+
+		if !bytes.Equal(sourceChecksum, targetChecksum) {
+			result.Status = commons.DiffStatusChecksumDiffers
+			return result, nil
+		}
+	}
+
+	result.Status = commons.DiffStatusIdentical
+	return result, nil
+}
+
+// getOnlyStatus returns only-local or only-remote if the other side is of different kind
+func (diff *DiffCommand) getOnlyStatus(entry *diffEntry, defaultStatus commons.DiffStatus) commons.DiffStatus {
+	if diff.sourceLocal == diff.targetLocal {
+		return defaultStatus
+	}
+
+	if entry.Local {
+		return commons.DiffStatusOnlyLocal
+	}
+
+	return commons.DiffStatusOnlyRemote
+}
+
+// getChecksums returns checksums of both entries computed with the same algorithm
+func (diff *DiffCommand) getChecksums(sourceEntry *diffEntry, targetEntry *diffEntry) ([]byte, []byte, irodsclient_types.ChecksumAlgorithm, error) {
+	// use the algorithm of the iRODS side
+	irodsEntry := targetEntry
+	if !sourceEntry.Local {
+		irodsEntry = sourceEntry
+	}
+
+	err := diff.ensureIRODSChecksum(irodsEntry)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	algorithm := irodsEntry.ChecksumAlgorithm
+
+	sourceChecksum, err := diff.getChecksum(sourceEntry, algorithm)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	targetChecksum, err := diff.getChecksum(targetEntry, algorithm)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return sourceChecksum, targetChecksum, algorithm, nil
+}
+
+func (diff *DiffCommand) getChecksum(entry *diffEntry, algorithm irodsclient_types.ChecksumAlgorithm) ([]byte, error) {
+	if entry.Local {
+		localChecksum, err := irodsclient_util.HashLocalFile(entry.Path, string(algorithm))
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get hash of %q: %w", entry.Path, err)
+		}
+
+		return localChecksum, nil
+	}
+
+	err := diff.ensureIRODSChecksum(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.ChecksumAlgorithm != algorithm {
+		// checksums computed with different algorithms never match
+		return nil, xerrors.Errorf("failed to compare checksums of %q, algorithm %q is different from %q", entry.Path, entry.ChecksumAlgorithm, algorithm)
+	}
+
+	return entry.Checksum, nil
+}
+
+// ensureIRODSChecksum asks the server to compute a checksum if the data object does not have one
+func (diff *DiffCommand) ensureIRODSChecksum(entry *diffEntry) error {
+	if len(entry.Checksum) > 0 {
+		return nil
+	}
+
+	checksum, err := commons.ComputeDataObjectChecksum(diff.filesystem, entry.Path, false)
+	if err != nil {
+		return err
+	}
+
+	entry.ChecksumAlgorithm = checksum.Algorithm
+	entry.Checksum = checksum.Checksum
+	return nil
+}
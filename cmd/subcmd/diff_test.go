@@ -0,0 +1,136 @@
+package subcmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("test Compare", testDiffCompare)
+	t.Run("test CompareOnlyStatus", testDiffCompareOnlyStatus)
+}
+
+func newLocalDiffEntry(t *testing.T, dirPath string, name string, content string, modTime time.Time) *diffEntry {
+	localPath := filepath.Join(dirPath, name)
+	err := os.WriteFile(localPath, []byte(content), 0644)
+	assert.NoError(t, err)
+
+	return &diffEntry{
+		Path:    localPath,
+		Local:   true,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	}
+}
+
+// newIRODSDiffEntryForTest returns a data object entry with a registered checksum, so the server is not asked
+func newIRODSDiffEntryForTest(t *testing.T, name string, content string, modTime time.Time) *diffEntry {
+	hash, err := irodsclient_util.HashStrings([]string{content}, string(irodsclient_types.ChecksumAlgorithmSHA256))
+	assert.NoError(t, err)
+
+	return &diffEntry{
+		Path:              "/zone/home/user/" + name,
+		Size:              int64(len(content)),
+		ModTime:           modTime,
+		ChecksumAlgorithm: irodsclient_types.ChecksumAlgorithmSHA256,
+		Checksum:          hash,
+	}
+}
+
+func testDiffCompare(t *testing.T) {
+	dirPath := t.TempDir()
+	modTime := time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mode     commons.DiffMode
+		source   *diffEntry
+		target   *diffEntry
+		expected commons.DiffStatus
+	}{
+		// size
+		{"size, same", commons.DiffModeSize, newLocalDiffEntry(t, dirPath, "a1", "abc", modTime), newIRODSDiffEntryForTest(t, "a1", "abc", modTime.Add(time.Hour)), commons.DiffStatusIdentical},
+		{"size, same size different content", commons.DiffModeSize, newLocalDiffEntry(t, dirPath, "a2", "abc", modTime), newIRODSDiffEntryForTest(t, "a2", "xyz", modTime), commons.DiffStatusIdentical},
+		{"size, different", commons.DiffModeSize, newLocalDiffEntry(t, dirPath, "a3", "abc", modTime), newIRODSDiffEntryForTest(t, "a3", "abcd", modTime), commons.DiffStatusSizeDiffers},
+
+		// mtime
+		{"mtime, within tolerance", commons.DiffModeMtime, newLocalDiffEntry(t, dirPath, "b1", "abc", modTime), newIRODSDiffEntryForTest(t, "b1", "abc", modTime.Add(-2*time.Second)), commons.DiffStatusIdentical},
+		{"mtime, different", commons.DiffModeMtime, newLocalDiffEntry(t, dirPath, "b2", "abc", modTime), newIRODSDiffEntryForTest(t, "b2", "abc", modTime.Add(time.Minute)), commons.DiffStatusMtimeDiffers},
+		{"mtime, size is ignored", commons.DiffModeMtime, newLocalDiffEntry(t, dirPath, "b3", "abc", modTime), newIRODSDiffEntryForTest(t, "b3", "abcd", modTime), commons.DiffStatusIdentical},
+
+		// size+mtime
+		{"size+mtime, same", commons.DiffModeSizeMtime, newLocalDiffEntry(t, dirPath, "c1", "abc", modTime), newIRODSDiffEntryForTest(t, "c1", "xyz", modTime), commons.DiffStatusIdentical},
+		{"size+mtime, size differs", commons.DiffModeSizeMtime, newLocalDiffEntry(t, dirPath, "c2", "abc", modTime), newIRODSDiffEntryForTest(t, "c2", "abcd", modTime.Add(time.Minute)), commons.DiffStatusSizeDiffers},
+		{"size+mtime, mtime differs", commons.DiffModeSizeMtime, newLocalDiffEntry(t, dirPath, "c3", "abc", modTime), newIRODSDiffEntryForTest(t, "c3", "abc", modTime.Add(time.Minute)), commons.DiffStatusMtimeDiffers},
+
+		// checksum
+		{"checksum, same", commons.DiffModeChecksum, newLocalDiffEntry(t, dirPath, "d1", "abc", modTime), newIRODSDiffEntryForTest(t, "d1", "abc", modTime.Add(time.Hour)), commons.DiffStatusIdentical},
+		{"checksum, different content", commons.DiffModeChecksum, newLocalDiffEntry(t, dirPath, "d2", "abc", modTime), newIRODSDiffEntryForTest(t, "d2", "xyz", modTime), commons.DiffStatusChecksumDiffers},
+		{"checksum, size differs", commons.DiffModeChecksum, newLocalDiffEntry(t, dirPath, "d3", "abc", modTime), newIRODSDiffEntryForTest(t, "d3", "abcd", modTime), commons.DiffStatusSizeDiffers},
+
+		// types
+		{"directories", commons.DiffModeChecksum, &diffEntry{Path: dirPath, Local: true, IsDir: true}, &diffEntry{Path: "/zone/home/user/dir", IsDir: true}, commons.DiffStatusIdentical},
+		{"type differs", commons.DiffModeSize, &diffEntry{Path: dirPath, Local: true, IsDir: true}, newIRODSDiffEntryForTest(t, "e1", "abc", modTime), commons.DiffStatusTypeDiffers},
+	}
+
+	for _, test := range tests {
+		diff := &DiffCommand{
+			differentialTransferFlagValues: &flag.DifferentialTransferFlagValues{
+				Mode:           test.mode,
+				MtimeTolerance: 2 * time.Second,
+			},
+			sourceLocal: true,
+			targetLocal: false,
+		}
+
+		result, err := diff.compare(filepath.Base(test.source.Path), test.source, test.target)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, result.Status, test.name)
+		assert.Equal(t, test.source.Path, result.SourcePath, test.name)
+		assert.Equal(t, test.target.Path, result.TargetPath, test.name)
+
+		if test.mode == commons.DiffModeChecksum && test.expected != commons.DiffStatusSizeDiffers && !test.source.IsDir {
+			assert.Equal(t, string(irodsclient_types.ChecksumAlgorithmSHA256), result.ChecksumAlgorithm, test.name)
+			assert.NotEmpty(t, result.SourceChecksum, test.name)
+		}
+	}
+}
+
+func testDiffCompareOnlyStatus(t *testing.T) {
+	modTime := time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)
+	localEntry := &diffEntry{Path: "/data/a", Local: true, Size: 3, ModTime: modTime}
+	irodsEntry := newIRODSDiffEntryForTest(t, "a", "abc", modTime)
+
+	diff := &DiffCommand{
+		differentialTransferFlagValues: &flag.DifferentialTransferFlagValues{Mode: commons.DiffModeSize},
+		sourceLocal:                    true,
+		targetLocal:                    false,
+	}
+
+	result, err := diff.compare("a", localEntry, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, commons.DiffStatusOnlyLocal, result.Status)
+
+	result, err = diff.compare("a", nil, irodsEntry)
+	assert.NoError(t, err)
+	assert.Equal(t, commons.DiffStatusOnlyRemote, result.Status)
+
+	// between two collections
+	diff.sourceLocal = false
+
+	result, err = diff.compare("a", irodsEntry, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, commons.DiffStatusOnlySource, result.Status)
+
+	result, err = diff.compare("a", nil, irodsEntry)
+	assert.NoError(t, err)
+	assert.Equal(t, commons.DiffStatusOnlyTarget, result.Status)
+}
@@ -0,0 +1,224 @@
+package subcmd
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/dustin/go-humanize"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var duCmd = &cobra.Command{
+	Use:     "du [collection1] [collection2] ...",
+	Aliases: []string{"idu", "usage"},
+	Short:   "Display storage usage of iRODS collections",
+	Long:    `This displays total size and number of data objects in iRODS collections and their sub-collections. Sizes of all replicas are counted, the same as iRODS quotas, but a data object with multiple replicas is counted once.`,
+	RunE:    processDuCommand,
+	Args:    cobra.ArbitraryArgs,
+}
+
+func AddDuCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(duCmd, true)
+
+	flag.SetUsageFlags(duCmd)
+
+	rootCmd.AddCommand(duCmd)
+}
+
+func processDuCommand(command *cobra.Command, args []string) error {
+	du, err := NewDuCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return du.Process()
+}
+
+type DuCommand struct {
+	command *cobra.Command
+
+	usageFlagValues *flag.UsageFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	sourcePaths []string
+}
+
+func NewDuCommand(command *cobra.Command, args []string) (*DuCommand, error) {
+	du := &DuCommand{
+		command: command,
+
+		usageFlagValues: flag.GetUsageFlagValues(),
+	}
+
+	// path
+	du.sourcePaths = args[:]
+
+	if len(args) == 0 {
+		du.sourcePaths = []string{"."}
+	}
+
+	return du, nil
+}
+
+func (du *DuCommand) Process() error {
+	cont, err := flag.ProcessCommonFlags(du.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	du.account = commons.GetAccount()
+	du.filesystem, err = commons.GetIRODSFSClient(du.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer du.filesystem.Release()
+
+	// run
+	for _, sourcePath := range du.sourcePaths {
+		err = du.duOne(sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to get usage of %q: %w", sourcePath, err)
+		}
+	}
+
+	return nil
+}
+
+func (du *DuCommand) duOne(sourcePath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "DuCommand",
+		"function": "duOne",
+	})
+
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	sourcePath = commons.MakeIRODSPath(cwd, home, zone, sourcePath)
+
+	sourceEntry, err := du.filesystem.Stat(sourcePath)
+	if err != nil {
+		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
+	}
+
+	if !sourceEntry.IsDir() {
+		return commons.NewNotDirError(sourcePath)
+	}
+
+	connection, err := du.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer du.filesystem.ReturnMetadataConnection(connection)
+
+	logger.Debugf("getting usages of %q", sourcePath)
+
+	usages, err := commons.GetCollectionUsages(connection, sourcePath, du.usageFlagValues.GroupBy)
+	if err != nil {
+		return xerrors.Errorf("failed to get collection usages: %w", err)
+	}
+
+	collectionPaths, err := du.getCollectionPaths(connection, sourcePath)
+	if err != nil {
+		return err
+	}
+
+	// sub-collections come before their parents, same as du
+	children := map[string][]string{}
+	for _, collectionPath := range collectionPaths {
+		if collectionPath == sourcePath {
+			continue
+		}
+
+		parent := path.Dir(collectionPath)
+		children[parent] = append(children[parent], collectionPath)
+	}
+
+	sums := commons.SumCollectionUsages(sourcePath, usages)
+
+	du.printUsages(sourcePath, 0, children, sums)
+	return nil
+}
+
+func (du *DuCommand) getCollectionPaths(connection *irodsclient_conn.IRODSConnection, sourcePath string) ([]string, error) {
+	if du.usageFlagValues.Depth == 0 {
+		return []string{sourcePath}, nil
+	}
+
+	// list all sub-collections to display empty ones too
+	collections, err := commons.FindCollections(connection, sourcePath, commons.NewFindCondition())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list sub-collections of %q: %w", sourcePath, err)
+	}
+
+	collectionPaths := []string{}
+	for _, collection := range collections {
+		collectionPaths = append(collectionPaths, collection.Path)
+	}
+
+	sort.Strings(collectionPaths)
+	return collectionPaths, nil
+}
+
+func (du *DuCommand) printUsages(collectionPath string, depth int, children map[string][]string, collectionSums map[string][]*commons.CollectionUsage) {
+	if du.usageFlagValues.Depth < 0 || depth < du.usageFlagValues.Depth {
+		for _, child := range children[collectionPath] {
+			du.printUsages(child, depth+1, children, collectionSums)
+		}
+	}
+
+	sums := collectionSums[collectionPath]
+	if len(sums) == 0 {
+		// empty collection
+		sums = []*commons.CollectionUsage{
+			{
+				Path: collectionPath,
+			},
+		}
+	}
+
+	for _, sum := range sums {
+		columns := []string{
+			du.getSizeString(sum.Size),
+			fmt.Sprintf("%d", sum.ObjectCount),
+		}
+
+		if du.usageFlagValues.GroupBy != commons.UsageGroupByNone {
+			columns = append(columns, sum.Group)
+		}
+
+		columns = append(columns, sum.Path)
+		commons.Printf("%s\n", strings.Join(columns, "\t"))
+	}
+}
+
+func (du *DuCommand) getSizeString(size int64) string {
+	if du.usageFlagValues.HumanReadableSizes {
+		return humanize.Bytes(uint64(size))
+	}
+
+	return fmt.Sprintf("%d", size)
+}
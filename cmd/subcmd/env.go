@@ -37,11 +37,15 @@ func processEnvCommand(command *cobra.Command, args []string) error {
 
 type EnvCommand struct {
 	command *cobra.Command
+
+	outputFlagValues *flag.OutputFlagValues
 }
 
 func NewEnvCommand(command *cobra.Command, args []string) (*EnvCommand, error) {
 	env := &EnvCommand{
 		command: command,
+
+		outputFlagValues: flag.GetOutputFlagValues(),
 	}
 
 	return env, nil
@@ -70,6 +74,32 @@ func (env *EnvCommand) printEnvironment() error {
 		return xerrors.Errorf("environment is not set")
 	}
 
+	if env.outputFlagValues.Format.IsStructured() {
+		record := commons.EnvironmentRecord{
+			SessionEnvironmentFile:  envMgr.GetSessionFilePath(os.Getppid()),
+			EnvironmentFile:         envMgr.GetEnvironmentFilePath(),
+			AuthenticationFile:      envMgr.GetPasswordFilePath(),
+			Host:                    envMgr.Environment.Host,
+			Port:                    envMgr.Environment.Port,
+			Zone:                    envMgr.Environment.Zone,
+			Username:                envMgr.Environment.Username,
+			DefaultResource:         envMgr.Environment.DefaultResource,
+			DefaultHashScheme:       envMgr.Environment.DefaultHashScheme,
+			AuthenticationScheme:    envMgr.Environment.AuthenticationScheme,
+			ClientServerNegotiation: envMgr.Environment.ClientServerNegotiation,
+			ClientServerPolicy:      envMgr.Environment.ClientServerPolicy,
+			SSLCACertificateFile:    envMgr.Environment.SSLCACertificateFile,
+			SSLCACertificatePath:    envMgr.Environment.SSLCACertificatePath,
+			SSLVerifyServer:         envMgr.Environment.SSLVerifyServer,
+			EncryptionKeySize:       envMgr.Environment.EncryptionKeySize,
+			EncryptionAlgorithm:     envMgr.Environment.EncryptionAlgorithm,
+			EncryptionSaltSize:      envMgr.Environment.EncryptionSaltSize,
+			EncryptionNumHashRounds: envMgr.Environment.EncryptionNumHashRounds,
+		}
+
+		return commons.PrintRecord(env.outputFlagValues.Format, record)
+	}
+
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
 
@@ -0,0 +1,84 @@
+package subcmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	t.Run("test DiffFilterRelativeToRoot", testDiffFilterRelativeToRoot)
+	t.Run("test PutFilterTopLevelSource", testPutFilterTopLevelSource)
+}
+
+// parseFilterFlags parses filter flags as the commands do
+func parseFilterFlags(t *testing.T, args ...string) *cobra.Command {
+	flag.GetFilterFlagValues().Filter = commons.NewPathFilter()
+
+	command := &cobra.Command{Use: "test"}
+	flag.SetFilterFlags(command)
+	flag.SetHiddenFileFlags(command)
+	flag.SetNoRootFlags(command)
+
+	err := command.ParseFlags(args)
+	assert.NoError(t, err)
+
+	return command
+}
+
+func makeFilterTestTree(t *testing.T, rootPath string, files ...string) {
+	for _, file := range files {
+		filePath := filepath.Join(rootPath, file)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		assert.NoError(t, os.WriteFile(filePath, []byte("data"), 0644))
+	}
+}
+
+func testDiffFilterRelativeToRoot(t *testing.T) {
+	// the tree is under a directory named "tmp", patterns must not match it
+	rootPath := filepath.Join(t.TempDir(), "tmp", "data")
+	makeFilterTestTree(t, rootPath, "a.txt", "b.log", "src/c.txt", "src/tmp/d.txt", "tmp/e.txt")
+
+	command := parseFilterFlags(t, "--exclude", "tmp/", "--exclude", "/src/*.txt", "--include", "b.log", "--exclude", "*.log")
+
+	diff := &DiffCommand{
+		command:              command,
+		hiddenFileFlagValues: flag.GetHiddenFileFlagValues(),
+		filterFlagValues:     flag.GetFilterFlagValues(),
+	}
+
+	entries, err := diff.listLocalEntries(rootPath)
+	assert.NoError(t, err)
+
+	names := []string{}
+	for name := range entries {
+		names = append(names, name)
+	}
+
+	assert.ElementsMatch(t, []string{"a.txt", "b.log", "src"}, names)
+}
+
+func testPutFilterTopLevelSource(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	commons.SetDefaultConfigIfEmpty()
+
+	sourcePath := filepath.Join(t.TempDir(), "data.tmp")
+	makeFilterTestTree(t, filepath.Dir(sourcePath), "data.tmp")
+
+	command := parseFilterFlags(t, "--exclude", "*.tmp")
+
+	put := &PutCommand{
+		command:          command,
+		filterFlagValues: flag.GetFilterFlagValues(),
+		noRootFlagValues: flag.GetNoRootFlagValues(),
+	}
+
+	// the source is excluded before anything is uploaded, so no filesystem is needed
+	err := put.putOne(sourcePath, "/zone/home/user")
+	assert.NoError(t, err)
+}
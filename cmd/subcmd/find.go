@@ -0,0 +1,377 @@
+package subcmd
+
+import (
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_irodsfs "github.com/cyverse/go-irodsclient/irods/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var findCmd = &cobra.Command{
+	Use:     "find [collection1] [collection2] ...",
+	Aliases: []string{"ifind", "search"},
+	Short:   "Find data objects and collections",
+	Long:    `This finds data objects and collections under iRODS collections by name, size, modification time and metadata. Conditions are evaluated in iCAT queries where possible.`,
+	RunE:    processFindCommand,
+	Args:    cobra.ArbitraryArgs,
+}
+
+func AddFindCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(findCmd, true)
+
+	flag.SetFindFlags(findCmd)
+
+	findCmd.MarkFlagsMutuallyExclusive("exec", "print0", "output")
+
+	rootCmd.AddCommand(findCmd)
+}
+
+func processFindCommand(command *cobra.Command, args []string) error {
+	find, err := NewFindCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return find.Process()
+}
+
+type FindCommand struct {
+	command *cobra.Command
+
+	findFlagValues   *flag.FindFlagValues
+	outputFlagValues *flag.OutputFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	sourcePaths []string
+
+	condition   *commons.FindCondition
+	execCommand []string
+
+	collections []*irodsclient_types.IRODSCollection
+	dataObjects []*irodsclient_types.IRODSDataObject
+}
+
+func NewFindCommand(command *cobra.Command, args []string) (*FindCommand, error) {
+	find := &FindCommand{
+		command: command,
+
+		findFlagValues:   flag.GetFindFlagValues(),
+		outputFlagValues: flag.GetOutputFlagValues(),
+
+		collections: []*irodsclient_types.IRODSCollection{},
+		dataObjects: []*irodsclient_types.IRODSDataObject{},
+	}
+
+	// path
+	find.sourcePaths = args[:]
+
+	if len(args) == 0 {
+		find.sourcePaths = []string{"."}
+	}
+
+	// condition
+	err := find.makeCondition()
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
+	if len(find.findFlagValues.Exec) > 0 {
+		find.execCommand = strings.Fields(find.findFlagValues.Exec)
+	}
+
+	return find, nil
+}
+
+func (find *FindCommand) makeCondition() error {
+	condition := commons.NewFindCondition()
+
+	condition.Name = find.findFlagValues.Name
+	condition.IgnoreCase = find.findFlagValues.IgnoreCase
+
+	switch find.findFlagValues.Type {
+	case "", "f", "d":
+	default:
+		return xerrors.Errorf("unknown type %q, must be f or d", find.findFlagValues.Type)
+	}
+
+	minSize, maxSize, err := commons.ParseFindSize(find.findFlagValues.Size)
+	if err != nil {
+		return err
+	}
+	condition.MinSize = minSize
+	condition.MaxSize = maxSize
+
+	modifiedAfter, modifiedBefore, err := commons.ParseFindModifyTime(find.findFlagValues.ModifyTime, time.Now())
+	if err != nil {
+		return err
+	}
+	condition.ModifiedAfter = modifiedAfter
+	condition.ModifiedBefore = modifiedBefore
+
+	for _, meta := range find.findFlagValues.Metas {
+		metaCondition, err := commons.ParseFindMetaCondition(meta)
+		if err != nil {
+			return err
+		}
+
+		condition.Metas = append(condition.Metas, metaCondition)
+	}
+
+	find.condition = condition
+	return nil
+}
+
+func (find *FindCommand) Process() error {
+	cont, err := flag.ProcessCommonFlags(find.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	find.account = commons.GetAccount()
+	find.filesystem, err = commons.GetIRODSFSClient(find.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer find.filesystem.Release()
+
+	// run
+	for _, sourcePath := range find.sourcePaths {
+		err = find.findOne(sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to find in %q: %w", sourcePath, err)
+		}
+	}
+
+	return find.printResults()
+}
+
+func (find *FindCommand) findOne(sourcePath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "FindCommand",
+		"function": "findOne",
+	})
+
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	sourcePath = commons.MakeIRODSPath(cwd, home, zone, sourcePath)
+
+	sourceEntry, err := find.filesystem.Stat(sourcePath)
+	if err != nil {
+		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
+	}
+
+	connection, err := find.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer find.filesystem.ReturnMetadataConnection(connection)
+
+	if !sourceEntry.IsDir() {
+		// data object
+		if find.findFlagValues.Type == "d" {
+			return nil
+		}
+
+		entry, err := irodsclient_irodsfs.GetDataObjectWithoutCollection(connection, sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to get data-object %q: %w", sourcePath, err)
+		}
+
+		if !find.condition.MatchDataObject(entry) {
+			return nil
+		}
+
+		if len(find.condition.Metas) > 0 {
+			matched, err := find.matchMetas(sourcePath)
+			if err != nil {
+				return err
+			}
+
+			if !matched {
+				return nil
+			}
+		}
+
+		find.dataObjects = append(find.dataObjects, entry)
+		return nil
+	}
+
+	logger.Debugf("finding entries in %q", sourcePath)
+
+	if find.findFlagValues.Type != "d" {
+		dataObjects, err := commons.FindDataObjects(connection, sourcePath, find.condition)
+		if err != nil {
+			return xerrors.Errorf("failed to find data-objects: %w", err)
+		}
+
+		for _, dataObject := range dataObjects {
+			if find.condition.RequireMetaCheck() {
+				matched, err := find.matchMetas(dataObject.Path)
+				if err != nil {
+					return err
+				}
+
+				if !matched {
+					continue
+				}
+			}
+
+			find.dataObjects = append(find.dataObjects, dataObject)
+		}
+	}
+
+	if find.findFlagValues.Type != "f" {
+		collections, err := commons.FindCollections(connection, sourcePath, find.condition)
+		if err != nil {
+			return xerrors.Errorf("failed to find collections: %w", err)
+		}
+
+		for _, collection := range collections {
+			if find.condition.RequireMetaCheck() {
+				matched, err := find.matchMetas(collection.Path)
+				if err != nil {
+					return err
+				}
+
+				if !matched {
+					continue
+				}
+			}
+
+			find.collections = append(find.collections, collection)
+		}
+	}
+
+	return nil
+}
+
+func (find *FindCommand) matchMetas(targetPath string) (bool, error) {
+	metas, err := find.filesystem.ListMetadata(targetPath)
+	if err != nil {
+		return false, xerrors.Errorf("failed to list meta for path %q: %w", targetPath, err)
+	}
+
+	return find.condition.MatchMetas(metas), nil
+}
+
+func (find *FindCommand) printResults() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "FindCommand",
+		"function": "printResults",
+	})
+
+	sort.SliceStable(find.collections, func(i int, j int) bool {
+		return find.collections[i].Path < find.collections[j].Path
+	})
+
+	sort.SliceStable(find.dataObjects, func(i int, j int) bool {
+		return find.dataObjects[i].Path < find.dataObjects[j].Path
+	})
+
+	if find.outputFlagValues.Format.IsStructured() {
+		records := []commons.EntryRecord{}
+		for _, collection := range find.collections {
+			records = append(records, commons.NewCollectionEntryRecord(collection))
+		}
+
+		for _, dataObject := range find.dataObjects {
+			records = append(records, commons.NewDataObjectEntryRecord(dataObject, true))
+		}
+
+		return commons.PrintRecords(find.outputFlagValues.Format, records)
+	}
+
+	paths := []string{}
+	for _, collection := range find.collections {
+		paths = append(paths, collection.Path)
+	}
+
+	for _, dataObject := range find.dataObjects {
+		paths = append(paths, dataObject.Path)
+	}
+
+	if len(find.execCommand) == 0 {
+		separator := "\n"
+		if find.findFlagValues.Print0 {
+			separator = "\x00"
+		}
+
+		for _, p := range paths {
+			commons.Printf("%s%s", p, separator)
+		}
+
+		return nil
+	}
+
+	failed := 0
+	for _, p := range paths {
+		err := find.runExec(p)
+		if err != nil {
+			logger.WithError(err).Errorf("failed to run command for %q", p)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return xerrors.Errorf("failed to run command for %d entries", failed)
+	}
+
+	return nil
+}
+
+func (find *FindCommand) runExec(targetPath string) error {
+	// {} is replaced with the path, the path is appended if it is not given
+	args := []string{}
+	replaced := false
+	for _, arg := range find.execCommand {
+		if strings.Contains(arg, "{}") {
+			arg = strings.ReplaceAll(arg, "{}", targetPath)
+			replaced = true
+		}
+
+		args = append(args, arg)
+	}
+
+	if !replaced {
+		args = append(args, targetPath)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = commons.GetTerminalWriter()
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return xerrors.Errorf("failed to run %q: %w", args[0], err)
+	}
+
+	return nil
+}
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
@@ -25,9 +28,9 @@ var getCmd = &cobra.Command{
 	Use:     "get [data-object1] [data-object2] [collection1] ... [local dir]",
 	Aliases: []string{"iget", "download"},
 	Short:   "Download iRODS data-objects or collections",
-	Long:    `This downloads iRODS data-objects or collections to the given local path.`,
+	Long:    `This downloads iRODS data-objects or collections to the given local path. Use '-' as a target to write a data-object to stdout. Use --from_report to download data-objects recorded in a transfer report again. Use --files_from to read source paths from a file, e.g., the output of qmeta or find. Use --meta_sidecar to write metadata to sidecar files next to downloaded files, that 'put --meta_sidecar' reads, or --xattrs to write metadata to extended attributes of downloaded files.`,
 	RunE:    processGetCommand,
-	Args:    cobra.MinimumNArgs(1),
+	Args:    cobra.ArbitraryArgs,
 }
 
 func AddGetCommand(rootCmd *cobra.Command) {
@@ -40,6 +43,7 @@ func AddGetCommand(rootCmd *cobra.Command) {
 	flag.SetParallelTransferFlags(getCmd, false)
 	flag.SetProgressFlags(getCmd)
 	flag.SetRetryFlags(getCmd)
+	flag.SetContinueOnErrorFlags(getCmd)
 	flag.SetDifferentialTransferFlags(getCmd, true)
 	flag.SetChecksumFlags(getCmd, false)
 	flag.SetTransferReportFlags(getCmd)
@@ -47,7 +51,16 @@ func AddGetCommand(rootCmd *cobra.Command) {
 	flag.SetSyncFlags(getCmd, false)
 	flag.SetDecryptionFlags(getCmd)
 	flag.SetHiddenFileFlags(getCmd)
+	flag.SetFilterFlags(getCmd)
+	flag.SetDryRunFlags(getCmd)
+	flag.SetPreserveFlags(getCmd)
+	flag.SetStreamFlags(getCmd)
 	flag.SetPostTransferFlagValues(getCmd)
+	flag.SetFromReportFlags(getCmd)
+	flag.SetFilesFromFlags(getCmd)
+	flag.SetDownloadMetaFlags(getCmd)
+
+	getCmd.MarkFlagsMutuallyExclusive("from_report", "files_from")
 
 	rootCmd.AddCommand(getCmd)
 }
@@ -69,6 +82,7 @@ type GetCommand struct {
 	parallelTransferFlagValues     *flag.ParallelTransferFlagValues
 	progressFlagValues             *flag.ProgressFlagValues
 	retryFlagValues                *flag.RetryFlagValues
+	continueOnErrorFlagValues      *flag.ContinueOnErrorFlagValues
 	differentialTransferFlagValues *flag.DifferentialTransferFlagValues
 	checksumFlagValues             *flag.ChecksumFlagValues
 	noRootFlagValues               *flag.NoRootFlagValues
@@ -76,7 +90,14 @@ type GetCommand struct {
 	decryptionFlagValues           *flag.DecryptionFlagValues
 	postTransferFlagValues         *flag.PostTransferFlagValues
 	hiddenFileFlagValues           *flag.HiddenFileFlagValues
+	filterFlagValues               *flag.FilterFlagValues
+	dryRunFlagValues               *flag.DryRunFlagValues
+	preserveFlagValues             *flag.PreserveFlagValues
+	streamFlagValues               *flag.StreamFlagValues
 	transferReportFlagValues       *flag.TransferReportFlagValues
+	fromReportFlagValues           *flag.FromReportFlagValues
+	filesFromFlagValues            *flag.FilesFromFlagValues
+	downloadMetaFlagValues         *flag.DownloadMetaFlagValues
 
 	maxConnectionNum int
 
@@ -86,9 +107,10 @@ type GetCommand struct {
 	sourcePaths []string
 	targetPath  string
 
-	parallelJobManager    *commons.ParallelJobManager
-	transferReportManager *commons.TransferReportManager
-	updatedPathMap        map[string]bool
+	parallelJobManager     *commons.ParallelJobManager
+	transferReportManager  *commons.TransferReportManager
+	updatedPathMap         map[string]bool
+	xattrsNotSupportedOnce sync.Once
 }
 
 func NewGetCommand(command *cobra.Command, args []string) (*GetCommand, error) {
@@ -100,6 +122,7 @@ func NewGetCommand(command *cobra.Command, args []string) (*GetCommand, error) {
 		parallelTransferFlagValues:     flag.GetParallelTransferFlagValues(),
 		progressFlagValues:             flag.GetProgressFlagValues(),
 		retryFlagValues:                flag.GetRetryFlagValues(),
+		continueOnErrorFlagValues:      flag.GetContinueOnErrorFlagValues(),
 		differentialTransferFlagValues: flag.GetDifferentialTransferFlagValues(),
 		checksumFlagValues:             flag.GetChecksumFlagValues(),
 		noRootFlagValues:               flag.GetNoRootFlagValues(),
@@ -107,7 +130,14 @@ func NewGetCommand(command *cobra.Command, args []string) (*GetCommand, error) {
 		decryptionFlagValues:           flag.GetDecryptionFlagValues(command),
 		postTransferFlagValues:         flag.GetPostTransferFlagValues(),
 		hiddenFileFlagValues:           flag.GetHiddenFileFlagValues(),
+		filterFlagValues:               flag.GetFilterFlagValues(),
+		dryRunFlagValues:               flag.GetDryRunFlagValues(),
+		preserveFlagValues:             flag.GetPreserveFlagValues(),
+		streamFlagValues:               flag.GetStreamFlagValues(),
 		transferReportFlagValues:       flag.GetTransferReportFlagValues(command),
+		fromReportFlagValues:           flag.GetFromReportFlagValues(),
+		filesFromFlagValues:            flag.GetFilesFromFlagValues(),
+		downloadMetaFlagValues:         flag.GetDownloadMetaFlagValues(),
 
 		updatedPathMap: map[string]bool{},
 	}
@@ -118,15 +148,80 @@ func NewGetCommand(command *cobra.Command, args []string) (*GetCommand, error) {
 	get.targetPath = "./"
 	get.sourcePaths = args
 
-	if len(args) >= 2 {
+	if len(get.filesFromFlagValues.Path) > 0 {
+		// only the target is given as an arg, source paths are read from the file
+		get.sourcePaths = []string{}
+		if len(args) >= 1 {
+			get.targetPath = args[len(args)-1]
+			get.sourcePaths = append(get.sourcePaths, args[:len(args)-1]...)
+		}
+
+		paths, err := commons.ReadPathList(get.filesFromFlagValues.Path)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to read source paths: %w", err)
+		}
+
+		get.sourcePaths = append(get.sourcePaths, paths...)
+
+		if len(get.sourcePaths) == 0 {
+			return nil, xerrors.Errorf("failed to get, no source paths are given in %q", get.filesFromFlagValues.Path)
+		}
+	} else if len(args) >= 2 {
 		get.targetPath = args[len(args)-1]
 		get.sourcePaths = args[:len(args)-1]
 	}
 
+	if len(args) == 0 && len(get.fromReportFlagValues.ReportPath) == 0 && len(get.filesFromFlagValues.Path) == 0 {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("requires at least 1 arg(s), only received 0"))
+	}
+
+	if len(get.fromReportFlagValues.ReportPath) > 0 {
+		if len(args) > 0 {
+			return nil, xerrors.Errorf("failed to get from report, source and target paths must not be given")
+		}
+
+		if get.syncFlagValues.Delete {
+			return nil, xerrors.Errorf("failed to get from report, deleting extra files is not supported")
+		}
+
+		if get.transferReportFlagValues.Report && !get.transferReportFlagValues.ReportToStdout && filepath.Clean(get.transferReportFlagValues.ReportPath) == filepath.Clean(get.fromReportFlagValues.ReportPath) {
+			return nil, xerrors.Errorf("failed to get from report, new transfer report must be written to a different file")
+		}
+	} else if get.fromReportFlagValues.OnlyFailed {
+		return nil, xerrors.Errorf("failed to get only failed files, transfer report must be given with --from_report")
+	}
+
 	if get.noRootFlagValues.NoRoot && len(get.sourcePaths) > 1 {
 		return nil, xerrors.Errorf("failed to get multiple source collections without creating root directory")
 	}
 
+	err := get.differentialTransferFlagValues.Validate()
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
+	if len(get.downloadMetaFlagValues.SidecarFormat) > 0 && !get.downloadMetaFlagValues.SidecarFormat.IsStructured() {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("invalid metadata sidecar format %q, must be one of json, csv or yaml", get.downloadMetaFlagValues.SidecarFormat))
+	}
+
+	if commons.IsStreamPath(get.targetPath) {
+		if len(get.sourcePaths) > 1 {
+			return nil, xerrors.Errorf("failed to get multiple sources to stdout")
+		}
+
+		if get.retryFlagValues.RetryNumber > 0 {
+			return nil, xerrors.Errorf("failed to get to stdout, retry is not supported")
+		}
+
+		if get.syncFlagValues.Delete {
+			return nil, xerrors.Errorf("failed to get to stdout, deleting extra files is not supported")
+		}
+
+		if get.transferReportFlagValues.Report && get.transferReportFlagValues.ReportToStdout {
+			return nil, xerrors.Errorf("failed to get to stdout, transfer report must be written to a file")
+		}
+	}
+
 	return get, nil
 }
 
@@ -146,6 +241,11 @@ func (get *GetCommand) Process() error {
 		return nil
 	}
 
+	if commons.IsStreamPath(get.targetPath) {
+		// keep stdout only for data
+		commons.SetTerminalOutput(os.Stderr)
+	}
+
 	// handle local flags
 	_, err = commons.InputMissingFields()
 	if err != nil {
@@ -168,15 +268,6 @@ func (get *GetCommand) Process() error {
 		}
 	}
 
-	// handle retry
-	if get.retryFlagValues.RetryNumber > 0 && !get.retryFlagValues.RetryChild {
-		err := commons.RunWithRetry(get.retryFlagValues.RetryNumber, get.retryFlagValues.RetryIntervalSeconds)
-		if err != nil {
-			return xerrors.Errorf("failed to run with retry %d: %w", get.retryFlagValues.RetryNumber, err)
-		}
-		return nil
-	}
-
 	// Create a file system
 	get.account = commons.GetAccount()
 	get.filesystem, err = commons.GetIRODSFSClientAdvanced(get.account, get.maxConnectionNum, get.parallelTransferFlagValues.TCPBufferSize)
@@ -186,7 +277,7 @@ func (get *GetCommand) Process() error {
 	defer get.filesystem.Release()
 
 	// transfer report
-	get.transferReportManager, err = commons.NewTransferReportManager(get.transferReportFlagValues.Report, get.transferReportFlagValues.ReportPath, get.transferReportFlagValues.ReportToStdout)
+	get.transferReportManager, err = commons.NewTransferReportManager(get.transferReportFlagValues.Report, get.transferReportFlagValues.ReportPath, get.transferReportFlagValues.ReportToStdout, get.dryRunFlagValues.DryRun)
 	if err != nil {
 		return xerrors.Errorf("failed to create transfer report manager: %w", err)
 	}
@@ -199,21 +290,31 @@ func (get *GetCommand) Process() error {
 
 	// parallel job manager
 	get.parallelJobManager = commons.NewParallelJobManager(get.filesystem, get.parallelTransferFlagValues.ThreadNumber, get.progressFlagValues.ShowProgress, get.progressFlagValues.ShowFullPath)
+	get.parallelJobManager.SetRetryPolicy(get.retryFlagValues.RetryPolicy)
+	get.parallelJobManager.SetRetryCallback(get.transferReportManager.GetRetryCallback(commons.TransferMethodGet))
+	get.parallelJobManager.SetContinueOnError(get.continueOnErrorFlagValues.ContinueOnError)
 	get.parallelJobManager.Start()
 
 	// run
-	if len(get.sourcePaths) >= 2 {
-		// multi-source, target must be a dir
-		err = get.ensureTargetIsDir(get.targetPath)
+	if len(get.fromReportFlagValues.ReportPath) > 0 {
+		err = get.getFromReport(get.fromReportFlagValues.ReportPath)
 		if err != nil {
-			return err
+			return xerrors.Errorf("failed to get from report %q: %w", get.fromReportFlagValues.ReportPath, err)
+		}
+	} else {
+		if len(get.sourcePaths) >= 2 {
+			// multi-source, target must be a dir
+			err = get.ensureTargetIsDir(get.targetPath)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	for _, sourcePath := range get.sourcePaths {
-		err = get.getOne(sourcePath, get.targetPath)
-		if err != nil {
-			return xerrors.Errorf("failed to get %q to %q: %w", sourcePath, get.targetPath, err)
+		for _, sourcePath := range get.sourcePaths {
+			err = get.getOne(sourcePath, get.targetPath)
+			if err != nil {
+				return xerrors.Errorf("failed to get %q to %q: %w", sourcePath, get.targetPath, err)
+			}
 		}
 	}
 
@@ -268,6 +369,47 @@ func (get *GetCommand) ensureTargetIsDir(targetPath string) error {
 	return nil
 }
 
+// getFromReport gets files recorded in the transfer report again
+func (get *GetCommand) getFromReport(reportPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "GetCommand",
+		"function": "getFromReport",
+	})
+
+	reportFiles, err := commons.ReadTransferReportFiles(reportPath)
+	if err != nil {
+		return xerrors.Errorf("failed to read report file %q: %w", reportPath, err)
+	}
+
+	reportFilter := &commons.TransferReportFilter{
+		Method:       commons.TransferMethodGet,
+		OnlyFailed:   get.fromReportFlagValues.OnlyFailed,
+		IncludeNotes: get.fromReportFlagValues.IncludeNotes,
+		ExcludeNotes: get.fromReportFlagValues.ExcludeNotes,
+	}
+
+	reportFiles = reportFilter.Filter(reportFiles)
+	logger.Debugf("get %d files in the report %q", len(reportFiles), reportPath)
+
+	for _, reportFile := range reportFiles {
+		if reportFile.HasNote("directory", "stdout") || len(reportFile.DestPath) == 0 {
+			// directories are created with files in them, streams cannot be read again
+			logger.Debugf("skip %q in the report", reportFile.SourcePath)
+			continue
+		}
+
+		get.sourcePaths = append(get.sourcePaths, reportFile.SourcePath)
+
+		err = get.getOne(reportFile.SourcePath, reportFile.DestPath)
+		if err != nil {
+			return xerrors.Errorf("failed to get %q to %q: %w", reportFile.SourcePath, reportFile.DestPath, err)
+		}
+	}
+
+	return nil
+}
+
 func (get *GetCommand) requireDecryption(sourcePath string) bool {
 	if get.decryptionFlagValues.NoDecryption {
 		return false
@@ -294,24 +436,42 @@ func (get *GetCommand) deleteTransferStatusFile(targetPath string) {
 }
 
 func (get *GetCommand) getOne(sourcePath string, targetPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "GetCommand",
+		"function": "getOne",
+	})
+
 	cwd := commons.GetCWD()
 	home := commons.GetHomeDir()
 	zone := commons.GetZone()
 	sourcePath = commons.MakeIRODSPath(cwd, home, zone, sourcePath)
-	targetPath = commons.MakeLocalPath(targetPath)
 
 	sourceEntry, err := get.filesystem.Stat(sourcePath)
 	if err != nil {
 		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
 	}
 
+	if commons.IsStreamPath(targetPath) {
+		// stdout
+		return get.getStream(sourceEntry)
+	}
+
+	targetPath = commons.MakeLocalPath(targetPath)
+
+	filterRootPath := commons.GetFilterRootPath(sourceEntry.Path, sourceEntry.IsDir() && get.noRootFlagValues.NoRoot)
+	if get.filterFlagValues.Filter.IsExcluded(filterRootPath, sourceEntry.Path, sourceEntry.IsDir()) {
+		logger.Debugf("skip downloading %q, excluded by filters", sourceEntry.Path)
+		return nil
+	}
+
 	if sourceEntry.IsDir() {
 		// dir
 		if !get.noRootFlagValues.NoRoot {
 			targetPath = commons.MakeTargetLocalFilePath(sourcePath, targetPath)
 		}
 
-		return get.getDir(sourceEntry, targetPath)
+		return get.getDir(sourceEntry, targetPath, filterRootPath)
 	}
 
 	// file
@@ -322,11 +482,96 @@ func (get *GetCommand) getOne(sourcePath string, targetPath string) error {
 			return xerrors.Errorf("failed to get decryption path for %q: %w", sourceEntry.Path, err)
 		}
 
-		return get.getFile(sourceEntry, tempPath, newTargetPath)
+		err = get.getFile(sourceEntry, tempPath, newTargetPath)
+		if err != nil {
+			return get.handleScanFailure(sourceEntry, newTargetPath, err)
+		}
+
+		return nil
 	}
 
 	targetPath = commons.MakeTargetLocalFilePath(sourcePath, targetPath)
-	return get.getFile(sourceEntry, "", targetPath)
+	err = get.getFile(sourceEntry, "", targetPath)
+	if err != nil {
+		return get.handleScanFailure(sourceEntry, targetPath, err)
+	}
+
+	return nil
+}
+
+func (get *GetCommand) getStream(sourceEntry *irodsclient_fs.Entry) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "GetCommand",
+		"function": "getStream",
+	})
+
+	if sourceEntry.IsDir() {
+		// source must be a file
+		return commons.NewNotFileError(sourceEntry.Path)
+	}
+
+	if get.requireDecryption(sourceEntry.Path) {
+		return xerrors.Errorf("failed to get %q to stdout, decryption is not supported", sourceEntry.Path)
+	}
+
+	if get.dryRunFlagValues.DryRun {
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodGet,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourceEntry.Path,
+			SourceSize: sourceEntry.Size,
+			DestPath:   commons.StreamPath,
+			Notes:      []string{"stdout"},
+		}
+
+		get.transferReportManager.AddFile(reportFile)
+
+		logger.Debugf("skip downloading a data object %q to stdout, dry run", sourceEntry.Path)
+		return nil
+	}
+
+	getTask := func(job *commons.ParallelJob) error {
+		manager := job.GetManager()
+		fs := manager.GetFilesystem()
+
+		callbackGet := func(processed int64, total int64) {
+			job.Progress(processed, total, false)
+		}
+
+		job.Progress(0, sourceEntry.Size, false)
+
+		logger.Debugf("downloading a data object %q to stdout", sourceEntry.Path)
+
+		downloadResult, downloadErr := commons.DownloadToStream(fs, sourceEntry.Path, "", os.Stdout, get.streamFlagValues.BufferSize, callbackGet)
+		if downloadErr != nil {
+			job.Progress(-1, sourceEntry.Size, true)
+			return xerrors.Errorf("failed to download %q to stdout: %w", sourceEntry.Path, downloadErr)
+		}
+
+		err := get.transferReportManager.AddTransfer(downloadResult, commons.TransferMethodGet, downloadErr, []string{"stdout"})
+		if err != nil {
+			job.Progress(-1, sourceEntry.Size, true)
+			return xerrors.Errorf("failed to add transfer report: %w", err)
+		}
+
+		logger.Debugf("downloaded a data object %q to stdout", sourceEntry.Path)
+		job.Progress(sourceEntry.Size, sourceEntry.Size, false)
+
+		job.Done()
+		return nil
+	}
+
+	err := get.parallelJobManager.Schedule(sourceEntry.Path, getTask, 1, progress.UnitsBytes)
+	if err != nil {
+		return xerrors.Errorf("failed to schedule download %q to stdout: %w", sourceEntry.Path, err)
+	}
+
+	logger.Debugf("scheduled a data object download %q to stdout", sourceEntry.Path)
+
+	return nil
 }
 
 func (get *GetCommand) scheduleGet(sourceEntry *irodsclient_fs.Entry, tempPath string, targetPath string, resume bool) error {
@@ -336,6 +581,37 @@ func (get *GetCommand) scheduleGet(sourceEntry *irodsclient_fs.Entry, tempPath s
 		"function": "scheduleGet",
 	})
 
+	if get.dryRunFlagValues.DryRun {
+		notes := []string{}
+		if resume {
+			notes = append(notes, "resume")
+		}
+
+		if get.requireMetaSidecar() {
+			notes = append(notes, "meta_sidecar")
+		}
+
+		if get.downloadMetaFlagValues.Xattrs {
+			notes = append(notes, "xattrs")
+		}
+
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodGet,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourceEntry.Path,
+			SourceSize: sourceEntry.Size,
+			DestPath:   targetPath,
+			Notes:      notes,
+		}
+
+		get.transferReportManager.AddFile(reportFile)
+
+		logger.Debugf("skip downloading a data object %q to %q, dry run", sourceEntry.Path, targetPath)
+		return nil
+	}
+
 	getTask := func(job *commons.ParallelJob) error {
 		manager := job.GetManager()
 		fs := manager.GetFilesystem()
@@ -419,6 +695,40 @@ func (get *GetCommand) scheduleGet(sourceEntry *irodsclient_fs.Entry, tempPath s
 			}
 		}
 
+		// preserve
+		if get.preserveFlagValues.Preserve {
+			preserveErr := commons.PreserveLocalFileAttributes(fs, sourceEntry, targetPath)
+			if preserveErr != nil {
+				job.Progress(-1, sourceEntry.Size, true)
+				return xerrors.Errorf("failed to preserve attributes of %q: %w", targetPath, preserveErr)
+			}
+
+			notes = append(notes, "preserved")
+		}
+
+		// metadata
+		if get.requireMetaSidecar() {
+			written, metaErr := get.writeMetaSidecar(fs, sourceEntry.Path, commons.GetMetaSidecarPath(targetPath, get.downloadMetaFlagValues.SidecarFormat))
+			if metaErr != nil {
+				job.Progress(-1, sourceEntry.Size, true)
+				return metaErr
+			}
+
+			if written {
+				notes = append(notes, "meta_sidecar")
+			}
+		}
+
+		if get.downloadMetaFlagValues.Xattrs {
+			xattrNotes, xattrErr := get.writeXattrs(fs, sourceEntry.Path, targetPath)
+			if xattrErr != nil {
+				job.Progress(-1, sourceEntry.Size, true)
+				return xattrErr
+			}
+
+			notes = append(notes, xattrNotes...)
+		}
+
 		err := get.transferReportManager.AddTransfer(downloadResult, commons.TransferMethodGet, downloadErr, notes)
 		if err != nil {
 			job.Progress(-1, sourceEntry.Size, true)
@@ -433,7 +743,8 @@ func (get *GetCommand) scheduleGet(sourceEntry *irodsclient_fs.Entry, tempPath s
 	}
 
 	threadsRequired := irodsclient_util.GetNumTasksForParallelTransfer(sourceEntry.Size)
-	err := get.parallelJobManager.Schedule(sourceEntry.Path, getTask, threadsRequired, progress.UnitsBytes)
+	reportedGetTask := get.transferReportManager.WrapTask(commons.TransferMethodGet, sourceEntry.Path, sourceEntry.Size, targetPath, getTask)
+	err := get.parallelJobManager.Schedule(sourceEntry.Path, reportedGetTask, threadsRequired, progress.UnitsBytes)
 	if err != nil {
 		return xerrors.Errorf("failed to schedule download %q to %q: %w", sourceEntry.Path, targetPath, err)
 	}
@@ -451,6 +762,9 @@ func (get *GetCommand) getFile(sourceEntry *irodsclient_fs.Entry, tempPath strin
 	})
 
 	commons.MarkPathMap(get.updatedPathMap, targetPath)
+	if get.requireMetaSidecar() {
+		commons.MarkPathMap(get.updatedPathMap, commons.GetMetaSidecarPath(targetPath, get.downloadMetaFlagValues.SidecarFormat))
+	}
 
 	targetStat, err := os.Stat(targetPath)
 	if err != nil {
@@ -469,7 +783,10 @@ func (get *GetCommand) getFile(sourceEntry *irodsclient_fs.Entry, tempPath strin
 		if get.syncFlagValues.Sync {
 			// if it is sync, remove
 			if get.forceFlagValues.Force {
-				removeErr := os.RemoveAll(targetPath)
+				var removeErr error
+				if !get.dryRunFlagValues.DryRun {
+					removeErr = os.RemoveAll(targetPath)
+				}
 
 				now := time.Now()
 				reportFile := &commons.TransferReportFile{
@@ -490,7 +807,10 @@ func (get *GetCommand) getFile(sourceEntry *irodsclient_fs.Entry, tempPath strin
 				// ask
 				overwrite := commons.InputYN(fmt.Sprintf("overwriting a file %q, but directory exists. Overwrite?", targetPath))
 				if overwrite {
-					removeErr := os.RemoveAll(targetPath)
+					var removeErr error
+					if !get.dryRunFlagValues.DryRun {
+						removeErr = os.RemoveAll(targetPath)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -526,7 +846,8 @@ func (get *GetCommand) getFile(sourceEntry *irodsclient_fs.Entry, tempPath strin
 	}
 
 	if get.differentialTransferFlagValues.DifferentialTransfer {
-		if get.differentialTransferFlagValues.NoHash {
+		switch get.differentialTransferFlagValues.Mode {
+		case commons.DiffModeSize:
 			if targetStat.Size() == sourceEntry.Size {
 				// skip
 				now := time.Now()
@@ -550,7 +871,34 @@ func (get *GetCommand) getFile(sourceEntry *irodsclient_fs.Entry, tempPath strin
 				logger.Debugf("skip downloading a data object %q to %q. The file already exists!", sourceEntry.Path, targetPath)
 				return nil
 			}
-		} else {
+		case commons.DiffModeMtime, commons.DiffModeSizeMtime:
+			if get.differentialTransferFlagValues.Mode == commons.DiffModeSizeMtime && targetStat.Size() != sourceEntry.Size {
+				break
+			}
+
+			if commons.IsModTimeUpToDate(sourceEntry.ModifyTime, targetStat.ModTime(), get.differentialTransferFlagValues.MtimeTolerance) {
+				// skip
+				now := time.Now()
+				reportFile := &commons.TransferReportFile{
+					Method:            commons.TransferMethodGet,
+					StartAt:           now,
+					EndAt:             now,
+					SourcePath:        sourceEntry.Path,
+					SourceSize:        sourceEntry.Size,
+					SourceChecksum:    hex.EncodeToString(sourceEntry.CheckSum),
+					DestPath:          targetPath,
+					DestSize:          targetStat.Size(),
+					ChecksumAlgorithm: string(sourceEntry.CheckSumAlgorithm),
+					Notes:             []string{"differential", string(get.differentialTransferFlagValues.Mode), "not older", "skip"},
+				}
+
+				get.transferReportManager.AddFile(reportFile)
+
+				commons.Printf("skip downloading a data object %q to %q. The file is up to date!\n", sourceEntry.Path, targetPath)
+				logger.Debugf("skip downloading a data object %q to %q. The file is up to date!", sourceEntry.Path, targetPath)
+				return nil
+			}
+		default:
 			if targetStat.Size() == sourceEntry.Size {
 				// compare hash
 				if len(sourceEntry.CheckSum) > 0 {
@@ -618,7 +966,96 @@ func (get *GetCommand) getFile(sourceEntry *irodsclient_fs.Entry, tempPath strin
 	return get.scheduleGet(sourceEntry, tempPath, targetPath, false)
 }
 
-func (get *GetCommand) getDir(sourceEntry *irodsclient_fs.Entry, targetPath string) error {
+// handleScanFailure adds a data object or a collection failed before it is scheduled to the report and failures of jobs
+// it returns nil to continue with other data objects if continue_on_error is set, or the error otherwise
+func (get *GetCommand) handleScanFailure(sourceEntry *irodsclient_fs.Entry, targetPath string, err error) error {
+	notes := []string{"failed", "scan"}
+	if sourceEntry.IsDir() {
+		notes = append(notes, "directory")
+	}
+
+	now := time.Now()
+	reportFile := &commons.TransferReportFile{
+		Method:     commons.TransferMethodGet,
+		StartAt:    now,
+		EndAt:      now,
+		SourcePath: sourceEntry.Path,
+		SourceSize: sourceEntry.Size,
+		DestPath:   targetPath,
+		Error:      err,
+		Notes:      notes,
+	}
+
+	get.transferReportManager.AddFile(reportFile)
+
+	return get.parallelJobManager.AddFailure(sourceEntry.Path, err)
+}
+
+func (get *GetCommand) getDir(sourceEntry *irodsclient_fs.Entry, targetPath string, filterRootPath string) error {
+	err := get.prepareTargetDir(sourceEntry, targetPath)
+	if err != nil {
+		return get.handleScanFailure(sourceEntry, targetPath, err)
+	}
+
+	// load encryption config
+	requireDecryption := get.requireDecryption(sourceEntry.Path)
+
+	// get entries
+	entries, err := get.filesystem.List(sourceEntry.Path)
+	if err != nil {
+		return get.handleScanFailure(sourceEntry, targetPath, xerrors.Errorf("failed to list a directory %q: %w", sourceEntry.Path, err))
+	}
+
+	for _, entry := range entries {
+		if get.hiddenFileFlagValues.Exclude {
+			if strings.HasPrefix(entry.Name, ".") {
+				continue
+			}
+		}
+
+		if get.filterFlagValues.Filter.IsExcluded(filterRootPath, entry.Path, entry.IsDir()) {
+			continue
+		}
+
+		newEntryPath := commons.MakeTargetLocalFilePath(entry.Path, targetPath)
+
+		if entry.IsDir() {
+			// dir, failures under it are handled in it
+			err = get.getDir(entry, newEntryPath, filterRootPath)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// file
+		if requireDecryption {
+			// decrypt filename
+			tempPath, newTargetPath, pathErr := get.getPathsForDecryption(entry.Path, targetPath)
+			if pathErr != nil {
+				err = xerrors.Errorf("failed to get decryption path for %q: %w", entry.Path, pathErr)
+			} else {
+				newEntryPath = newTargetPath
+				err = get.getFile(entry, tempPath, newTargetPath)
+			}
+		} else {
+			err = get.getFile(entry, "", newEntryPath)
+		}
+
+		if err != nil {
+			err = get.handleScanFailure(entry, newEntryPath, err)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// prepareTargetDir makes the target directory of the collection, or replaces a file in the way on sync
+func (get *GetCommand) prepareTargetDir(sourceEntry *irodsclient_fs.Entry, targetPath string) error {
 	commons.MarkPathMap(get.updatedPathMap, targetPath)
 
 	targetStat, err := os.Stat(targetPath)
@@ -626,9 +1063,11 @@ func (get *GetCommand) getDir(sourceEntry *irodsclient_fs.Entry, targetPath stri
 		if os.IsNotExist(err) {
 			// target does not exist
 			// target must be a directorywith new name
-			err = os.MkdirAll(targetPath, 0766)
-			if err != nil {
-				return xerrors.Errorf("failed to make a directory %q: %w", targetPath, err)
+			if !get.dryRunFlagValues.DryRun {
+				err = os.MkdirAll(targetPath, 0766)
+				if err != nil {
+					return xerrors.Errorf("failed to make a directory %q: %w", targetPath, err)
+				}
 			}
 
 			now := time.Now()
@@ -651,7 +1090,10 @@ func (get *GetCommand) getDir(sourceEntry *irodsclient_fs.Entry, targetPath stri
 			if get.syncFlagValues.Sync {
 				// if it is sync, remove
 				if get.forceFlagValues.Force {
-					removeErr := os.Remove(targetPath)
+					var removeErr error
+					if !get.dryRunFlagValues.DryRun {
+						removeErr = os.Remove(targetPath)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -672,7 +1114,10 @@ func (get *GetCommand) getDir(sourceEntry *irodsclient_fs.Entry, targetPath stri
 					// ask
 					overwrite := commons.InputYN(fmt.Sprintf("overwriting a directory %q, but file exists. Overwrite?", targetPath))
 					if overwrite {
-						removeErr := os.Remove(targetPath)
+						var removeErr error
+						if !get.dryRunFlagValues.DryRun {
+							removeErr = os.Remove(targetPath)
+						}
 
 						now := time.Now()
 						reportFile := &commons.TransferReportFile{
@@ -699,53 +1144,108 @@ func (get *GetCommand) getDir(sourceEntry *irodsclient_fs.Entry, targetPath stri
 		}
 	}
 
-	// load encryption config
-	requireDecryption := get.requireDecryption(sourceEntry.Path)
+	// metadata
+	if get.requireMetaSidecar() {
+		sidecarPath := commons.GetCollectionMetaSidecarPath(targetPath, get.downloadMetaFlagValues.SidecarFormat)
+		commons.MarkPathMap(get.updatedPathMap, sidecarPath)
 
-	// get entries
-	entries, err := get.filesystem.List(sourceEntry.Path)
+		if !get.dryRunFlagValues.DryRun {
+			_, err = get.writeMetaSidecar(get.filesystem, sourceEntry.Path, sidecarPath)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (get *GetCommand) requireMetaSidecar() bool {
+	return len(get.downloadMetaFlagValues.SidecarFormat) > 0
+}
+
+// writeMetaSidecar writes metadata of the data object or the collection to the sidecar file
+// the sidecar file is not written if there is no metadata
+func (get *GetCommand) writeMetaSidecar(fs *irodsclient_fs.FileSystem, sourcePath string, sidecarPath string) (bool, error) {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "GetCommand",
+		"function": "writeMetaSidecar",
+	})
+
+	metas, err := fs.ListMetadata(sourcePath)
 	if err != nil {
-		return xerrors.Errorf("failed to list a directory %q: %w", sourceEntry.Path, err)
+		return false, xerrors.Errorf("failed to list metadata of %q: %w", sourcePath, err)
 	}
 
-	for _, entry := range entries {
-		if get.hiddenFileFlagValues.Exclude {
-			if strings.HasPrefix(entry.Name, ".") {
-				continue
-			}
+	if len(metas) == 0 {
+		return false, nil
+	}
+
+	logger.Debugf("writing %d metadata of %q to %q", len(metas), sourcePath, sidecarPath)
+
+	err = commons.WriteMetaSidecar(sidecarPath, get.downloadMetaFlagValues.SidecarFormat, sourcePath, metas)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// writeXattrs writes metadata of the data object to extended attributes of the downloaded file, returns notes for transfer report
+// attributes having multiple values are skipped as an extended attribute has a single value
+func (get *GetCommand) writeXattrs(fs *irodsclient_fs.FileSystem, sourcePath string, targetPath string) ([]string, error) {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "GetCommand",
+		"function": "writeXattrs",
+	})
+
+	metas, err := fs.ListMetadata(sourcePath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list metadata of %q: %w", sourcePath, err)
+	}
+
+	values := map[string][]string{}
+	for _, meta := range metas {
+		if !strings.HasPrefix(meta.Name, get.downloadMetaFlagValues.XattrsPrefix) {
+			continue
 		}
 
-		newEntryPath := commons.MakeTargetLocalFilePath(entry.Path, targetPath)
+		values[meta.Name] = append(values[meta.Name], meta.Value)
+	}
 
-		if entry.IsDir() {
-			// dir
-			err = get.getDir(entry, newEntryPath)
-			if err != nil {
-				return err
-			}
-		} else {
-			// file
-			if requireDecryption {
-				// decrypt filename
-				tempPath, newTargetPath, err := get.getPathsForDecryption(entry.Path, targetPath)
-				if err != nil {
-					return xerrors.Errorf("failed to get decryption path for %q: %w", entry.Path, err)
-				}
+	attributes := []string{}
+	for attribute := range values {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
 
-				err = get.getFile(entry, tempPath, newTargetPath)
-				if err != nil {
-					return err
-				}
-			} else {
-				err = get.getFile(entry, "", newEntryPath)
-				if err != nil {
-					return err
-				}
+	notes := []string{}
+	for _, attribute := range attributes {
+		if len(values[attribute]) > 1 {
+			logger.Warnf("skip writing metadata %q of %q to extended attributes, it has %d values", attribute, sourcePath, len(values[attribute]))
+			continue
+		}
+
+		logger.Debugf("writing metadata %q of %q to extended attribute of %q", attribute, sourcePath, targetPath)
+
+		err = commons.WriteUserXattr(targetPath, attribute, values[attribute][0])
+		if err != nil {
+			if commons.IsXattrNotSupportedError(err) {
+				get.xattrsNotSupportedOnce.Do(func() {
+					logger.WithError(err).Warnf("failed to write extended attributes of %q, the local filesystem does not support them", targetPath)
+				})
+				return append(notes, "xattrs_not_supported"), nil
 			}
+
+			return nil, err
 		}
+
+		notes = append(notes, fmt.Sprintf("xattr:%s%s", commons.XattrUserPrefix, attribute))
 	}
 
-	return nil
+	return notes, nil
 }
 
 func (get *GetCommand) deleteOnSuccess(sourcePath string) error {
@@ -754,6 +1254,20 @@ func (get *GetCommand) deleteOnSuccess(sourcePath string) error {
 		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
 	}
 
+	if get.dryRunFlagValues.DryRun {
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodDelete,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourcePath,
+			Notes:      []string{"delete_on_success"},
+		}
+
+		get.transferReportManager.AddFile(reportFile)
+		return nil
+	}
+
 	if sourceEntry.IsDir() {
 		return get.filesystem.RemoveDir(sourcePath, true, true)
 	}
@@ -764,10 +1278,11 @@ func (get *GetCommand) deleteOnSuccess(sourcePath string) error {
 func (get *GetCommand) deleteExtra(targetPath string) error {
 	targetPath = commons.MakeLocalPath(targetPath)
 
-	return get.deleteExtraInternal(targetPath)
+	// paths under the target are compared with the source paths relative to the transfer root
+	return get.deleteExtraInternal(targetPath, targetPath)
 }
 
-func (get *GetCommand) deleteExtraInternal(targetPath string) error {
+func (get *GetCommand) deleteExtraInternal(targetPath string, filterRootPath string) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
 		"struct":   "GetCommand",
@@ -777,6 +1292,11 @@ func (get *GetCommand) deleteExtraInternal(targetPath string) error {
 	targetStat, err := os.Stat(targetPath)
 	if err != nil {
 		if os.IsNotExist(err) {
+			if get.dryRunFlagValues.DryRun {
+				// the target is not created in dry run, nothing to delete
+				return nil
+			}
+
 			return irodsclient_types.NewFileNotFoundError(targetPath)
 		}
 
@@ -789,7 +1309,10 @@ func (get *GetCommand) deleteExtraInternal(targetPath string) error {
 			// extra file
 			logger.Debugf("removing an extra file %q", targetPath)
 
-			removeErr := os.Remove(targetPath)
+			var removeErr error
+			if !get.dryRunFlagValues.DryRun {
+				removeErr = os.Remove(targetPath)
+			}
 
 			now := time.Now()
 			reportFile := &commons.TransferReportFile{
@@ -816,7 +1339,10 @@ func (get *GetCommand) deleteExtraInternal(targetPath string) error {
 		// extra dir
 		logger.Debugf("removing an extra directory %q", targetPath)
 
-		removeErr := os.RemoveAll(targetPath)
+		var removeErr error
+		if !get.dryRunFlagValues.DryRun {
+			removeErr = os.RemoveAll(targetPath)
+		}
 
 		now := time.Now()
 		reportFile := &commons.TransferReportFile{
@@ -843,7 +1369,12 @@ func (get *GetCommand) deleteExtraInternal(targetPath string) error {
 
 		for _, entry := range entries {
 			newTargetPath := path.Join(targetPath, entry.Name())
-			err = get.deleteExtraInternal(newTargetPath)
+			if get.filterFlagValues.Filter.IsExcluded(filterRootPath, newTargetPath, entry.IsDir()) {
+				// excluded paths are not transferred, keep them
+				continue
+			}
+
+			err = get.deleteExtraInternal(newTargetPath, filterRootPath)
 			if err != nil {
 				return err
 			}
@@ -0,0 +1,128 @@
+package subcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	t.Run("test DeleteExtraDryRun", testGetDeleteExtraDryRun)
+	t.Run("test ScanFailure", testGetScanFailure)
+}
+
+func testGetDeleteExtraDryRun(t *testing.T) {
+	commons.InitTerminalOutput()
+	defer commons.InitTerminalOutput()
+
+	buffer := &bytes.Buffer{}
+	commons.SetTerminalOutput(buffer)
+
+	targetPath := filepath.Join(t.TempDir(), "data")
+	makeFilterTestTree(t, targetPath, "kept.txt", "extra.txt", "sub/kept.txt", "extra_dir/a.txt")
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	reportManager, err := commons.NewTransferReportManager(true, reportPath, false, true)
+	assert.NoError(t, err)
+
+	get := &GetCommand{
+		dryRunFlagValues:      &flag.DryRunFlagValues{DryRun: true},
+		filterFlagValues:      &flag.FilterFlagValues{Filter: commons.NewPathFilter()},
+		transferReportManager: reportManager,
+		updatedPathMap:        map[string]bool{},
+	}
+
+	commons.MarkPathMap(get.updatedPathMap, filepath.Join(targetPath, "kept.txt"))
+	commons.MarkPathMap(get.updatedPathMap, filepath.Join(targetPath, "sub", "kept.txt"))
+
+	// the target is not created in dry run
+	err = get.deleteExtra(filepath.Join(t.TempDir(), "missing"))
+	assert.NoError(t, err)
+
+	err = get.deleteExtra(targetPath)
+	assert.NoError(t, err)
+	reportManager.Release()
+
+	files, err := commons.ReadTransferReportFiles(reportPath)
+	assert.NoError(t, err)
+
+	deleted := []string{}
+	for _, file := range files {
+		assert.Equal(t, commons.TransferMethodDelete, file.Method)
+		assert.True(t, file.HasNote("dry_run"))
+		deleted = append(deleted, file.SourcePath)
+	}
+
+	assert.ElementsMatch(t, []string{filepath.Join(targetPath, "extra.txt"), filepath.Join(targetPath, "extra_dir")}, deleted)
+
+	assert.Contains(t, buffer.String(), "extra.txt")
+
+	// nothing is removed in dry run
+	_, err = os.Stat(filepath.Join(targetPath, "extra_dir", "a.txt"))
+	assert.NoError(t, err)
+}
+
+func testGetScanFailure(t *testing.T) {
+	// the target of the data object is a directory, so it fails before it is scheduled
+	targetPath := filepath.Join(t.TempDir(), "a.txt")
+	assert.NoError(t, os.MkdirAll(targetPath, 0755))
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	reportManager, err := commons.NewTransferReportManager(true, reportPath, false, false)
+	assert.NoError(t, err)
+
+	jobManager := commons.NewParallelJobManager(nil, 1, false, false)
+	jobManager.SetContinueOnError(true)
+	jobManager.Start()
+
+	get := &GetCommand{
+		dryRunFlagValues:       &flag.DryRunFlagValues{},
+		syncFlagValues:         &flag.SyncFlagValues{},
+		downloadMetaFlagValues: &flag.DownloadMetaFlagValues{},
+		transferReportManager:  reportManager,
+		parallelJobManager:     jobManager,
+		updatedPathMap:         map[string]bool{},
+	}
+
+	sourceEntry := &irodsclient_fs.Entry{Path: "/zone/home/user/a.txt", Type: irodsclient_fs.FileEntry, Size: 4}
+
+	scanErr := get.getFile(sourceEntry, "", targetPath)
+	assert.True(t, commons.IsNotFileError(scanErr))
+
+	// continues with other data objects and reports the failure at the end
+	err = get.handleScanFailure(sourceEntry, targetPath, scanErr)
+	assert.NoError(t, err)
+
+	jobManager.DoneScheduling()
+	err = jobManager.Wait()
+	assert.True(t, commons.IsJobFailuresError(err))
+	reportManager.Release()
+
+	files, err := commons.ReadTransferReportFiles(reportPath)
+	assert.NoError(t, err)
+
+	// failed files can be got again from the report
+	filter := &commons.TransferReportFilter{
+		Method:     commons.TransferMethodGet,
+		OnlyFailed: true,
+	}
+	files = filter.Filter(files)
+	assert.Len(t, files, 1)
+	assert.Equal(t, sourceEntry.Path, files[0].SourcePath)
+	assert.Equal(t, targetPath, files[0].DestPath)
+	assert.True(t, files[0].HasNote("scan"))
+
+	// the error is given back without continue_on_error
+	get.parallelJobManager = commons.NewParallelJobManager(nil, 1, false, false)
+	get.transferReportManager, err = commons.NewTransferReportManager(false, "", false, false)
+	assert.NoError(t, err)
+
+	err = get.handleScanFailure(sourceEntry, targetPath, scanErr)
+	assert.True(t, commons.IsNotFileError(err))
+}
@@ -0,0 +1,134 @@
+package subcmd
+
+import (
+	"strings"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var inheritCmd = &cobra.Command{
+	Use:   "inherit [on|off] [collection1] [collection2] ...",
+	Short: "Turn on or off access inheritance of iRODS collections",
+	Long:  `This turns on or off access inheritance of iRODS collections. Data-objects and collections created in a collection with inheritance on get the same access as the collection.`,
+	RunE:  processInheritCommand,
+	Args:  cobra.MinimumNArgs(2),
+}
+
+func AddInheritCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(inheritCmd, false)
+
+	rootCmd.AddCommand(inheritCmd)
+}
+
+func processInheritCommand(command *cobra.Command, args []string) error {
+	inherit, err := NewInheritCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return inherit.Process()
+}
+
+type InheritCommand struct {
+	command *cobra.Command
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	inherit     bool
+	targetPaths []string
+}
+
+func NewInheritCommand(command *cobra.Command, args []string) (*InheritCommand, error) {
+	inherit := &InheritCommand{
+		command: command,
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on":
+		inherit.inherit = true
+	case "off":
+		inherit.inherit = false
+	default:
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("unknown inheritance %q, must be on or off", args[0]))
+	}
+
+	// path
+	inherit.targetPaths = args[1:]
+
+	return inherit, nil
+}
+
+func (inherit *InheritCommand) Process() error {
+	cont, err := flag.ProcessCommonFlags(inherit.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	inherit.account = commons.GetAccount()
+	inherit.filesystem, err = commons.GetIRODSFSClient(inherit.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer inherit.filesystem.Release()
+
+	// run
+	for _, targetPath := range inherit.targetPaths {
+		err = inherit.inheritOne(targetPath)
+		if err != nil {
+			return xerrors.Errorf("failed to set access inheritance of %q: %w", targetPath, err)
+		}
+	}
+
+	return nil
+}
+
+func (inherit *InheritCommand) inheritOne(targetPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "InheritCommand",
+		"function": "inheritOne",
+	})
+
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	targetPath = commons.MakeIRODSPath(cwd, home, zone, targetPath)
+
+	targetEntry, err := inherit.filesystem.Stat(targetPath)
+	if err != nil {
+		return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
+	}
+
+	if !targetEntry.IsDir() {
+		return commons.NewNotDirError(targetPath)
+	}
+
+	connection, err := inherit.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer inherit.filesystem.ReturnMetadataConnection(connection)
+
+	logger.Debugf("set access inheritance of %q to %t", targetPath, inherit.inherit)
+
+	return commons.SetAccessInheritance(connection, targetPath, inherit.inherit)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
 	irodsclient_irodsfs "github.com/cyverse/go-irodsclient/irods/fs"
 	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
 	"github.com/cyverse/gocommands/cmd/flag"
@@ -29,7 +30,7 @@ var lsCmd = &cobra.Command{
 	Use:     "ls [collection1] [collection2] ...",
 	Aliases: []string{"ils", "list"},
 	Short:   "List entries in iRODS collections",
-	Long:    `This lists data objects and collections in iRODS collections.`,
+	Long:    `This lists data objects and collections in iRODS collections. Use -A to display access control lists of entries and access inheritance of collections.`,
 	RunE:    processLsCommand,
 	Args:    cobra.ArbitraryArgs,
 }
@@ -39,6 +40,8 @@ func AddLsCommand(rootCmd *cobra.Command) {
 	flag.SetCommonFlags(lsCmd, false)
 
 	flag.SetListFlags(lsCmd)
+	flag.SetACLFlags(lsCmd)
+	flag.SetRecursiveFlags(lsCmd, false)
 	flag.SetTicketAccessFlags(lsCmd)
 	flag.SetDecryptionFlags(lsCmd)
 	flag.SetHiddenFileFlags(lsCmd)
@@ -60,13 +63,22 @@ type LsCommand struct {
 
 	ticketAccessFlagValues *flag.TicketAccessFlagValues
 	listFlagValues         *flag.ListFlagValues
+	aclFlagValues          *flag.ACLFlagValues
+	recursiveFlagValues    *flag.RecursiveFlagValues
 	decryptionFlagValues   *flag.DecryptionFlagValues
 	hiddenFileFlagValues   *flag.HiddenFileFlagValues
+	outputFlagValues       *flag.OutputFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
 
 	sourcePaths []string
+
+	// records are collected to print them at once in a structured format
+	records []commons.EntryRecord
+
+	// accesses of entries being listed, keyed by path
+	accesses map[string][]string
 }
 
 func NewLsCommand(command *cobra.Command, args []string) (*LsCommand, error) {
@@ -75,8 +87,13 @@ func NewLsCommand(command *cobra.Command, args []string) (*LsCommand, error) {
 
 		ticketAccessFlagValues: flag.GetTicketAccessFlagValues(),
 		listFlagValues:         flag.GetListFlagValues(),
+		aclFlagValues:          flag.GetACLFlagValues(),
+		recursiveFlagValues:    flag.GetRecursiveFlagValues(),
 		decryptionFlagValues:   flag.GetDecryptionFlagValues(command),
 		hiddenFileFlagValues:   flag.GetHiddenFileFlagValues(),
+		outputFlagValues:       flag.GetOutputFlagValues(),
+
+		accesses: map[string][]string{},
 	}
 
 	// path
@@ -148,6 +165,10 @@ func (ls *LsCommand) Process() error {
 		}
 	}
 
+	if ls.outputFlagValues.Format.IsStructured() {
+		return commons.PrintRecords(ls.outputFlagValues.Format, ls.records)
+	}
+
 	return nil
 }
 
@@ -192,34 +213,153 @@ func (ls *LsCommand) listOne(sourcePath string) error {
 			return xerrors.Errorf("failed to get collection %q: %w", sourcePath, err)
 		}
 
-		colls, err := irodsclient_irodsfs.ListSubCollections(connection, sourcePath)
+		return ls.listCollection(connection, collection)
+	}
+
+	// data object
+	entry, err := irodsclient_irodsfs.GetDataObjectWithoutCollection(connection, sourcePath)
+	if err != nil {
+		return xerrors.Errorf("failed to get data-object %q: %w", sourcePath, err)
+	}
+
+	if ls.aclFlagValues.ShowACL {
+		accesses, err := ls.filesystem.ListACLs(sourcePath)
 		if err != nil {
-			return xerrors.Errorf("failed to list sub-collections in %q: %w", sourcePath, err)
+			return xerrors.Errorf("failed to list accesses of %q: %w", sourcePath, err)
 		}
 
-		objs, err := irodsclient_irodsfs.ListDataObjects(connection, collection)
+		ls.addAccesses(accesses)
+	}
+
+	entries := []*irodsclient_types.IRODSDataObject{entry}
+	if ls.outputFlagValues.Format.IsStructured() {
+		ls.addDataObjectRecords(entries)
+		return nil
+	}
+
+	ls.printDataObjects(entries)
+
+	return nil
+}
+
+func (ls *LsCommand) listCollection(connection *irodsclient_conn.IRODSConnection, collection *irodsclient_types.IRODSCollection) error {
+	colls, err := irodsclient_irodsfs.ListSubCollections(connection, collection.Path)
+	if err != nil {
+		return xerrors.Errorf("failed to list sub-collections in %q: %w", collection.Path, err)
+	}
+
+	objs, err := irodsclient_irodsfs.ListDataObjects(connection, collection)
+	if err != nil {
+		return xerrors.Errorf("failed to list data-objects in %q: %w", collection.Path, err)
+	}
+
+	// filter out hidden files
+	filtered_colls := ls.filterHiddenCollections(colls)
+	filtered_objs := ls.filterHiddenDataObjects(objs)
+
+	if ls.aclFlagValues.ShowACL {
+		err = ls.listAccesses(connection, collection)
 		if err != nil {
-			return xerrors.Errorf("failed to list data-objects in %q: %w", sourcePath, err)
+			return err
 		}
+	}
 
-		// filter out hidden files
-		filtered_colls := ls.filterHiddenCollections(colls)
-		filtered_objs := ls.filterHiddenDataObjects(objs)
+	if ls.outputFlagValues.Format.IsStructured() {
+		ls.addDataObjectRecords(filtered_objs)
+		ls.addCollectionRecords(filtered_colls)
+	} else {
+		if ls.recursiveFlagValues.Recursive || ls.aclFlagValues.ShowACL {
+			commons.Printf("%s:\n", collection.Path)
+		}
+
+		if ls.aclFlagValues.ShowACL {
+			err = ls.printCollectionAccessInheritance(connection, collection)
+			if err != nil {
+				return err
+			}
+		}
 
 		ls.printDataObjects(filtered_objs)
 		ls.printCollections(filtered_colls)
+	}
 
+	if !ls.recursiveFlagValues.Recursive {
 		return nil
 	}
 
-	// data object
-	entry, err := irodsclient_irodsfs.GetDataObjectWithoutCollection(connection, sourcePath)
+	// entries are sorted when they are printed, so sub-collections are visited in the same order
+	for _, coll := range filtered_colls {
+		err = ls.listCollection(connection, coll)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listAccesses lists accesses of the collection, its sub-collections and data-objects in it
+func (ls *LsCommand) listAccesses(connection *irodsclient_conn.IRODSConnection, collection *irodsclient_types.IRODSCollection) error {
+	collectionAccesses, err := irodsclient_irodsfs.ListCollectionAccesses(connection, collection.Path)
 	if err != nil {
-		return xerrors.Errorf("failed to get data-object %q: %w", sourcePath, err)
+		return xerrors.Errorf("failed to list accesses of %q: %w", collection.Path, err)
 	}
 
-	entries := []*irodsclient_types.IRODSDataObject{entry}
-	ls.printDataObjects(entries)
+	subCollectionAccesses, err := irodsclient_irodsfs.ListAccessesForSubCollections(connection, collection.Path)
+	if err != nil {
+		return xerrors.Errorf("failed to list accesses of sub-collections in %q: %w", collection.Path, err)
+	}
+
+	dataObjectAccesses, err := irodsclient_irodsfs.ListAccessesForDataObjects(connection, collection)
+	if err != nil {
+		return xerrors.Errorf("failed to list accesses of data-objects in %q: %w", collection.Path, err)
+	}
+
+	ls.addAccesses(collectionAccesses)
+	ls.addAccesses(subCollectionAccesses)
+	ls.addAccesses(dataObjectAccesses)
+
+	return nil
+}
+
+func (ls *LsCommand) addAccesses(accesses []*irodsclient_types.IRODSAccess) {
+	for _, access := range accesses {
+		ls.accesses[access.Path] = append(ls.accesses[access.Path], commons.GetAccessString(access))
+	}
+}
+
+// getAccesses returns accesses of the entry at the path in sorted order, nil if ACLs are not shown
+func (ls *LsCommand) getAccesses(entryPath string) []string {
+	if !ls.aclFlagValues.ShowACL {
+		return nil
+	}
+
+	accesses := append([]string{}, ls.accesses[entryPath]...)
+	sort.Strings(accesses)
+	return accesses
+}
+
+func (ls *LsCommand) printAccesses(entryPath string) {
+	if !ls.aclFlagValues.ShowACL {
+		return
+	}
+
+	commons.Printf("        ACL - %s\n", strings.Join(ls.getAccesses(entryPath), "   "))
+}
+
+func (ls *LsCommand) printCollectionAccessInheritance(connection *irodsclient_conn.IRODSConnection, collection *irodsclient_types.IRODSCollection) error {
+	inheritance, err := irodsclient_irodsfs.GetCollectionAccessInheritance(connection, collection.Path)
+	if err != nil {
+		return xerrors.Errorf("failed to get access inheritance of %q: %w", collection.Path, err)
+	}
+
+	inheritanceString := "Disabled"
+	if inheritance.Inheritance {
+		inheritanceString = "Enabled"
+	}
+
+	ls.printAccesses(collection.Path)
+	commons.Printf("        Inheritance - %s\n", inheritanceString)
 
 	return nil
 }
@@ -258,22 +398,67 @@ func (ls *LsCommand) filterHiddenDataObjects(entries []*irodsclient_types.IRODSD
 	return filteredEntries
 }
 
+func (ls *LsCommand) addCollectionRecords(entries []*irodsclient_types.IRODSCollection) {
+	sort.SliceStable(entries, getCollectionSortFunction(entries, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
+	for _, entry := range entries {
+		record := commons.NewCollectionEntryRecord(entry)
+		record.ACL = ls.getAccesses(entry.Path)
+
+		ls.records = append(ls.records, record)
+	}
+}
+
+func (ls *LsCommand) addDataObjectRecords(entries []*irodsclient_types.IRODSDataObject) {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "LsCommand",
+		"function": "addDataObjectRecords",
+	})
+
+	// replica details are included in long formats
+	withReplicas := ls.listFlagValues.Format != commons.ListFormatNormal
+
+	sort.SliceStable(entries, getDataObjectSortFunction(entries, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
+	for _, entry := range entries {
+		record := commons.NewDataObjectEntryRecord(entry, withReplicas)
+		record.ACL = ls.getAccesses(entry.Path)
+
+		if ls.requireDecryption(entry.Path) {
+			// need to decrypt
+			encryptionMode := commons.DetectEncryptionMode(entry.Name)
+			if encryptionMode != commons.EncryptionModeUnknown {
+				encryptManager := ls.getEncryptionManagerForDecryption(encryptionMode)
+
+				decryptedFilename, err := encryptManager.DecryptFilename(entry.Name)
+				if err != nil {
+					logger.Debugf("%+v", err)
+				} else {
+					record.DecryptedName = decryptedFilename
+				}
+			}
+		}
+
+		ls.records = append(ls.records, record)
+	}
+}
+
 func (ls *LsCommand) printCollections(entries []*irodsclient_types.IRODSCollection) {
-	sort.SliceStable(entries, ls.getCollectionSortFunction(entries, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
+	sort.SliceStable(entries, getCollectionSortFunction(entries, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
 	for _, entry := range entries {
 		commons.Printf("  C- %s\n", entry.Path)
+		ls.printAccesses(entry.Path)
 	}
 }
 
 func (ls *LsCommand) printDataObjects(entries []*irodsclient_types.IRODSDataObject) {
 	if ls.listFlagValues.Format == commons.ListFormatNormal {
-		sort.SliceStable(entries, ls.getDataObjectSortFunction(entries, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
+		sort.SliceStable(entries, getDataObjectSortFunction(entries, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
 		for _, entry := range entries {
 			ls.printDataObjectShort(entry)
 		}
 	} else {
 		replicas := ls.flattenReplicas(entries)
-		sort.SliceStable(replicas, ls.getFlatReplicaSortFunction(replicas, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
+		sort.SliceStable(replicas, getFlatReplicaSortFunction(replicas, ls.listFlagValues.SortOrder, ls.listFlagValues.SortReverse))
 		ls.printReplicas(replicas)
 	}
 }
@@ -292,7 +477,7 @@ func (ls *LsCommand) flattenReplicas(objects []*irodsclient_types.IRODSDataObjec
 	return result
 }
 
-func (ls *LsCommand) getFlatReplicaSortFunction(entries []*FlatReplica, sortOrder commons.ListSortOrder, sortReverse bool) func(i int, j int) bool {
+func getFlatReplicaSortFunction(entries []*FlatReplica, sortOrder commons.ListSortOrder, sortReverse bool) func(i int, j int) bool {
 	if sortReverse {
 		switch sortOrder {
 		case commons.ListSortOrderName:
@@ -354,7 +539,7 @@ func (ls *LsCommand) getFlatReplicaSortFunction(entries []*FlatReplica, sortOrde
 	}
 }
 
-func (ls *LsCommand) getDataObjectSortFunction(entries []*irodsclient_types.IRODSDataObject, sortOrder commons.ListSortOrder, sortReverse bool) func(i int, j int) bool {
+func getDataObjectSortFunction(entries []*irodsclient_types.IRODSDataObject, sortOrder commons.ListSortOrder, sortReverse bool) func(i int, j int) bool {
 	if sortReverse {
 		switch sortOrder {
 		case commons.ListSortOrderName:
@@ -369,8 +554,8 @@ func (ls *LsCommand) getDataObjectSortFunction(entries []*irodsclient_types.IROD
 			}
 		case commons.ListSortOrderTime:
 			return func(i int, j int) bool {
-				return (ls.getDataObjectModifyTime(entries[i]).After(ls.getDataObjectModifyTime(entries[j]))) ||
-					(ls.getDataObjectModifyTime(entries[i]).Equal(ls.getDataObjectModifyTime(entries[j])) &&
+				return (getDataObjectModifyTime(entries[i]).After(getDataObjectModifyTime(entries[j]))) ||
+					(getDataObjectModifyTime(entries[i]).Equal(getDataObjectModifyTime(entries[j])) &&
 						entries[i].Name < entries[j].Name)
 			}
 		case commons.ListSortOrderSize:
@@ -399,8 +584,8 @@ func (ls *LsCommand) getDataObjectSortFunction(entries []*irodsclient_types.IROD
 		}
 	case commons.ListSortOrderTime:
 		return func(i int, j int) bool {
-			return (ls.getDataObjectModifyTime(entries[i]).Before(ls.getDataObjectModifyTime(entries[j]))) ||
-				(ls.getDataObjectModifyTime(entries[i]).Equal(ls.getDataObjectModifyTime(entries[j])) &&
+			return (getDataObjectModifyTime(entries[i]).Before(getDataObjectModifyTime(entries[j]))) ||
+				(getDataObjectModifyTime(entries[i]).Equal(getDataObjectModifyTime(entries[j])) &&
 					entries[i].Name < entries[j].Name)
 		}
 	case commons.ListSortOrderSize:
@@ -416,7 +601,7 @@ func (ls *LsCommand) getDataObjectSortFunction(entries []*irodsclient_types.IROD
 	}
 }
 
-func (ls *LsCommand) getDataObjectModifyTime(object *irodsclient_types.IRODSDataObject) time.Time {
+func getDataObjectModifyTime(object *irodsclient_types.IRODSDataObject) time.Time {
 	// ModifyTime of data object is considered to be ModifyTime of replica modified most recently
 	maxTime := object.Replicas[0].ModifyTime
 	for _, t := range object.Replicas[1:] {
@@ -453,6 +638,7 @@ func (ls *LsCommand) printDataObjectShort(entry *irodsclient_types.IRODSDataObje
 	}
 
 	commons.Printf("  %s\n", newName)
+	ls.printAccesses(entry.Path)
 }
 
 func (ls *LsCommand) printReplicas(flatReplicas []*FlatReplica) {
@@ -519,9 +705,11 @@ func (ls *LsCommand) printReplica(flatReplica FlatReplica) {
 	default:
 		commons.Printf("  %d\t%s\n", flatReplica.Replica.Number, newName)
 	}
+
+	ls.printAccesses(flatReplica.DataObject.Path)
 }
 
-func (ls *LsCommand) getCollectionSortFunction(entries []*irodsclient_types.IRODSCollection, sortOrder commons.ListSortOrder, sortReverse bool) func(i int, j int) bool {
+func getCollectionSortFunction(entries []*irodsclient_types.IRODSCollection, sortOrder commons.ListSortOrder, sortReverse bool) func(i int, j int) bool {
 	if sortReverse {
 		switch sortOrder {
 		case commons.ListSortOrderName:
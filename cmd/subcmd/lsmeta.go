@@ -46,6 +46,7 @@ type LsMetaCommand struct {
 
 	listFlagValues         *flag.ListFlagValues
 	targetObjectFlagValues *flag.TargetObjectFlagValues
+	outputFlagValues       *flag.OutputFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -57,6 +58,7 @@ func NewLsMetaCommand(command *cobra.Command, args []string) (*LsMetaCommand, er
 
 		listFlagValues:         flag.GetListFlagValues(),
 		targetObjectFlagValues: flag.GetTargetObjectFlagValues(command),
+		outputFlagValues:       flag.GetOutputFlagValues(),
 	}
 
 	return lsMeta, nil
@@ -109,11 +111,6 @@ func (lsMeta *LsMetaCommand) listMetaForPath(targetPath string) error {
 		return xerrors.Errorf("failed to list meta for path %q: %w", targetPath, err)
 	}
 
-	if len(metas) == 0 {
-		commons.Printf("Found no metadata\n")
-		return nil
-	}
-
 	return lsMeta.printMetas(metas)
 }
 
@@ -123,11 +120,6 @@ func (lsMeta *LsMetaCommand) listMetaForUser(username string) error {
 		return xerrors.Errorf("failed to list meta for user %q: %w", username, err)
 	}
 
-	if len(metas) == 0 {
-		commons.Printf("Found no metadata\n")
-		return nil
-	}
-
 	return lsMeta.printMetas(metas)
 }
 
@@ -137,17 +129,26 @@ func (lsMeta *LsMetaCommand) listMetaForResource(resource string) error {
 		return xerrors.Errorf("failed to list meta for resource %q: %w", resource, err)
 	}
 
-	if len(metas) == 0 {
-		commons.Printf("Found no metadata\n")
-		return nil
-	}
-
 	return lsMeta.printMetas(metas)
 }
 
 func (lsMeta *LsMetaCommand) printMetas(metas []*types.IRODSMeta) error {
 	sort.SliceStable(metas, lsMeta.getMetaSortFunction(metas, lsMeta.listFlagValues.SortOrder, lsMeta.listFlagValues.SortReverse))
 
+	if lsMeta.outputFlagValues.Format.IsStructured() {
+		records := []commons.MetaRecord{}
+		for _, meta := range metas {
+			records = append(records, commons.NewMetaRecord(meta))
+		}
+
+		return commons.PrintRecords(lsMeta.outputFlagValues.Format, records)
+	}
+
+	if len(metas) == 0 {
+		commons.Printf("Found no metadata\n")
+		return nil
+	}
+
 	for _, meta := range metas {
 		lsMeta.printMetaInternal(meta)
 	}
@@ -43,7 +43,8 @@ func processLsticketCommand(command *cobra.Command, args []string) error {
 type LsTicketCommand struct {
 	command *cobra.Command
 
-	listFlagValues *flag.ListFlagValues
+	listFlagValues   *flag.ListFlagValues
+	outputFlagValues *flag.OutputFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -55,7 +56,8 @@ func NewLsTicketCommand(command *cobra.Command, args []string) (*LsTicketCommand
 	lsTicket := &LsTicketCommand{
 		command: command,
 
-		listFlagValues: flag.GetListFlagValues(),
+		listFlagValues:   flag.GetListFlagValues(),
+		outputFlagValues: flag.GetOutputFlagValues(),
 	}
 
 	// tickets
@@ -92,6 +94,10 @@ func (lsTicket *LsTicketCommand) Process() error {
 		return lsTicket.listTickets()
 	}
 
+	if lsTicket.outputFlagValues.Format.IsStructured() {
+		return lsTicket.printTicketsByName(lsTicket.tickets)
+	}
+
 	for _, ticketName := range lsTicket.tickets {
 		err = lsTicket.printTicket(ticketName)
 		if err != nil {
@@ -108,34 +114,72 @@ func (lsTicket *LsTicketCommand) listTickets() error {
 		return xerrors.Errorf("failed to list tickets: %w", err)
 	}
 
-	if len(tickets) == 0 {
+	if len(tickets) == 0 && !lsTicket.outputFlagValues.Format.IsStructured() {
 		commons.Printf("Found no tickets\n")
 	}
 
 	return lsTicket.printTickets(tickets)
 }
 
-func (lsTicket *LsTicketCommand) printTicket(ticketName string) error {
+func (lsTicket *LsTicketCommand) getTicket(ticketName string) (*types.IRODSTicket, error) {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
 		"struct":   "LsTicketCommand",
-		"function": "printTicket",
+		"function": "getTicket",
 	})
 
-	logger.Debugf("print ticket %q", ticketName)
+	logger.Debugf("get ticket %q", ticketName)
 
 	ticket, err := lsTicket.filesystem.GetTicket(ticketName)
 	if err != nil {
-		return xerrors.Errorf("failed to get ticket %q: %w", ticketName, err)
+		return nil, xerrors.Errorf("failed to get ticket %q: %w", ticketName, err)
+	}
+
+	return ticket, nil
+}
+
+func (lsTicket *LsTicketCommand) printTicket(ticketName string) error {
+	ticket, err := lsTicket.getTicket(ticketName)
+	if err != nil {
+		return err
 	}
 
 	tickets := []*types.IRODSTicket{ticket}
 	return lsTicket.printTickets(tickets)
 }
 
+// printTicketsByName prints tickets in a structured output
+// a ticket failed to get does not hide other tickets, the first failure is returned after printing
+func (lsTicket *LsTicketCommand) printTicketsByName(ticketNames []string) error {
+	tickets := []*types.IRODSTicket{}
+	var firstErr error
+	for _, ticketName := range ticketNames {
+		ticket, err := lsTicket.getTicket(ticketName)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	err := lsTicket.printTickets(tickets)
+	if err != nil {
+		return err
+	}
+
+	return firstErr
+}
+
 func (lsTicket *LsTicketCommand) printTickets(tickets []*types.IRODSTicket) error {
 	sort.SliceStable(tickets, lsTicket.getTicketSortFunction(tickets, lsTicket.listFlagValues.SortOrder, lsTicket.listFlagValues.SortReverse))
 
+	if lsTicket.outputFlagValues.Format.IsStructured() {
+		return lsTicket.printTicketRecords(tickets)
+	}
+
 	for _, ticket := range tickets {
 		err := lsTicket.printTicketInternal(ticket)
 		if err != nil {
@@ -146,6 +190,31 @@ func (lsTicket *LsTicketCommand) printTickets(tickets []*types.IRODSTicket) erro
 	return nil
 }
 
+func (lsTicket *LsTicketCommand) printTicketRecords(tickets []*types.IRODSTicket) error {
+	records := []commons.TicketRecord{}
+	var firstErr error
+	for _, ticket := range tickets {
+		// restrictions are always included in structured output
+		// a ticket is still printed without restrictions if they cannot be read
+		restrictions, err := lsTicket.filesystem.GetTicketRestrictions(ticket.ID)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = xerrors.Errorf("failed to get ticket restrictions %q: %w", ticket.Name, err)
+			}
+			restrictions = nil
+		}
+
+		records = append(records, commons.NewTicketRecord(ticket, restrictions))
+	}
+
+	err := commons.PrintRecords(lsTicket.outputFlagValues.Format, records)
+	if err != nil {
+		return err
+	}
+
+	return firstErr
+}
+
 func (lsTicket *LsTicketCommand) printTicketInternal(ticket *types.IRODSTicket) error {
 	commons.Printf("[%s]\n", ticket.Name)
 	commons.Printf("  id: %d\n", ticket.ID)
@@ -0,0 +1,42 @@
+package subcmd
+
+import (
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var metaCmd = &cobra.Command{
+	Use:   "meta [subcommand]",
+	Short: "Import or export metadata in bulk",
+	Long:  `This imports or exports metadata of many data objects and collections at once.`,
+	RunE:  processMetaCommand,
+	Args:  cobra.NoArgs,
+}
+
+func AddMetadataCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(metaCmd, true)
+
+	// add sub commands
+	AddMetaImportCommand(metaCmd)
+	AddMetaExportCommand(metaCmd)
+
+	rootCmd.AddCommand(metaCmd)
+}
+
+func processMetaCommand(command *cobra.Command, args []string) error {
+	cont, err := flag.ProcessCommonFlags(command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// if nothing is given
+	command.Usage()
+
+	return nil
+}
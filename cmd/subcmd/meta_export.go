@@ -0,0 +1,167 @@
+package subcmd
+
+import (
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var metaExportCmd = &cobra.Command{
+	Use:   "export [data-object|collection] ...",
+	Short: "Export metadata to stdout",
+	Long:  `This exports metadata of data objects and collections in csv, json or yaml. For a collection, metadata of the collection and its direct children are exported, or all entries under it with -r. The output can be imported again with "meta import".`,
+	RunE:  processMetaExportCommand,
+	Args:  cobra.ArbitraryArgs,
+}
+
+func AddMetaExportCommand(metaCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(metaExportCmd, true)
+
+	flag.SetMetaExportFlags(metaExportCmd)
+	flag.SetRecursiveFlags(metaExportCmd, false)
+
+	metaCmd.AddCommand(metaExportCmd)
+}
+
+func processMetaExportCommand(command *cobra.Command, args []string) error {
+	metaExport, err := NewMetaExportCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return metaExport.Process()
+}
+
+type MetaExportCommand struct {
+	command *cobra.Command
+
+	metaExportFlagValues *flag.MetaExportFlagValues
+	recursiveFlagValues  *flag.RecursiveFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	sourcePaths []string
+
+	records []commons.PathMetaRecord
+}
+
+func NewMetaExportCommand(command *cobra.Command, args []string) (*MetaExportCommand, error) {
+	metaExport := &MetaExportCommand{
+		command: command,
+
+		metaExportFlagValues: flag.GetMetaExportFlagValues(),
+		recursiveFlagValues:  flag.GetRecursiveFlagValues(),
+
+		records: []commons.PathMetaRecord{},
+	}
+
+	if !metaExport.metaExportFlagValues.Format.IsStructured() {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("unsupported format %q, must be one of csv, json or yaml", metaExport.metaExportFlagValues.Format))
+	}
+
+	// path
+	metaExport.sourcePaths = args[:]
+
+	if len(args) == 0 {
+		metaExport.sourcePaths = []string{"."}
+	}
+
+	return metaExport, nil
+}
+
+func (metaExport *MetaExportCommand) Process() error {
+	cont, err := flag.ProcessCommonFlags(metaExport.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	metaExport.account = commons.GetAccount()
+	metaExport.filesystem, err = commons.GetIRODSFSClient(metaExport.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer metaExport.filesystem.Release()
+
+	// run
+	for _, sourcePath := range metaExport.sourcePaths {
+		err = metaExport.exportOne(sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to export metadata of %q: %w", sourcePath, err)
+		}
+	}
+
+	return commons.PrintRecords(metaExport.metaExportFlagValues.Format, metaExport.records)
+}
+
+func (metaExport *MetaExportCommand) exportOne(sourcePath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "MetaExportCommand",
+		"function": "exportOne",
+	})
+
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	sourcePath = commons.MakeIRODSPath(cwd, home, zone, sourcePath)
+
+	sourceEntry, err := metaExport.filesystem.Stat(sourcePath)
+	if err != nil {
+		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
+	}
+
+	if !sourceEntry.IsDir() {
+		// data object
+		metas, err := metaExport.filesystem.ListMetadata(sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to list meta for path %q: %w", sourcePath, err)
+		}
+
+		records := []commons.PathMetaRecord{}
+		for _, meta := range metas {
+			records = append(records, commons.PathMetaRecord{
+				Path:      sourcePath,
+				Attribute: meta.Name,
+				Value:     meta.Value,
+				Unit:      meta.Units,
+			})
+		}
+
+		commons.SortPathMetaRecords(records)
+		metaExport.records = append(metaExport.records, records...)
+		return nil
+	}
+
+	logger.Debugf("exporting metadata in %q", sourcePath)
+
+	connection, err := metaExport.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer metaExport.filesystem.ReturnMetadataConnection(connection)
+
+	records, err := commons.ListPathMetaRecords(connection, sourcePath, metaExport.recursiveFlagValues.Recursive)
+	if err != nil {
+		return xerrors.Errorf("failed to list metadata: %w", err)
+	}
+
+	metaExport.records = append(metaExport.records, records...)
+	return nil
+}
@@ -0,0 +1,352 @@
+package subcmd
+
+import (
+	"io"
+	"os"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/jedib0t/go-pretty/v6/progress"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var metaImportCmd = &cobra.Command{
+	Use:   "import [csv|json|yaml file]",
+	Short: "Import metadata from a file",
+	Long: `This adds, sets or removes metadata of data objects and collections listed in a csv, json or yaml file. Each record has path, attribute, value, unit and an optional operation (add, set or remove). Records are applied in parallel, one job per path. Use "-" to read from stdin.
+Add skips metadata that already exists. Set replaces all values of the attribute. Remove with an empty value removes all values of the attribute.`,
+	RunE: processMetaImportCommand,
+	Args: cobra.ExactArgs(1),
+}
+
+func AddMetaImportCommand(metaCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(metaImportCmd, true)
+
+	flag.SetMetaImportFlags(metaImportCmd)
+	flag.SetProgressFlags(metaImportCmd)
+	flag.SetRetryFlags(metaImportCmd)
+	flag.SetContinueOnErrorFlags(metaImportCmd)
+
+	metaCmd.AddCommand(metaImportCmd)
+}
+
+func processMetaImportCommand(command *cobra.Command, args []string) error {
+	metaImport, err := NewMetaImportCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return metaImport.Process()
+}
+
+type MetaImportCommand struct {
+	command *cobra.Command
+
+	metaImportFlagValues      *flag.MetaImportFlagValues
+	progressFlagValues        *flag.ProgressFlagValues
+	retryFlagValues           *flag.RetryFlagValues
+	continueOnErrorFlagValues *flag.ContinueOnErrorFlagValues
+
+	maxConnectionNum int
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	sourcePath string
+	format     commons.OutputFormat
+
+	parallelJobManager *commons.ParallelJobManager
+}
+
+func NewMetaImportCommand(command *cobra.Command, args []string) (*MetaImportCommand, error) {
+	metaImport := &MetaImportCommand{
+		command: command,
+
+		metaImportFlagValues:      flag.GetMetaImportFlagValues(),
+		progressFlagValues:        flag.GetProgressFlagValues(),
+		retryFlagValues:           flag.GetRetryFlagValues(),
+		continueOnErrorFlagValues: flag.GetContinueOnErrorFlagValues(),
+	}
+
+	metaImport.maxConnectionNum = metaImport.metaImportFlagValues.ThreadNumber
+
+	// path
+	metaImport.sourcePath = args[0]
+
+	// format
+	metaImport.format = metaImport.metaImportFlagValues.Format
+	if len(metaImport.format) == 0 {
+		if commons.IsStreamPath(metaImport.sourcePath) {
+			metaImport.format = commons.OutputFormatCSV
+		} else {
+			format, err := commons.GetMetaFormatFromPath(metaImport.sourcePath)
+			if err != nil {
+				return nil, commons.NewUsageError(command.CommandPath(), err)
+			}
+			metaImport.format = format
+		}
+	}
+
+	if !metaImport.format.IsStructured() {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("unsupported format %q, must be one of csv, json or yaml", metaImport.format))
+	}
+
+	return metaImport, nil
+}
+
+func (metaImport *MetaImportCommand) Process() error {
+	cont, err := flag.ProcessCommonFlags(metaImport.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// read records before connecting to fail early on a bad file
+	records, err := metaImport.readRecords()
+	if err != nil {
+		return err
+	}
+
+	// Create a file system
+	metaImport.account = commons.GetAccount()
+	metaImport.filesystem, err = commons.GetIRODSFSClientAdvanced(metaImport.account, metaImport.maxConnectionNum, commons.TcpBufferSizeDefault)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer metaImport.filesystem.Release()
+
+	// parallel job manager
+	metaImport.parallelJobManager = commons.NewParallelJobManager(metaImport.filesystem, metaImport.metaImportFlagValues.ThreadNumber, metaImport.progressFlagValues.ShowProgress, metaImport.progressFlagValues.ShowFullPath)
+	metaImport.parallelJobManager.SetRetryPolicy(metaImport.retryFlagValues.RetryPolicy)
+	metaImport.parallelJobManager.SetContinueOnError(metaImport.continueOnErrorFlagValues.ContinueOnError)
+	metaImport.parallelJobManager.Start()
+
+	// group records by path, operations on the same path are applied in order
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+
+	targetPaths := []string{}
+	recordsByPath := map[string][]commons.PathMetaRecord{}
+	for _, record := range records {
+		targetPath := commons.MakeIRODSPath(cwd, home, zone, record.Path)
+		if _, ok := recordsByPath[targetPath]; !ok {
+			targetPaths = append(targetPaths, targetPath)
+		}
+
+		recordsByPath[targetPath] = append(recordsByPath[targetPath], record)
+	}
+
+	// run
+	for _, targetPath := range targetPaths {
+		err = metaImport.scheduleImport(targetPath, recordsByPath[targetPath])
+		if err != nil {
+			return err
+		}
+	}
+
+	metaImport.parallelJobManager.DoneScheduling()
+	err = metaImport.parallelJobManager.Wait()
+	if err != nil {
+		return xerrors.Errorf("failed to perform parallel jobs: %w", err)
+	}
+
+	return nil
+}
+
+func (metaImport *MetaImportCommand) readRecords() ([]commons.PathMetaRecord, error) {
+	var reader io.Reader
+	if commons.IsStreamPath(metaImport.sourcePath) {
+		reader = os.Stdin
+	} else {
+		sourcePath := commons.MakeLocalPath(metaImport.sourcePath)
+
+		file, err := os.Open(sourcePath)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to open file %q: %w", sourcePath, err)
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	records, err := commons.ReadPathMetaRecords(reader, metaImport.format, metaImport.metaImportFlagValues.Operation)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read metadata from %q: %w", metaImport.sourcePath, err)
+	}
+
+	return records, nil
+}
+
+func (metaImport *MetaImportCommand) scheduleImport(targetPath string, records []commons.PathMetaRecord) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "MetaImportCommand",
+		"function": "scheduleImport",
+	})
+
+	importTask := func(job *commons.ParallelJob) error {
+		manager := job.GetManager()
+		fs := manager.GetFilesystem()
+
+		total := int64(len(records))
+		job.Progress(0, total, false)
+
+		targets, err := commons.ListMetaTargets(fs, targetPath, false)
+		if err != nil {
+			job.Progress(-1, total, true)
+			return xerrors.Errorf("failed to get target of path %q: %w", targetPath, err)
+		}
+
+		target := targets[0]
+
+		metas, err := commons.ListMetadata(fs, target)
+		if err != nil {
+			job.Progress(-1, total, true)
+			return err
+		}
+
+		conn, err := fs.GetMetadataConnection()
+		if err != nil {
+			job.Progress(-1, total, true)
+			return xerrors.Errorf("failed to get connection: %w", err)
+		}
+		defer fs.ReturnMetadataConnection(conn)
+
+		for idx, record := range records {
+			metas, err = metaImport.applyRecord(conn, target, metas, record)
+			if err != nil {
+				job.Progress(-1, total, true)
+				return err
+			}
+
+			job.Progress(int64(idx+1), total, false)
+		}
+
+		logger.Debugf("imported %d metadata records to %q", len(records), targetPath)
+		job.Done()
+		return nil
+	}
+
+	err := metaImport.parallelJobManager.Schedule(targetPath, importTask, 1, progress.UnitsDefault)
+	if err != nil {
+		return xerrors.Errorf("failed to schedule metadata import to %q: %w", targetPath, err)
+	}
+
+	logger.Debugf("scheduled metadata import to %q", targetPath)
+
+	return nil
+}
+
+// applyRecord applies a record to the target, and returns updated metadata of the target
+// metadata added or set here have no AVU IDs, they are removed by their values
+func (metaImport *MetaImportCommand) applyRecord(conn *irodsclient_conn.IRODSConnection, target commons.MetaTarget, metas []*irodsclient_types.IRODSMeta, record commons.PathMetaRecord) ([]*irodsclient_types.IRODSMeta, error) {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "MetaImportCommand",
+		"function": "applyRecord",
+	})
+
+	exists := false
+	attributeValues := 0
+	for _, meta := range metas {
+		if meta.Name != record.Attribute {
+			continue
+		}
+
+		attributeValues++
+		if meta.Value == record.Value && meta.Units == record.Unit {
+			exists = true
+		}
+	}
+
+	newMeta := &irodsclient_types.IRODSMeta{
+		Name:  record.Attribute,
+		Value: record.Value,
+		Units: record.Unit,
+	}
+
+	switch record.Operation {
+	case commons.MetaOperationAdd:
+		if exists {
+			logger.Debugf("skip adding existing metadata to path %q (attr %q, value %q, unit %q)", target.Name, record.Attribute, record.Value, record.Unit)
+			return metas, nil
+		}
+
+		logger.Debugf("add metadata to path %q (attr %q, value %q, unit %q)", target.Name, record.Attribute, record.Value, record.Unit)
+
+		err := commons.AddMetadata(conn, target, record.Attribute, record.Value, record.Unit)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(metas, newMeta), nil
+	case commons.MetaOperationSet:
+		if exists && attributeValues == 1 {
+			logger.Debugf("skip setting existing metadata of path %q (attr %q, value %q, unit %q)", target.Name, record.Attribute, record.Value, record.Unit)
+			return metas, nil
+		}
+
+		logger.Debugf("set metadata of path %q (attr %q, value %q, unit %q)", target.Name, record.Attribute, record.Value, record.Unit)
+
+		// replaces all values of the attribute in a single request
+		err := commons.SetMetadata(conn, target, record.Attribute, record.Value, record.Unit)
+		if err != nil {
+			return nil, err
+		}
+
+		remainingMetas := []*irodsclient_types.IRODSMeta{}
+		for _, meta := range metas {
+			if meta.Name != record.Attribute {
+				remainingMetas = append(remainingMetas, meta)
+			}
+		}
+
+		return append(remainingMetas, newMeta), nil
+	}
+
+	// remove
+	remainingMetas := []*irodsclient_types.IRODSMeta{}
+	for _, meta := range metas {
+		if meta.Name != record.Attribute {
+			remainingMetas = append(remainingMetas, meta)
+			continue
+		}
+
+		// empty value removes all values of the attribute
+		if len(record.Value) > 0 && (meta.Value != record.Value || meta.Units != record.Unit) {
+			remainingMetas = append(remainingMetas, meta)
+			continue
+		}
+
+		logger.Debugf("remove metadata from path %q (attr %q, value %q, unit %q)", target.Name, meta.Name, meta.Value, meta.Units)
+
+		var err error
+		if meta.AVUID != 0 {
+			err = commons.RemoveMetadata(conn, target, meta.AVUID)
+		} else {
+			err = commons.RemoveMetadataByValue(conn, target, meta.Name, meta.Value, meta.Units)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return remainingMetas, nil
+}
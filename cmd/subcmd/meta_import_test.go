@@ -0,0 +1,45 @@
+package subcmd
+
+import (
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaImport(t *testing.T) {
+	t.Run("test ApplyRecordWithoutChanges", testApplyRecordWithoutChanges)
+}
+
+func testApplyRecordWithoutChanges(t *testing.T) {
+	metaImport := &MetaImportCommand{}
+	target := commons.MetaTarget{ItemType: irodsclient_types.IRODSDataObjectMetaItemType, Name: "/zone/home/user/a.txt"}
+
+	metas := []*irodsclient_types.IRODSMeta{
+		{AVUID: 1, Name: "color", Value: "red", Units: ""},
+		{AVUID: 2, Name: "size", Value: "10", Units: "cm"},
+		{AVUID: 3, Name: "size", Value: "20", Units: "cm"},
+	}
+
+	// records that change nothing do not send requests, so no connection is needed
+	tests := []struct {
+		name   string
+		record commons.PathMetaRecord
+	}{
+		{"add existing", commons.PathMetaRecord{Attribute: "color", Value: "red", Operation: commons.MetaOperationAdd}},
+		{"set the only value", commons.PathMetaRecord{Attribute: "color", Value: "red", Operation: commons.MetaOperationSet}},
+		{"remove missing value", commons.PathMetaRecord{Attribute: "size", Value: "30", Unit: "cm", Operation: commons.MetaOperationRemove}},
+		{"remove missing attribute", commons.PathMetaRecord{Attribute: "shape", Operation: commons.MetaOperationRemove}},
+	}
+
+	for _, test := range tests {
+		updatedMetas, err := metaImport.applyRecord(nil, target, metas, test.record)
+		assert.NoError(t, err, test.name)
+		assert.ElementsMatch(t, metas, updatedMetas, test.name)
+	}
+
+	// setting one of many values replaces all of them
+	_, err := metaImport.applyRecord(nil, target, metas, commons.PathMetaRecord{Attribute: "size", Value: "10", Unit: "cm", Operation: commons.MetaOperationSet})
+	assert.Error(t, err)
+}
@@ -0,0 +1,173 @@
+package subcmd
+
+import (
+	"strconv"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var modmetaCmd = &cobra.Command{
+	Use:     "modmeta [AVU ID|attribute name] [new attribute value] [new attribute unit (optional)]",
+	Aliases: []string{"mod_meta", "modify_meta", "mod_metadata", "modify_metadata"},
+	Short:   "Modify a metadata",
+	Long:    `This changes value and unit of a metadata of the given collection, data object, user, or a resource in a single request. The metadata is selected by its AVU ID, or by its attribute name if the attribute has only one value. The unit is kept if not given.`,
+	RunE:    processModmetaCommand,
+	Args:    cobra.RangeArgs(2, 3),
+}
+
+func AddModmetaCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(modmetaCmd, true)
+
+	flag.SetTargetObjectFlags(modmetaCmd)
+
+	rootCmd.AddCommand(modmetaCmd)
+}
+
+func processModmetaCommand(command *cobra.Command, args []string) error {
+	modMeta, err := NewModMetaCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return modMeta.Process()
+}
+
+type ModMetaCommand struct {
+	command *cobra.Command
+
+	targetObjectFlagValues *flag.TargetObjectFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	avuID       string
+	value       string
+	unit        string
+	unitUpdated bool
+}
+
+func NewModMetaCommand(command *cobra.Command, args []string) (*ModMetaCommand, error) {
+	modMeta := &ModMetaCommand{
+		command: command,
+
+		targetObjectFlagValues: flag.GetTargetObjectFlagValues(command),
+	}
+
+	// get avu
+	modMeta.avuID = args[0]
+	modMeta.value = args[1]
+	modMeta.unit = ""
+	modMeta.unitUpdated = false
+	if len(args) >= 3 {
+		modMeta.unit = args[2]
+		modMeta.unitUpdated = true
+	}
+
+	return modMeta, nil
+}
+
+func (modMeta *ModMetaCommand) Process() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "ModMetaCommand",
+		"function": "Process",
+	})
+
+	cont, err := flag.ProcessCommonFlags(modMeta.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	modMeta.account = commons.GetAccount()
+	modMeta.filesystem, err = commons.GetIRODSFSClient(modMeta.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer modMeta.filesystem.Release()
+
+	targets, err := getMetaTargets(modMeta.filesystem, modMeta.targetObjectFlagValues, false)
+	if err != nil {
+		return err
+	}
+
+	target := targets[0]
+
+	meta, err := modMeta.findMeta(target)
+	if err != nil {
+		return err
+	}
+
+	unit := meta.Units
+	if modMeta.unitUpdated {
+		unit = modMeta.unit
+	}
+
+	connection, err := modMeta.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer modMeta.filesystem.ReturnMetadataConnection(connection)
+
+	logger.Debugf("modify metadata %d of %q (attr %q, value %q, unit %q)", meta.AVUID, target.Name, meta.Name, modMeta.value, unit)
+
+	return commons.ModifyMetadata(connection, target, meta, modMeta.value, unit)
+}
+
+func (modMeta *ModMetaCommand) findMeta(target commons.MetaTarget) (*irodsclient_types.IRODSMeta, error) {
+	metas, err := commons.ListMetadata(modMeta.filesystem, target)
+	if err != nil {
+		return nil, err
+	}
+
+	if commons.IsDigitsOnly(modMeta.avuID) {
+		// avu ID
+		avuid, err := strconv.ParseInt(modMeta.avuID, 10, 64)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to parse AVUID: %w", err)
+		}
+
+		for _, meta := range metas {
+			if meta.AVUID == avuid {
+				return meta, nil
+			}
+		}
+
+		return nil, xerrors.Errorf("failed to find metadata %d of %q", avuid, target.Name)
+	}
+
+	// possibly name
+	matchedMetas := []*irodsclient_types.IRODSMeta{}
+	for _, meta := range metas {
+		if meta.Name == modMeta.avuID {
+			matchedMetas = append(matchedMetas, meta)
+		}
+	}
+
+	if len(matchedMetas) == 0 {
+		return nil, xerrors.Errorf("failed to find metadata %q of %q", modMeta.avuID, target.Name)
+	}
+
+	if len(matchedMetas) > 1 {
+		return nil, xerrors.Errorf("attribute %q of %q has %d values, use AVU ID to select one", modMeta.avuID, target.Name, len(matchedMetas))
+	}
+
+	return matchedMetas[0], nil
+}
@@ -25,6 +25,8 @@ func AddMvCommand(rootCmd *cobra.Command) {
 	// attach common flags
 	flag.SetCommonFlags(mvCmd, false)
 
+	flag.SetDryRunFlags(mvCmd)
+
 	rootCmd.AddCommand(mvCmd)
 }
 
@@ -40,6 +42,8 @@ func processMvCommand(command *cobra.Command, args []string) error {
 type MvCommand struct {
 	command *cobra.Command
 
+	dryRunFlagValues *flag.DryRunFlagValues
+
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
 
@@ -50,6 +54,8 @@ type MvCommand struct {
 func NewMvCommand(command *cobra.Command, args []string) (*MvCommand, error) {
 	mv := &MvCommand{
 		command: command,
+
+		dryRunFlagValues: flag.GetDryRunFlagValues(),
 	}
 
 	// paths
@@ -160,6 +166,11 @@ func (mv *MvCommand) moveFile(sourceEntry *irodsclient_fs.Entry, targetPath stri
 		if irodsclient_types.IsFileNotFoundError(err) {
 			// target does not exist
 			// target must be a file with new name
+			if mv.dryRunFlagValues.DryRun {
+				commons.Printf("[DRY RUN] RENAME\t%q -> %q\n", sourceEntry.Path, targetPath)
+				return nil
+			}
+
 			logger.Debugf("renaming a data object %q to %q", sourceEntry.Path, targetPath)
 			err = mv.filesystem.RenameFileToFile(sourceEntry.Path, targetPath)
 			if err != nil {
@@ -178,6 +189,11 @@ func (mv *MvCommand) moveFile(sourceEntry *irodsclient_fs.Entry, targetPath stri
 		return commons.NewNotFileError(targetPath)
 	}
 
+	if mv.dryRunFlagValues.DryRun {
+		commons.Printf("[DRY RUN] RENAME\t%q -> %q\t(overwrite)\n", sourceEntry.Path, targetPath)
+		return nil
+	}
+
 	// overwrite
 	err = mv.filesystem.RemoveFile(targetPath, true)
 	if err != nil {
@@ -205,6 +221,11 @@ func (mv *MvCommand) moveDir(sourceEntry *irodsclient_fs.Entry, targetPath strin
 		if irodsclient_types.IsFileNotFoundError(err) {
 			// target does not exist
 			// target must be a directorywith new name
+			if mv.dryRunFlagValues.DryRun {
+				commons.Printf("[DRY RUN] RENAME\t%q -> %q\t(collection)\n", sourceEntry.Path, targetPath)
+				return nil
+			}
+
 			logger.Debugf("renaming a collection %q to %q", sourceEntry.Path, targetPath)
 			err = mv.filesystem.RenameDirToDir(sourceEntry.Path, targetPath)
 			if err != nil {
@@ -220,6 +241,11 @@ func (mv *MvCommand) moveDir(sourceEntry *irodsclient_fs.Entry, targetPath strin
 	// target exist
 	if targetEntry.IsDir() {
 		targetDirPath := path.Join(targetPath, sourceEntry.Name)
+		if mv.dryRunFlagValues.DryRun {
+			commons.Printf("[DRY RUN] RENAME\t%q -> %q\t(collection)\n", sourceEntry.Path, targetDirPath)
+			return nil
+		}
+
 		logger.Debugf("renaming a collection %q to %q", sourceEntry.Path, targetDirPath)
 		err = mv.filesystem.RenameDirToDir(sourceEntry.Path, targetDirPath)
 		if err != nil {
@@ -46,6 +46,7 @@ type PsCommand struct {
 	command *cobra.Command
 
 	processFilterFlagValues *flag.ProcessFilterFlagValues
+	outputFlagValues        *flag.OutputFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -56,6 +57,7 @@ func NewPsCommand(command *cobra.Command, args []string) (*PsCommand, error) {
 		command: command,
 
 		processFilterFlagValues: flag.GetProcessFilterFlagValues(),
+		outputFlagValues:        flag.GetOutputFlagValues(),
 	}
 
 	return ps, nil
@@ -113,6 +115,10 @@ func (ps *PsCommand) listProcesses() error {
 		return xerrors.Errorf("failed to stat process addr %q, zone %q: %w", ps.processFilterFlagValues.Address, ps.processFilterFlagValues.Zone, err)
 	}
 
+	if ps.outputFlagValues.Format.IsStructured() {
+		return ps.printProcessRecords(processes)
+	}
+
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
 
@@ -205,3 +211,39 @@ func (ps *PsCommand) listProcesses() error {
 
 	return nil
 }
+
+func (ps *PsCommand) printProcessRecords(processes []*irodsclient_types.IRODSProcess) error {
+	if ps.processFilterFlagValues.GroupBy == flag.ProcessGroupByNone {
+		records := []commons.ProcessRecord{}
+		for _, process := range processes {
+			records = append(records, commons.NewProcessRecord(process))
+		}
+
+		return commons.PrintRecords(ps.outputFlagValues.Format, records)
+	}
+
+	records := []commons.ProcessGroupRecord{}
+	recordIndex := map[string]int{}
+	for _, process := range processes {
+		record := commons.ProcessGroupRecord{}
+		if ps.processFilterFlagValues.GroupBy == flag.ProcessGroupByUser {
+			record.ProxyUser = fmt.Sprintf("%s#%s", process.ProxyUser, process.ProxyZone)
+			record.ClientUser = fmt.Sprintf("%s#%s", process.ClientUser, process.ClientZone)
+		} else {
+			record.ClientProgram = process.ClientProgram
+		}
+
+		key := fmt.Sprintf("%s,%s,%s", record.ProxyUser, record.ClientUser, record.ClientProgram)
+		if idx, ok := recordIndex[key]; ok {
+			// existing
+			records[idx].ProcessCount++
+			continue
+		}
+
+		record.ProcessCount = 1
+		recordIndex[key] = len(records)
+		records = append(records, record)
+	}
+
+	return commons.PrintRecords(ps.outputFlagValues.Format, records)
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
 	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
 	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
 	"github.com/cyverse/gocommands/cmd/flag"
@@ -26,9 +27,9 @@ var putCmd = &cobra.Command{
 	Use:     "put [local file1] [local file2] [local dir1] ... [collection]",
 	Aliases: []string{"iput", "upload"},
 	Short:   "Upload files or directories",
-	Long:    `This uploads files or directories to the given iRODS collection.`,
+	Long:    `This uploads files or directories to the given iRODS collection. Use '-' as a source to upload data read from stdin, an existing data object is only overwritten with --force. Use --from_report to upload files recorded in a transfer report again. Use --meta, --meta_sidecar or --xattrs to add metadata to uploaded data objects in the same upload job. Interrupted multi-thread uploads of large files are resumed on the next run, except uploads redirected to resource servers, use --icat to make uploads of files of 1GB or more resumable.`,
 	RunE:    processPutCommand,
-	Args:    cobra.MinimumNArgs(1),
+	Args:    cobra.ArbitraryArgs,
 }
 
 func AddPutCommand(rootCmd *cobra.Command) {
@@ -41,14 +42,22 @@ func AddPutCommand(rootCmd *cobra.Command) {
 	flag.SetParallelTransferFlags(putCmd, true)
 	flag.SetProgressFlags(putCmd)
 	flag.SetRetryFlags(putCmd)
+	flag.SetContinueOnErrorFlags(putCmd)
 	flag.SetDifferentialTransferFlags(putCmd, true)
 	flag.SetChecksumFlags(putCmd, true)
 	flag.SetNoRootFlags(putCmd)
 	flag.SetSyncFlags(putCmd, false)
 	flag.SetEncryptionFlags(putCmd)
 	flag.SetHiddenFileFlags(putCmd)
+	flag.SetFilterFlags(putCmd)
+	flag.SetDryRunFlags(putCmd)
+	flag.SetPreserveFlags(putCmd)
+	flag.SetStreamFlags(putCmd)
 	flag.SetPostTransferFlagValues(putCmd)
 	flag.SetTransferReportFlags(putCmd)
+	flag.SetFromReportFlags(putCmd)
+	flag.SetUploadMetaFlags(putCmd)
+	flag.SetProvenanceFlags(putCmd)
 
 	rootCmd.AddCommand(putCmd)
 }
@@ -70,6 +79,7 @@ type PutCommand struct {
 	parallelTransferFlagValues     *flag.ParallelTransferFlagValues
 	progressFlagValues             *flag.ProgressFlagValues
 	retryFlagValues                *flag.RetryFlagValues
+	continueOnErrorFlagValues      *flag.ContinueOnErrorFlagValues
 	differentialTransferFlagValues *flag.DifferentialTransferFlagValues
 	checksumFlagValues             *flag.ChecksumFlagValues
 	noRootFlagValues               *flag.NoRootFlagValues
@@ -77,7 +87,14 @@ type PutCommand struct {
 	encryptionFlagValues           *flag.EncryptionFlagValues
 	postTransferFlagValues         *flag.PostTransferFlagValues
 	hiddenFileFlagValues           *flag.HiddenFileFlagValues
+	filterFlagValues               *flag.FilterFlagValues
+	dryRunFlagValues               *flag.DryRunFlagValues
+	preserveFlagValues             *flag.PreserveFlagValues
+	streamFlagValues               *flag.StreamFlagValues
 	transferReportFlagValues       *flag.TransferReportFlagValues
+	fromReportFlagValues           *flag.FromReportFlagValues
+	uploadMetaFlagValues           *flag.UploadMetaFlagValues
+	provenanceFlagValues           *flag.ProvenanceFlagValues
 
 	maxConnectionNum int
 
@@ -86,10 +103,13 @@ type PutCommand struct {
 
 	sourcePaths []string
 	targetPath  string
+	metaRecords []commons.PathMetaRecord
+	provenance  *commons.Provenance
 
-	parallelJobManager    *commons.ParallelJobManager
-	transferReportManager *commons.TransferReportManager
-	updatedPathMap        map[string]bool
+	parallelJobManager       *commons.ParallelJobManager
+	transferReportManager    *commons.TransferReportManager
+	updatedPathMap           map[string]bool
+	xattrsNotSupportedWarned bool
 }
 
 func NewPutCommand(command *cobra.Command, args []string) (*PutCommand, error) {
@@ -101,6 +121,7 @@ func NewPutCommand(command *cobra.Command, args []string) (*PutCommand, error) {
 		parallelTransferFlagValues:     flag.GetParallelTransferFlagValues(),
 		progressFlagValues:             flag.GetProgressFlagValues(),
 		retryFlagValues:                flag.GetRetryFlagValues(),
+		continueOnErrorFlagValues:      flag.GetContinueOnErrorFlagValues(),
 		differentialTransferFlagValues: flag.GetDifferentialTransferFlagValues(),
 		checksumFlagValues:             flag.GetChecksumFlagValues(),
 		noRootFlagValues:               flag.GetNoRootFlagValues(),
@@ -108,7 +129,14 @@ func NewPutCommand(command *cobra.Command, args []string) (*PutCommand, error) {
 		encryptionFlagValues:           flag.GetEncryptionFlagValues(command),
 		postTransferFlagValues:         flag.GetPostTransferFlagValues(),
 		hiddenFileFlagValues:           flag.GetHiddenFileFlagValues(),
+		filterFlagValues:               flag.GetFilterFlagValues(),
+		dryRunFlagValues:               flag.GetDryRunFlagValues(),
+		preserveFlagValues:             flag.GetPreserveFlagValues(),
+		streamFlagValues:               flag.GetStreamFlagValues(),
 		transferReportFlagValues:       flag.GetTransferReportFlagValues(command),
+		fromReportFlagValues:           flag.GetFromReportFlagValues(),
+		uploadMetaFlagValues:           flag.GetUploadMetaFlagValues(),
+		provenanceFlagValues:           flag.GetProvenanceFlagValues(),
 
 		updatedPathMap: map[string]bool{},
 	}
@@ -124,10 +152,65 @@ func NewPutCommand(command *cobra.Command, args []string) (*PutCommand, error) {
 		put.sourcePaths = args[:len(args)-1]
 	}
 
+	if len(args) == 0 && len(put.fromReportFlagValues.ReportPath) == 0 {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("requires at least 1 arg(s), only received 0"))
+	}
+
+	if len(put.fromReportFlagValues.ReportPath) > 0 {
+		if len(args) > 0 {
+			return nil, xerrors.Errorf("failed to put from report, source and target paths must not be given")
+		}
+
+		if put.syncFlagValues.Delete {
+			return nil, xerrors.Errorf("failed to put from report, deleting extra files is not supported")
+		}
+
+		if put.transferReportFlagValues.Report && !put.transferReportFlagValues.ReportToStdout && filepath.Clean(put.transferReportFlagValues.ReportPath) == filepath.Clean(put.fromReportFlagValues.ReportPath) {
+			return nil, xerrors.Errorf("failed to put from report, new transfer report must be written to a different file")
+		}
+	} else if put.fromReportFlagValues.OnlyFailed {
+		return nil, xerrors.Errorf("failed to put only failed files, transfer report must be given with --from_report")
+	}
+
 	if put.noRootFlagValues.NoRoot && len(put.sourcePaths) > 1 {
 		return nil, xerrors.Errorf("failed to put multiple source collections without creating root directory")
 	}
 
+	err := put.differentialTransferFlagValues.Validate()
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
+	// metadata
+	put.metaRecords = []commons.PathMetaRecord{}
+	for _, meta := range put.uploadMetaFlagValues.Metas {
+		record, err := commons.ParseMetaAVU(meta)
+		if err != nil {
+			return nil, commons.NewUsageError(command.CommandPath(), err)
+		}
+
+		put.metaRecords = append(put.metaRecords, record)
+	}
+
+	// provenance
+	provenance, err := newProvenance(command, put.provenanceFlagValues)
+	if err != nil {
+		return nil, err
+	}
+	put.provenance = provenance
+
+	for _, sourcePath := range put.sourcePaths {
+		if commons.IsStreamPath(sourcePath) {
+			if len(args) != 2 {
+				return nil, xerrors.Errorf("failed to put from stdin, stdin must be the only source and a target data object must be given")
+			}
+
+			if put.retryFlagValues.RetryNumber > 0 {
+				return nil, xerrors.Errorf("failed to put from stdin, retry is not supported")
+			}
+		}
+	}
+
 	return put, nil
 }
 
@@ -169,15 +252,6 @@ func (put *PutCommand) Process() error {
 		}
 	}
 
-	// handle retry
-	if put.retryFlagValues.RetryNumber > 0 && !put.retryFlagValues.RetryChild {
-		err = commons.RunWithRetry(put.retryFlagValues.RetryNumber, put.retryFlagValues.RetryIntervalSeconds)
-		if err != nil {
-			return xerrors.Errorf("failed to run with retry %d: %w", put.retryFlagValues.RetryNumber, err)
-		}
-		return nil
-	}
-
 	// Create a file system
 	put.account = commons.GetAccount()
 	put.filesystem, err = commons.GetIRODSFSClientAdvanced(put.account, put.maxConnectionNum, put.parallelTransferFlagValues.TCPBufferSize)
@@ -187,12 +261,17 @@ func (put *PutCommand) Process() error {
 	defer put.filesystem.Release()
 
 	// transfer report
-	put.transferReportManager, err = commons.NewTransferReportManager(put.transferReportFlagValues.Report, put.transferReportFlagValues.ReportPath, put.transferReportFlagValues.ReportToStdout)
+	put.transferReportManager, err = commons.NewTransferReportManager(put.transferReportFlagValues.Report, put.transferReportFlagValues.ReportPath, put.transferReportFlagValues.ReportToStdout, put.dryRunFlagValues.DryRun)
 	if err != nil {
 		return xerrors.Errorf("failed to create transfer report manager: %w", err)
 	}
 	defer put.transferReportManager.Release()
 
+	if put.provenance != nil {
+		logger.Infof("recording provenance with run ID %q", put.provenance.RunID)
+		put.transferReportManager.SetRunID(put.provenance.RunID)
+	}
+
 	// set default key for encryption
 	if len(put.encryptionFlagValues.Key) == 0 {
 		put.encryptionFlagValues.Key = put.account.Password
@@ -200,21 +279,31 @@ func (put *PutCommand) Process() error {
 
 	// parallel job manager
 	put.parallelJobManager = commons.NewParallelJobManager(put.filesystem, put.parallelTransferFlagValues.ThreadNumber, put.progressFlagValues.ShowProgress, put.progressFlagValues.ShowFullPath)
+	put.parallelJobManager.SetRetryPolicy(put.retryFlagValues.RetryPolicy)
+	put.parallelJobManager.SetRetryCallback(put.transferReportManager.GetRetryCallback(commons.TransferMethodPut))
+	put.parallelJobManager.SetContinueOnError(put.continueOnErrorFlagValues.ContinueOnError)
 	put.parallelJobManager.Start()
 
 	// run
-	if len(put.sourcePaths) >= 2 {
-		// multi-source, target must be a dir
-		err = put.ensureTargetIsDir(put.targetPath)
+	if len(put.fromReportFlagValues.ReportPath) > 0 {
+		err = put.putFromReport(put.fromReportFlagValues.ReportPath)
 		if err != nil {
-			return err
+			return xerrors.Errorf("failed to put from report %q: %w", put.fromReportFlagValues.ReportPath, err)
+		}
+	} else {
+		if len(put.sourcePaths) >= 2 {
+			// multi-source, target must be a dir
+			err = put.ensureTargetIsDir(put.targetPath)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	for _, sourcePath := range put.sourcePaths {
-		err = put.putOne(sourcePath, put.targetPath)
-		if err != nil {
-			return xerrors.Errorf("failed to put %q to %q: %w", sourcePath, put.targetPath, err)
+		for _, sourcePath := range put.sourcePaths {
+			err = put.putOne(sourcePath, put.targetPath)
+			if err != nil {
+				return xerrors.Errorf("failed to put %q to %q: %w", sourcePath, put.targetPath, err)
+			}
 		}
 	}
 
@@ -227,6 +316,10 @@ func (put *PutCommand) Process() error {
 	// delete on success
 	if put.postTransferFlagValues.DeleteOnSuccess {
 		for _, sourcePath := range put.sourcePaths {
+			if commons.IsStreamPath(sourcePath) {
+				continue
+			}
+
 			logger.Infof("deleting source %q after successful data put", sourcePath)
 
 			err := put.deleteOnSuccess(sourcePath)
@@ -272,6 +365,47 @@ func (put *PutCommand) ensureTargetIsDir(targetPath string) error {
 	return nil
 }
 
+// putFromReport puts files recorded in the transfer report again
+func (put *PutCommand) putFromReport(reportPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "PutCommand",
+		"function": "putFromReport",
+	})
+
+	reportFiles, err := commons.ReadTransferReportFiles(reportPath)
+	if err != nil {
+		return xerrors.Errorf("failed to read report file %q: %w", reportPath, err)
+	}
+
+	reportFilter := &commons.TransferReportFilter{
+		Method:       commons.TransferMethodPut,
+		OnlyFailed:   put.fromReportFlagValues.OnlyFailed,
+		IncludeNotes: put.fromReportFlagValues.IncludeNotes,
+		ExcludeNotes: put.fromReportFlagValues.ExcludeNotes,
+	}
+
+	reportFiles = reportFilter.Filter(reportFiles)
+	logger.Debugf("put %d files in the report %q", len(reportFiles), reportPath)
+
+	for _, reportFile := range reportFiles {
+		if reportFile.HasNote("directory", "stdin") || len(reportFile.DestPath) == 0 {
+			// directories are created with files in them, streams cannot be read again
+			logger.Debugf("skip %q in the report", reportFile.SourcePath)
+			continue
+		}
+
+		put.sourcePaths = append(put.sourcePaths, reportFile.SourcePath)
+
+		err = put.putOne(reportFile.SourcePath, reportFile.DestPath)
+		if err != nil {
+			return xerrors.Errorf("failed to put %q to %q: %w", reportFile.SourcePath, reportFile.DestPath, err)
+		}
+	}
+
+	return nil
+}
+
 func (put *PutCommand) requireEncryption(targetPath string, parentEncryption bool, parentEncryptionMode commons.EncryptionMode) (bool, commons.EncryptionMode) {
 	if put.encryptionFlagValues.Encryption {
 		return true, put.encryptionFlagValues.Mode
@@ -288,7 +422,7 @@ func (put *PutCommand) requireEncryption(targetPath string, parentEncryption boo
 		targetEntry, err := put.filesystem.Stat(targetPath)
 		if err != nil {
 			if irodsclient_types.IsFileNotFoundError(err) {
-				targetDir = commons.GetDir(targetEntry.Path)
+				targetDir = commons.GetDir(targetPath)
 			} else {
 				return parentEncryption, parentEncryptionMode
 			}
@@ -314,13 +448,34 @@ func (put *PutCommand) requireEncryption(targetPath string, parentEncryption boo
 	return parentEncryption, parentEncryptionMode
 }
 
+func (put *PutCommand) hasTransferStatusFile(sourcePath string, targetPath string) bool {
+	// check transfer status file
+	return commons.HasUploadTransferStatusFile(sourcePath, targetPath)
+}
+
+func (put *PutCommand) deleteTransferStatusFile(sourcePath string, targetPath string) {
+	commons.DeleteUploadTransferStatusFile(sourcePath, targetPath)
+}
+
 func (put *PutCommand) putOne(sourcePath string, targetPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "PutCommand",
+		"function": "putOne",
+	})
+
 	cwd := commons.GetCWD()
 	home := commons.GetHomeDir()
 	zone := commons.GetZone()
-	sourcePath = commons.MakeLocalPath(sourcePath)
 	targetPath = commons.MakeIRODSPath(cwd, home, zone, targetPath)
 
+	if commons.IsStreamPath(sourcePath) {
+		// stdin
+		return put.putStream(targetPath)
+	}
+
+	sourcePath = commons.MakeLocalPath(sourcePath)
+
 	sourceStat, err := os.Stat(sourcePath)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -330,13 +485,19 @@ func (put *PutCommand) putOne(sourcePath string, targetPath string) error {
 		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
 	}
 
+	filterRootPath := commons.GetFilterRootPath(sourcePath, sourceStat.IsDir() && put.noRootFlagValues.NoRoot)
+	if put.filterFlagValues.Filter.IsExcluded(filterRootPath, sourcePath, sourceStat.IsDir()) {
+		logger.Debugf("skip uploading %q, excluded by filters", sourcePath)
+		return nil
+	}
+
 	if sourceStat.IsDir() {
 		// dir
 		if !put.noRootFlagValues.NoRoot {
 			targetPath = commons.MakeTargetIRODSFilePath(put.filesystem, sourcePath, targetPath)
 		}
 
-		return put.putDir(sourceStat, sourcePath, targetPath, false, commons.EncryptionModeUnknown)
+		return put.putDir(sourceStat, sourcePath, targetPath, filterRootPath, false, commons.EncryptionModeUnknown)
 	}
 
 	// file
@@ -348,20 +509,66 @@ func (put *PutCommand) putOne(sourcePath string, targetPath string) error {
 			return xerrors.Errorf("failed to get encryption path for %q: %w", sourcePath, err)
 		}
 
-		return put.putFile(sourceStat, sourcePath, tempPath, newTargetPath, requireEncryption, encryptionMode)
+		err = put.putFile(sourceStat, sourcePath, tempPath, newTargetPath, requireEncryption, encryptionMode)
+		if err != nil {
+			return put.handleScanFailure(sourcePath, newTargetPath, false, err)
+		}
+
+		return nil
 	}
 
 	targetPath = commons.MakeTargetIRODSFilePath(put.filesystem, sourcePath, targetPath)
-	return put.putFile(sourceStat, sourcePath, "", targetPath, requireEncryption, commons.EncryptionModeUnknown)
+	err = put.putFile(sourceStat, sourcePath, "", targetPath, requireEncryption, commons.EncryptionModeUnknown)
+	if err != nil {
+		return put.handleScanFailure(sourcePath, targetPath, false, err)
+	}
+
+	return nil
 }
 
-func (put *PutCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string, tempPath string, targetPath string, requireDecryption bool, encryptionMode commons.EncryptionMode) error {
+func (put *PutCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string, tempPath string, targetPath string, requireDecryption bool, encryptionMode commons.EncryptionMode, resume bool) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
 		"struct":   "PutCommand",
 		"function": "schedulePut",
 	})
 
+	metaRecords, err := put.getMetaRecords(sourcePath)
+	if err != nil {
+		return err
+	}
+
+	if put.dryRunFlagValues.DryRun {
+		notes := []string{}
+		if resume {
+			notes = append(notes, "resume")
+		}
+
+		for _, record := range metaRecords {
+			notes = append(notes, fmt.Sprintf("meta:%s", commons.GetMetaAVUString(record)))
+		}
+
+		if put.provenance != nil {
+			notes = append(notes, "provenance")
+		}
+
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodPut,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourcePath,
+			SourceSize: sourceStat.Size(),
+			DestPath:   targetPath,
+			Notes:      notes,
+		}
+
+		put.transferReportManager.AddFile(reportFile)
+
+		logger.Debugf("skip uploading a file %q to %q, dry run", sourcePath, targetPath)
+		return nil
+	}
+
 	putTask := func(job *commons.ParallelJob) error {
 		manager := job.GetManager()
 		fs := manager.GetFilesystem()
@@ -397,14 +604,28 @@ func (put *PutCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string, te
 		}
 
 		// determine how to upload
+		// large multi-thread uploads record progress of chunks in a status file, so they can be resumed
+		// encrypted files are regenerated for every upload, so they are not resumable
+		resumable := len(tempPath) == 0 && sourceStat.Size() >= commons.ResumableUploadMinSize
+
 		if put.parallelTransferFlagValues.SingleThread || put.parallelTransferFlagValues.ThreadNumber == 1 {
+			// delete status file if exists
+			put.deleteTransferStatusFile(uploadSourcePath, targetPath)
+
 			uploadResult, uploadErr = fs.UploadFile(uploadSourcePath, targetPath, "", false, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
 			notes = append(notes, "icat", "single-thread")
+		} else if resume {
+			uploadResult, uploadErr = commons.UploadFileParallelResumable(fs, uploadSourcePath, targetPath, "", 0, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
+			notes = append(notes, "icat", "multi-thread", "resume")
 		} else if put.parallelTransferFlagValues.RedirectToResource {
 			uploadResult, uploadErr = fs.UploadFileParallelRedirectToResource(uploadSourcePath, targetPath, "", 0, false, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
 			notes = append(notes, "redirect-to-resource")
 		} else if put.parallelTransferFlagValues.Icat {
-			uploadResult, uploadErr = fs.UploadFileParallel(uploadSourcePath, targetPath, "", 0, false, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
+			if resumable {
+				uploadResult, uploadErr = commons.UploadFileParallelResumable(fs, uploadSourcePath, targetPath, "", 0, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
+			} else {
+				uploadResult, uploadErr = fs.UploadFileParallel(uploadSourcePath, targetPath, "", 0, false, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
+			}
 			notes = append(notes, "icat", "multi-thread")
 		} else {
 			// auto
@@ -412,6 +633,9 @@ func (put *PutCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string, te
 				// redirect-to-resource
 				uploadResult, uploadErr = fs.UploadFileParallelRedirectToResource(uploadSourcePath, targetPath, "", 0, false, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
 				notes = append(notes, "redirect-to-resource")
+			} else if resumable {
+				uploadResult, uploadErr = commons.UploadFileParallelResumable(fs, uploadSourcePath, targetPath, "", 0, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
+				notes = append(notes, "icat", "multi-thread")
 			} else {
 				uploadResult, uploadErr = fs.UploadFileParallel(uploadSourcePath, targetPath, "", 0, false, put.checksumFlagValues.CalculateChecksum, put.checksumFlagValues.VerifyChecksum, callbackPut)
 				notes = append(notes, "icat", "multi-thread")
@@ -423,6 +647,48 @@ func (put *PutCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string, te
 			return xerrors.Errorf("failed to upload %q to %q: %w", sourcePath, targetPath, uploadErr)
 		}
 
+		// report the source file, not the encrypted temp file, so failed files can be found by their source paths
+		if uploadSourcePath != sourcePath {
+			uploadResult.LocalPath = sourcePath
+			uploadResult.LocalSize = sourceStat.Size()
+			// checksum of the encrypted file is not the checksum of the source
+			uploadResult.LocalCheckSum = nil
+		}
+
+		// preserve
+		if put.preserveFlagValues.Preserve {
+			preserveErr := commons.PreserveDataObjectAttributes(fs, sourcePath, targetPath)
+			if preserveErr != nil {
+				if !irodsclient_types.IsAPINotSupportedError(preserveErr) {
+					job.Progress(-1, sourceStat.Size(), true)
+					return xerrors.Errorf("failed to preserve attributes of %q: %w", targetPath, preserveErr)
+				}
+
+				logger.WithError(preserveErr).Warnf("failed to preserve modify time of %q, the server does not support it", targetPath)
+			} else {
+				notes = append(notes, "preserved")
+			}
+		}
+
+		// metadata
+		metaNotes, metaErr := put.addMeta(fs, targetPath, metaRecords)
+		if metaErr != nil {
+			job.Progress(-1, sourceStat.Size(), true)
+			return metaErr
+		}
+		notes = append(notes, metaNotes...)
+
+		// provenance
+		if put.provenance != nil {
+			provenanceErr := put.provenance.Apply(fs, sourcePath, targetPath, uploadResult.CheckSumAlgorithm, uploadResult.LocalCheckSum)
+			if provenanceErr != nil {
+				job.Progress(-1, sourceStat.Size(), true)
+				return xerrors.Errorf("failed to record provenance of %q: %w", targetPath, provenanceErr)
+			}
+
+			notes = append(notes, "provenance")
+		}
+
 		err := put.transferReportManager.AddTransfer(uploadResult, commons.TransferMethodPut, uploadErr, notes)
 		if err != nil {
 			job.Progress(-1, sourceStat.Size(), true)
@@ -442,7 +708,8 @@ func (put *PutCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string, te
 	}
 
 	threadsRequired := put.computeThreadsRequired(sourceStat.Size())
-	err := put.parallelJobManager.Schedule(sourcePath, putTask, threadsRequired, progress.UnitsBytes)
+	reportedPutTask := put.transferReportManager.WrapTask(commons.TransferMethodPut, sourcePath, sourceStat.Size(), targetPath, putTask)
+	err = put.parallelJobManager.Schedule(sourcePath, reportedPutTask, threadsRequired, progress.UnitsBytes)
 	if err != nil {
 		return xerrors.Errorf("failed to schedule upload %q to %q: %w", sourcePath, targetPath, err)
 	}
@@ -452,6 +719,123 @@ func (put *PutCommand) schedulePut(sourceStat fs.FileInfo, sourcePath string, te
 	return nil
 }
 
+func (put *PutCommand) putStream(targetPath string) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "PutCommand",
+		"function": "putStream",
+	})
+
+	targetEntry, err := put.filesystem.Stat(targetPath)
+	if err != nil {
+		if !irodsclient_types.IsFileNotFoundError(err) {
+			return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
+		}
+	} else if targetEntry.IsDir() {
+		// target must be a file
+		return commons.NewNotFileError(targetPath)
+	} else if !put.forceFlagValues.Force {
+		// stdin carries the data, so we cannot ask
+		return xerrors.Errorf("failed to put from stdin to %q, the data object already exists, use --force to overwrite", targetPath)
+	}
+
+	requireEncryption, _ := put.requireEncryption(targetPath, false, commons.EncryptionModeUnknown)
+	if requireEncryption {
+		return xerrors.Errorf("failed to put from stdin to %q, encryption is not supported", targetPath)
+	}
+
+	commons.MarkPathMap(put.updatedPathMap, targetPath)
+
+	if put.dryRunFlagValues.DryRun {
+		notes := []string{"stdin"}
+		for _, record := range put.metaRecords {
+			notes = append(notes, fmt.Sprintf("meta:%s", commons.GetMetaAVUString(record)))
+		}
+
+		if put.provenance != nil {
+			notes = append(notes, "provenance")
+		}
+
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodPut,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: commons.StreamPath,
+			DestPath:   targetPath,
+			Notes:      notes,
+		}
+
+		put.transferReportManager.AddFile(reportFile)
+
+		logger.Debugf("skip uploading stdin to %q, dry run", targetPath)
+		return nil
+	}
+
+	putTask := func(job *commons.ParallelJob) error {
+		manager := job.GetManager()
+		fs := manager.GetFilesystem()
+
+		callbackPut := func(processed int64, total int64) {
+			job.Progress(processed, total, false)
+		}
+
+		// size is unknown until EOF
+		job.Progress(0, -1, false)
+
+		logger.Debugf("uploading stdin to %q", targetPath)
+
+		uploadResult, uploadErr := commons.UploadFromStream(fs, os.Stdin, targetPath, "", put.streamFlagValues.BufferSize, callbackPut)
+		if uploadErr != nil {
+			put.transferReportManager.AddTransfer(uploadResult, commons.TransferMethodPut, uploadErr, []string{"stdin", "failed"})
+
+			job.Progress(-1, -1, true)
+			return xerrors.Errorf("failed to upload stdin to %q: %w", targetPath, uploadErr)
+		}
+
+		// metadata
+		metaNotes, metaErr := put.addMeta(fs, targetPath, put.metaRecords)
+		if metaErr != nil {
+			job.Progress(-1, uploadResult.LocalSize, true)
+			return metaErr
+		}
+
+		notes := append([]string{"stdin"}, metaNotes...)
+
+		// provenance
+		if put.provenance != nil {
+			provenanceErr := put.provenance.Apply(fs, commons.StreamPath, targetPath, uploadResult.CheckSumAlgorithm, uploadResult.LocalCheckSum)
+			if provenanceErr != nil {
+				job.Progress(-1, uploadResult.LocalSize, true)
+				return xerrors.Errorf("failed to record provenance of %q: %w", targetPath, provenanceErr)
+			}
+
+			notes = append(notes, "provenance")
+		}
+
+		err := put.transferReportManager.AddTransfer(uploadResult, commons.TransferMethodPut, uploadErr, notes)
+		if err != nil {
+			job.Progress(-1, uploadResult.LocalSize, true)
+			return xerrors.Errorf("failed to add transfer report: %w", err)
+		}
+
+		logger.Debugf("uploaded stdin to %q", targetPath)
+		job.Progress(uploadResult.LocalSize, uploadResult.LocalSize, false)
+
+		job.Done()
+		return nil
+	}
+
+	err = put.parallelJobManager.Schedule(targetPath, putTask, 1, progress.UnitsBytes)
+	if err != nil {
+		return xerrors.Errorf("failed to schedule upload stdin to %q: %w", targetPath, err)
+	}
+
+	logger.Debugf("scheduled upload stdin to %q", targetPath)
+
+	return nil
+}
+
 func (put *PutCommand) putFile(sourceStat fs.FileInfo, sourcePath string, tempPath string, targetPath string, requireEncryption bool, encryptionMode commons.EncryptionMode) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
@@ -466,7 +850,7 @@ func (put *PutCommand) putFile(sourceStat fs.FileInfo, sourcePath string, tempPa
 		if irodsclient_types.IsFileNotFoundError(err) {
 			// target does not exist
 			// target must be a file with new name
-			return put.schedulePut(sourceStat, sourcePath, tempPath, targetPath, requireEncryption, encryptionMode)
+			return put.schedulePut(sourceStat, sourcePath, tempPath, targetPath, requireEncryption, encryptionMode, false)
 		}
 
 		return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
@@ -478,7 +862,10 @@ func (put *PutCommand) putFile(sourceStat fs.FileInfo, sourcePath string, tempPa
 		if put.syncFlagValues.Sync {
 			// if it is sync, remove
 			if put.forceFlagValues.Force {
-				removeErr := put.filesystem.RemoveDir(targetPath, true, true)
+				var removeErr error
+				if !put.dryRunFlagValues.DryRun {
+					removeErr = put.filesystem.RemoveDir(targetPath, true, true)
+				}
 
 				now := time.Now()
 				reportFile := &commons.TransferReportFile{
@@ -499,7 +886,10 @@ func (put *PutCommand) putFile(sourceStat fs.FileInfo, sourcePath string, tempPa
 				// ask
 				overwrite := commons.InputYN(fmt.Sprintf("overwriting a file %q, but directory exists. Overwrite?", targetPath))
 				if overwrite {
-					removeErr := put.filesystem.RemoveDir(targetPath, true, true)
+					var removeErr error
+					if !put.dryRunFlagValues.DryRun {
+						removeErr = put.filesystem.RemoveDir(targetPath, true, true)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -525,8 +915,19 @@ func (put *PutCommand) putFile(sourceStat fs.FileInfo, sourcePath string, tempPa
 		}
 	}
 
+	// check transfer status file
+	// encrypted files are regenerated for every upload, so they cannot be resumed
+	if !requireEncryption && put.hasTransferStatusFile(sourcePath, targetPath) {
+		// incomplete data object - resume uploading
+		commons.Printf("resume uploading a file %q\n", sourcePath)
+		logger.Debugf("resume uploading a file %q", sourcePath)
+
+		return put.schedulePut(sourceStat, sourcePath, tempPath, targetPath, requireEncryption, encryptionMode, true)
+	}
+
 	if put.differentialTransferFlagValues.DifferentialTransfer {
-		if put.differentialTransferFlagValues.NoHash {
+		switch put.differentialTransferFlagValues.Mode {
+		case commons.DiffModeSize:
 			if targetEntry.Size == sourceStat.Size() {
 				// skip
 				now := time.Now()
@@ -549,7 +950,33 @@ func (put *PutCommand) putFile(sourceStat fs.FileInfo, sourcePath string, tempPa
 				logger.Debugf("skip uploading a file %q to %q. The file already exists!", sourcePath, targetPath)
 				return nil
 			}
-		} else {
+		case commons.DiffModeMtime, commons.DiffModeSizeMtime:
+			if put.differentialTransferFlagValues.Mode == commons.DiffModeSizeMtime && targetEntry.Size != sourceStat.Size() {
+				break
+			}
+
+			if commons.IsModTimeUpToDate(sourceStat.ModTime(), targetEntry.ModifyTime, put.differentialTransferFlagValues.MtimeTolerance) {
+				// skip
+				now := time.Now()
+				reportFile := &commons.TransferReportFile{
+					Method:            commons.TransferMethodPut,
+					StartAt:           now,
+					EndAt:             now,
+					SourcePath:        sourcePath,
+					SourceSize:        sourceStat.Size(),
+					DestPath:          targetEntry.Path,
+					DestSize:          targetEntry.Size,
+					ChecksumAlgorithm: string(targetEntry.CheckSumAlgorithm),
+					Notes:             []string{"differential", string(put.differentialTransferFlagValues.Mode), "not older", "skip"},
+				}
+
+				put.transferReportManager.AddFile(reportFile)
+
+				commons.Printf("skip uploading a file %q to %q. The data object is up to date!\n", sourcePath, targetPath)
+				logger.Debugf("skip uploading a file %q to %q. The data object is up to date!", sourcePath, targetPath)
+				return nil
+			}
+		default:
 			if targetEntry.Size == sourceStat.Size() {
 				// compare hash
 				if len(targetEntry.CheckSum) > 0 {
@@ -614,10 +1041,120 @@ func (put *PutCommand) putFile(sourceStat fs.FileInfo, sourcePath string, tempPa
 	}
 
 	// schedule
-	return put.schedulePut(sourceStat, sourcePath, tempPath, targetPath, requireEncryption, encryptionMode)
+	return put.schedulePut(sourceStat, sourcePath, tempPath, targetPath, requireEncryption, encryptionMode, false)
+}
+
+// handleScanFailure adds a file or a directory failed before it is scheduled to the report and failures of jobs
+// it returns nil to continue with other files if continue_on_error is set, or the error otherwise
+func (put *PutCommand) handleScanFailure(sourcePath string, targetPath string, isDir bool, err error) error {
+	notes := []string{"failed", "scan"}
+	if isDir {
+		notes = append(notes, "directory")
+	}
+
+	now := time.Now()
+	reportFile := &commons.TransferReportFile{
+		Method:     commons.TransferMethodPut,
+		StartAt:    now,
+		EndAt:      now,
+		SourcePath: sourcePath,
+		DestPath:   targetPath,
+		Error:      err,
+		Notes:      notes,
+	}
+
+	put.transferReportManager.AddFile(reportFile)
+
+	return put.parallelJobManager.AddFailure(sourcePath, err)
+}
+
+func (put *PutCommand) putDir(sourceStat fs.FileInfo, sourcePath string, targetPath string, filterRootPath string, parentEncryption bool, parentEncryptionMode commons.EncryptionMode) error {
+	err := put.prepareTargetDir(sourcePath, targetPath)
+	if err != nil {
+		return put.handleScanFailure(sourcePath, targetPath, true, err)
+	}
+
+	requireEncryption, encryptionMode := put.requireEncryption(targetPath, parentEncryption, parentEncryptionMode)
+
+	// get entries
+	entries, err := os.ReadDir(sourcePath)
+	if err != nil {
+		return put.handleScanFailure(sourcePath, targetPath, true, xerrors.Errorf("failed to list a directory %q: %w", sourcePath, err))
+	}
+
+	for _, entry := range entries {
+		if put.hiddenFileFlagValues.Exclude {
+			if strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+		}
+
+		newEntryPath := commons.MakeTargetIRODSFilePath(put.filesystem, entry.Name(), targetPath)
+
+		entryPath := filepath.Join(sourcePath, entry.Name())
+
+		if put.uploadMetaFlagValues.Sidecar && commons.IsMetaSidecar(entryPath) {
+			// sidecar files are added as metadata of the file next to it
+			continue
+		}
+
+		entryStat, err := os.Stat(entryPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				err = irodsclient_types.NewFileNotFoundError(entryPath)
+			} else {
+				err = xerrors.Errorf("failed to stat %q: %w", entryPath, err)
+			}
+
+			err = put.handleScanFailure(entryPath, newEntryPath, false, err)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if put.filterFlagValues.Filter.IsExcluded(filterRootPath, entryPath, entryStat.IsDir()) {
+			continue
+		}
+
+		if entryStat.IsDir() {
+			// dir, failures under it are handled in it
+			err = put.putDir(entryStat, entryPath, newEntryPath, filterRootPath, requireEncryption, encryptionMode)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// file
+		if requireEncryption {
+			// encrypt filename
+			tempPath, newTargetPath, pathErr := put.getPathsForEncryption(entryPath, targetPath)
+			if pathErr != nil {
+				err = xerrors.Errorf("failed to get encryption path for %q: %w", entryPath, pathErr)
+			} else {
+				newEntryPath = newTargetPath
+				err = put.putFile(entryStat, entryPath, tempPath, newTargetPath, requireEncryption, encryptionMode)
+			}
+		} else {
+			err = put.putFile(entryStat, entryPath, "", newEntryPath, requireEncryption, encryptionMode)
+		}
+
+		if err != nil {
+			err = put.handleScanFailure(entryPath, newEntryPath, false, err)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
-func (put *PutCommand) putDir(sourceStat fs.FileInfo, sourcePath string, targetPath string, parentEncryption bool, parentEncryptionMode commons.EncryptionMode) error {
+// prepareTargetDir makes the target collection of the directory, or replaces a data object in the way on sync
+func (put *PutCommand) prepareTargetDir(sourcePath string, targetPath string) error {
 	commons.MarkPathMap(put.updatedPathMap, targetPath)
 
 	targetEntry, err := put.filesystem.Stat(targetPath)
@@ -625,9 +1162,11 @@ func (put *PutCommand) putDir(sourceStat fs.FileInfo, sourcePath string, targetP
 		if irodsclient_types.IsFileNotFoundError(err) {
 			// target does not exist
 			// target must be a directory with new name
-			err = put.filesystem.MakeDir(targetPath, true)
-			if err != nil {
-				return xerrors.Errorf("failed to make a collection %q: %w", targetPath, err)
+			if !put.dryRunFlagValues.DryRun {
+				err = put.filesystem.MakeDir(targetPath, true)
+				if err != nil {
+					return xerrors.Errorf("failed to make a collection %q: %w", targetPath, err)
+				}
 			}
 
 			now := time.Now()
@@ -650,7 +1189,10 @@ func (put *PutCommand) putDir(sourceStat fs.FileInfo, sourcePath string, targetP
 			if put.syncFlagValues.Sync {
 				// if it is sync, remove
 				if put.forceFlagValues.Force {
-					removeErr := put.filesystem.RemoveFile(targetPath, true)
+					var removeErr error
+					if !put.dryRunFlagValues.DryRun {
+						removeErr = put.filesystem.RemoveFile(targetPath, true)
+					}
 
 					now := time.Now()
 					reportFile := &commons.TransferReportFile{
@@ -671,7 +1213,10 @@ func (put *PutCommand) putDir(sourceStat fs.FileInfo, sourcePath string, targetP
 					// ask
 					overwrite := commons.InputYN(fmt.Sprintf("overwriting a directory %q, but file exists. Overwrite?", targetPath))
 					if overwrite {
-						removeErr := put.filesystem.RemoveFile(targetPath, true)
+						var removeErr error
+						if !put.dryRunFlagValues.DryRun {
+							removeErr = put.filesystem.RemoveFile(targetPath, true)
+						}
 
 						now := time.Now()
 						reportFile := &commons.TransferReportFile{
@@ -698,63 +1243,150 @@ func (put *PutCommand) putDir(sourceStat fs.FileInfo, sourcePath string, targetP
 		}
 	}
 
-	requireEncryption, encryptionMode := put.requireEncryption(targetPath, parentEncryption, parentEncryptionMode)
+	// metadata
+	if put.uploadMetaFlagValues.Sidecar {
+		err = put.addCollectionMeta(sourcePath, targetPath)
+		if err != nil {
+			return err
+		}
+	}
 
-	// get entries
-	entries, err := os.ReadDir(sourcePath)
-	if err != nil {
-		return xerrors.Errorf("failed to list a directory %q: %w", sourcePath, err)
+	return nil
+}
+
+// newProvenance returns provenance to record from flags, nil if it is not requested
+func newProvenance(command *cobra.Command, provenanceFlagValues *flag.ProvenanceFlagValues) (*commons.Provenance, error) {
+	if !provenanceFlagValues.Provenance {
+		return nil, nil
 	}
 
-	for _, entry := range entries {
-		if put.hiddenFileFlagValues.Exclude {
-			if strings.HasPrefix(entry.Name(), ".") {
-				continue
-			}
+	fields := []commons.ProvenanceField{}
+	for _, fieldString := range provenanceFlagValues.Fields {
+		field, err := commons.GetProvenanceField(fieldString)
+		if err != nil {
+			return nil, commons.NewUsageError(command.CommandPath(), err)
 		}
 
-		newEntryPath := commons.MakeTargetIRODSFilePath(put.filesystem, entry.Name(), targetPath)
+		fields = append(fields, field)
+	}
 
-		entryPath := filepath.Join(sourcePath, entry.Name())
+	provenance, err := commons.NewProvenance(fields)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create provenance: %w", err)
+	}
 
-		entryStat, err := os.Stat(entryPath)
+	return provenance, nil
+}
+
+// getMetaRecords returns metadata to add to the data object uploaded from the source file
+func (put *PutCommand) getMetaRecords(sourcePath string) ([]commons.PathMetaRecord, error) {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "PutCommand",
+		"function": "getMetaRecords",
+	})
+
+	records := []commons.PathMetaRecord{}
+	records = append(records, put.metaRecords...)
+
+	if put.uploadMetaFlagValues.Xattrs {
+		xattrRecords, err := commons.ReadUserXattrs(sourcePath)
 		if err != nil {
-			if os.IsNotExist(err) {
-				return irodsclient_types.NewFileNotFoundError(entryPath)
+			if !commons.IsXattrNotSupportedError(err) {
+				return nil, xerrors.Errorf("failed to read extended attributes of %q: %w", sourcePath, err)
 			}
 
-			return xerrors.Errorf("failed to stat %q: %w", entryPath, err)
+			// warn only once, the same filesystem is likely used for all files
+			if !put.xattrsNotSupportedWarned {
+				logger.WithError(err).Warnf("failed to read extended attributes of %q, the local filesystem does not support them", sourcePath)
+				put.xattrsNotSupportedWarned = true
+			}
+		} else {
+			records = append(records, xattrRecords...)
 		}
+	}
 
-		if entryStat.IsDir() {
-			// dir
-			err = put.putDir(entryStat, entryPath, newEntryPath, requireEncryption, encryptionMode)
+	if put.uploadMetaFlagValues.Sidecar {
+		sidecarPath := commons.FindMetaSidecar(sourcePath)
+		if len(sidecarPath) > 0 {
+			sidecarRecords, err := commons.ReadMetaSidecar(sidecarPath)
 			if err != nil {
-				return err
+				return nil, xerrors.Errorf("failed to read metadata sidecar of %q: %w", sourcePath, err)
 			}
-		} else {
-			// file
-			if requireEncryption {
-				// encrypt filename
-				tempPath, newTargetPath, err := put.getPathsForEncryption(entryPath, targetPath)
-				if err != nil {
-					return xerrors.Errorf("failed to get encryption path for %q: %w", entryPath, err)
-				}
 
-				err = put.putFile(entryStat, entryPath, tempPath, newTargetPath, requireEncryption, encryptionMode)
-				if err != nil {
-					return err
-				}
-			} else {
-				err = put.putFile(entryStat, entryPath, "", newEntryPath, requireEncryption, encryptionMode)
-				if err != nil {
-					return err
-				}
+			records = append(records, sidecarRecords...)
+		}
+	}
+
+	return records, nil
+}
+
+// addMeta adds metadata to the uploaded data object, returns notes for transfer report
+func (put *PutCommand) addMeta(fs *irodsclient_fs.FileSystem, targetPath string, records []commons.PathMetaRecord) ([]string, error) {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "PutCommand",
+		"function": "addMeta",
+	})
+
+	notes := []string{}
+	for _, record := range records {
+		logger.Debugf("add metadata to %q (attr %q, value %q, unit %q)", targetPath, record.Attribute, record.Value, record.Unit)
+
+		err := fs.AddMetadata(targetPath, record.Attribute, record.Value, record.Unit)
+		if err != nil {
+			if irodsclient_types.GetIRODSErrorCode(err) != irodsclient_common.CATALOG_ALREADY_HAS_ITEM_BY_THAT_NAME {
+				return nil, xerrors.Errorf("failed to add metadata to %q (attr %q, value %q, unit %q): %w", targetPath, record.Attribute, record.Value, record.Unit, err)
 			}
+
+			// overwritten data object already has the same metadata
+			logger.Debugf("skip adding existing metadata to %q", targetPath)
 		}
+
+		notes = append(notes, fmt.Sprintf("meta:%s", commons.GetMetaAVUString(record)))
 	}
 
-	return nil
+	return notes, nil
+}
+
+// addCollectionMeta adds metadata in the sidecar file of the directory to the collection
+func (put *PutCommand) addCollectionMeta(sourcePath string, targetPath string) error {
+	sidecarPath := commons.FindCollectionMetaSidecar(sourcePath)
+	if len(sidecarPath) == 0 {
+		return nil
+	}
+
+	records, err := commons.ReadMetaSidecar(sidecarPath)
+	if err != nil {
+		return xerrors.Errorf("failed to read metadata sidecar of %q: %w", sourcePath, err)
+	}
+
+	notes := []string{"directory"}
+	var metaErr error
+	if put.dryRunFlagValues.DryRun {
+		for _, record := range records {
+			notes = append(notes, fmt.Sprintf("meta:%s", commons.GetMetaAVUString(record)))
+		}
+	} else {
+		var metaNotes []string
+		metaNotes, metaErr = put.addMeta(put.filesystem, targetPath, records)
+		notes = append(notes, metaNotes...)
+	}
+
+	now := time.Now()
+	reportFile := &commons.TransferReportFile{
+		Method:     commons.TransferMethodPut,
+		StartAt:    now,
+		EndAt:      now,
+		SourcePath: sidecarPath,
+		DestPath:   targetPath,
+		Error:      metaErr,
+		Notes:      notes,
+	}
+
+	put.transferReportManager.AddFile(reportFile)
+
+	return metaErr
 }
 
 func (put *PutCommand) computeThreadsRequired(size int64) int {
@@ -780,6 +1412,20 @@ func (put *PutCommand) deleteOnSuccess(sourcePath string) error {
 		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
 	}
 
+	if put.dryRunFlagValues.DryRun {
+		now := time.Now()
+		reportFile := &commons.TransferReportFile{
+			Method:     commons.TransferMethodDelete,
+			StartAt:    now,
+			EndAt:      now,
+			SourcePath: sourcePath,
+			Notes:      []string{"delete_on_success"},
+		}
+
+		put.transferReportManager.AddFile(reportFile)
+		return nil
+	}
+
 	if sourceStat.IsDir() {
 		return os.RemoveAll(sourcePath)
 	}
@@ -793,10 +1439,11 @@ func (put *PutCommand) deleteExtra(targetPath string) error {
 	zone := commons.GetZone()
 	targetPath = commons.MakeIRODSPath(cwd, home, zone, targetPath)
 
-	return put.deleteExtraInternal(targetPath)
+	// paths under the target are compared with the source paths relative to the transfer root
+	return put.deleteExtraInternal(targetPath, targetPath)
 }
 
-func (put *PutCommand) deleteExtraInternal(targetPath string) error {
+func (put *PutCommand) deleteExtraInternal(targetPath string, filterRootPath string) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
 		"struct":   "PutCommand",
@@ -805,6 +1452,11 @@ func (put *PutCommand) deleteExtraInternal(targetPath string) error {
 
 	targetEntry, err := put.filesystem.Stat(targetPath)
 	if err != nil {
+		if put.dryRunFlagValues.DryRun && irodsclient_types.IsFileNotFoundError(err) {
+			// the target is not created in dry run, nothing to delete
+			return nil
+		}
+
 		return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
 	}
 
@@ -814,7 +1466,10 @@ func (put *PutCommand) deleteExtraInternal(targetPath string) error {
 			// extra file
 			logger.Debugf("removing an extra data object %q", targetPath)
 
-			removeErr := put.filesystem.RemoveFile(targetPath, true)
+			var removeErr error
+			if !put.dryRunFlagValues.DryRun {
+				removeErr = put.filesystem.RemoveFile(targetPath, true)
+			}
 
 			now := time.Now()
 			reportFile := &commons.TransferReportFile{
@@ -841,7 +1496,10 @@ func (put *PutCommand) deleteExtraInternal(targetPath string) error {
 		// extra dir
 		logger.Debugf("removing an extra collection %q", targetPath)
 
-		removeErr := put.filesystem.RemoveDir(targetPath, true, true)
+		var removeErr error
+		if !put.dryRunFlagValues.DryRun {
+			removeErr = put.filesystem.RemoveDir(targetPath, true, true)
+		}
 
 		now := time.Now()
 		reportFile := &commons.TransferReportFile{
@@ -868,7 +1526,12 @@ func (put *PutCommand) deleteExtraInternal(targetPath string) error {
 
 		for _, entry := range entries {
 			newTargetPath := path.Join(targetPath, entry.Name)
-			err = put.deleteExtraInternal(newTargetPath)
+			if put.filterFlagValues.Filter.IsExcluded(filterRootPath, newTargetPath, entry.IsDir()) {
+				// excluded paths are not transferred, keep them
+				continue
+			}
+
+			err = put.deleteExtraInternal(newTargetPath, filterRootPath)
 			if err != nil {
 				return err
 			}
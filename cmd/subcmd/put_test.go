@@ -0,0 +1,62 @@
+package subcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPut(t *testing.T) {
+	t.Run("test AddCollectionMetaDryRun", testPutAddCollectionMetaDryRun)
+}
+
+func testPutAddCollectionMetaDryRun(t *testing.T) {
+	commons.InitTerminalOutput()
+	defer commons.InitTerminalOutput()
+
+	buffer := &bytes.Buffer{}
+	commons.SetTerminalOutput(buffer)
+
+	sourcePath := t.TempDir()
+	sidecarPath := commons.GetCollectionMetaSidecarPath(sourcePath, commons.OutputFormatCSV)
+	err := os.WriteFile(sidecarPath, []byte("study,ABC\nlength,10,m\n"), 0644)
+	assert.NoError(t, err)
+
+	reportPath := filepath.Join(t.TempDir(), "report.json")
+	reportManager, err := commons.NewTransferReportManager(true, reportPath, false, true)
+	assert.NoError(t, err)
+
+	put := &PutCommand{
+		dryRunFlagValues:      &flag.DryRunFlagValues{DryRun: true},
+		transferReportManager: reportManager,
+	}
+
+	// nothing is added in dry run, so no filesystem is needed
+	err = put.addCollectionMeta(sourcePath, "/zone/home/user/dir")
+	assert.NoError(t, err)
+
+	// directories without sidecar files are skipped
+	err = put.addCollectionMeta(t.TempDir(), "/zone/home/user/other")
+	assert.NoError(t, err)
+	reportManager.Release()
+
+	files, err := commons.ReadTransferReportFiles(reportPath)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.Equal(t, sidecarPath, files[0].SourcePath)
+	assert.Equal(t, "/zone/home/user/dir", files[0].DestPath)
+	assert.True(t, files[0].HasNote("directory"))
+	assert.True(t, files[0].HasNote("meta:study=ABC"))
+	assert.True(t, files[0].HasNote("meta:length=10=m"))
+
+	assert.Contains(t, buffer.String(), "/zone/home/user/dir")
+
+	// the sidecar file is not uploaded as a data object
+	assert.True(t, commons.IsMetaSidecar(sidecarPath))
+}
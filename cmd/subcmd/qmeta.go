@@ -0,0 +1,132 @@
+package subcmd
+
+import (
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var qmetaCmd = &cobra.Command{
+	Use:     "qmeta [attribute] [operator] [value] [and attribute operator value ...]",
+	Aliases: []string{"q_meta", "query_meta", "query_metadata", "search_meta"},
+	Short:   "Find entries by metadata",
+	Long: `This finds data objects, collections, users or resources having metadata that satisfy all the given conditions. Operators are =, like, <, > and between, e.g., 'study = ABC and quality > 30 and date between 20240101 20241231'. Values are compared as numbers if both are numbers.
+Matching paths are printed one per line, so the output can be given to 'get --files_from'.`,
+	RunE: processQmetaCommand,
+	Args: cobra.MinimumNArgs(3),
+}
+
+func AddQmetaCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(qmetaCmd, true)
+
+	flag.SetMetaQueryFlags(qmetaCmd)
+
+	rootCmd.AddCommand(qmetaCmd)
+}
+
+func processQmetaCommand(command *cobra.Command, args []string) error {
+	qMeta, err := NewQMetaCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return qMeta.Process()
+}
+
+type QMetaCommand struct {
+	command *cobra.Command
+
+	metaQueryFlagValues *flag.MetaQueryFlagValues
+	outputFlagValues    *flag.OutputFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	conditions []commons.MetaQueryCondition
+}
+
+func NewQMetaCommand(command *cobra.Command, args []string) (*QMetaCommand, error) {
+	qMeta := &QMetaCommand{
+		command: command,
+
+		metaQueryFlagValues: flag.GetMetaQueryFlagValues(),
+		outputFlagValues:    flag.GetOutputFlagValues(),
+	}
+
+	// conditions
+	conditions, err := commons.ParseMetaQueryConditions(args)
+	if err != nil {
+		return nil, commons.NewUsageError(command.CommandPath(), err)
+	}
+
+	qMeta.conditions = conditions
+
+	return qMeta, nil
+}
+
+func (qMeta *QMetaCommand) Process() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "QMetaCommand",
+		"function": "Process",
+	})
+
+	cont, err := flag.ProcessCommonFlags(qMeta.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	qMeta.account = commons.GetAccount()
+	qMeta.filesystem, err = commons.GetIRODSFSClient(qMeta.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer qMeta.filesystem.Release()
+
+	connection, err := qMeta.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer qMeta.filesystem.ReturnMetadataConnection(connection)
+
+	logger.Debugf("querying %s by %d metadata conditions", qMeta.metaQueryFlagValues.Target, len(qMeta.conditions))
+
+	paths, err := commons.QueryMeta(connection, qMeta.metaQueryFlagValues.Target, qMeta.conditions)
+	if err != nil {
+		return xerrors.Errorf("failed to query metadata: %w", err)
+	}
+
+	if qMeta.outputFlagValues.Format.IsStructured() {
+		records := []commons.MetaQueryRecord{}
+		for _, p := range paths {
+			records = append(records, commons.MetaQueryRecord{
+				Type: string(qMeta.metaQueryFlagValues.Target),
+				Path: p,
+			})
+		}
+
+		return commons.PrintRecords(qMeta.outputFlagValues.Format, records)
+	}
+
+	for _, p := range paths {
+		commons.Printf("%s\n", p)
+	}
+
+	return nil
+}
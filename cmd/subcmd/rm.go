@@ -25,6 +25,7 @@ func AddRmCommand(rootCmd *cobra.Command) {
 
 	flag.SetForceFlags(rmCmd, false)
 	flag.SetRecursiveFlags(rmCmd, false)
+	flag.SetDryRunFlags(rmCmd)
 
 	rootCmd.AddCommand(rmCmd)
 }
@@ -43,6 +44,7 @@ type RmCommand struct {
 
 	recursiveFlagValues *flag.RecursiveFlagValues
 	forceFlagValues     *flag.ForceFlagValues
+	dryRunFlagValues    *flag.DryRunFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -56,6 +58,7 @@ func NewRmCommand(command *cobra.Command, args []string) (*RmCommand, error) {
 
 		recursiveFlagValues: flag.GetRecursiveFlagValues(),
 		forceFlagValues:     flag.GetForceFlagValues(),
+		dryRunFlagValues:    flag.GetDryRunFlagValues(),
 	}
 
 	// path
@@ -112,6 +115,10 @@ func (rm *RmCommand) removeOne(targetPath string) error {
 
 	targetEntry, err := rm.filesystem.Stat(targetPath)
 	if err != nil {
+		if rm.dryRunFlagValues.DryRun {
+			return xerrors.Errorf("failed to stat %q: %w", targetPath, err)
+		}
+
 		logger.Debugf("failed to find a data object %q, but trying to remove", targetPath)
 		err = rm.filesystem.RemoveFile(targetPath, rm.forceFlagValues.Force)
 		if err != nil {
@@ -126,6 +133,22 @@ func (rm *RmCommand) removeOne(targetPath string) error {
 			return xerrors.Errorf("cannot remove a collection, recurse is not set")
 		}
 
+		if rm.dryRunFlagValues.DryRun {
+			entries, err := getRemovalEntries(rm.filesystem.List, targetEntry)
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				if entry.IsDir() {
+					commons.Printf("[DRY RUN] DELETE\t%q\t(collection)\n", entry.Path)
+				} else {
+					commons.Printf("[DRY RUN] DELETE\t%q\n", entry.Path)
+				}
+			}
+			return nil
+		}
+
 		logger.Debugf("removing a collection %q", targetPath)
 		err = rm.filesystem.RemoveDir(targetPath, rm.recursiveFlagValues.Recursive, rm.forceFlagValues.Force)
 		if err != nil {
@@ -136,6 +159,11 @@ func (rm *RmCommand) removeOne(targetPath string) error {
 	}
 
 	// file
+	if rm.dryRunFlagValues.DryRun {
+		commons.Printf("[DRY RUN] DELETE\t%q\n", targetPath)
+		return nil
+	}
+
 	logger.Debugf("removing a data object %q", targetPath)
 	err = rm.filesystem.RemoveFile(targetPath, rm.forceFlagValues.Force)
 	if err != nil {
@@ -144,3 +172,29 @@ func (rm *RmCommand) removeOne(targetPath string) error {
 
 	return nil
 }
+
+// getRemovalEntries returns all data-objects and collections removed with the collection
+// entries in a collection come before the collection, the collection itself is the last
+func getRemovalEntries(list func(string) ([]*irodsclient_fs.Entry, error), collectionEntry *irodsclient_fs.Entry) ([]*irodsclient_fs.Entry, error) {
+	entries, err := list(collectionEntry.Path)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list a collection %q: %w", collectionEntry.Path, err)
+	}
+
+	removalEntries := []*irodsclient_fs.Entry{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			subEntries, err := getRemovalEntries(list, entry)
+			if err != nil {
+				return nil, err
+			}
+
+			removalEntries = append(removalEntries, subEntries...)
+			continue
+		}
+
+		removalEntries = append(removalEntries, entry)
+	}
+
+	return append(removalEntries, collectionEntry), nil
+}
@@ -0,0 +1,57 @@
+package subcmd
+
+import (
+	"testing"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestRm(t *testing.T) {
+	t.Run("test GetRemovalEntries", testGetRemovalEntries)
+}
+
+func testGetRemovalEntries(t *testing.T) {
+	dir := func(p string) *irodsclient_fs.Entry {
+		return &irodsclient_fs.Entry{Path: p, Type: irodsclient_fs.DirectoryEntry}
+	}
+	file := func(p string) *irodsclient_fs.Entry {
+		return &irodsclient_fs.Entry{Path: p, Type: irodsclient_fs.FileEntry}
+	}
+
+	tree := map[string][]*irodsclient_fs.Entry{
+		"/zone/home/user/data":       {file("/zone/home/user/data/a.txt"), dir("/zone/home/user/data/sub"), dir("/zone/home/user/data/empty")},
+		"/zone/home/user/data/sub":   {file("/zone/home/user/data/sub/b.txt"), file("/zone/home/user/data/sub/c.txt")},
+		"/zone/home/user/data/empty": {},
+	}
+
+	list := func(p string) ([]*irodsclient_fs.Entry, error) {
+		entries, ok := tree[p]
+		if !ok {
+			return nil, xerrors.Errorf("collection %q not found", p)
+		}
+		return entries, nil
+	}
+
+	entries, err := getRemovalEntries(list, dir("/zone/home/user/data"))
+	assert.NoError(t, err)
+
+	paths := []string{}
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+
+	// all entries are listed, not only the top level, and collections come after their content
+	assert.Equal(t, []string{
+		"/zone/home/user/data/a.txt",
+		"/zone/home/user/data/sub/b.txt",
+		"/zone/home/user/data/sub/c.txt",
+		"/zone/home/user/data/sub",
+		"/zone/home/user/data/empty",
+		"/zone/home/user/data",
+	}, paths)
+
+	_, err = getRemovalEntries(list, dir("/zone/home/user/missing"))
+	assert.Error(t, err)
+}
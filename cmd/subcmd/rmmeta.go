@@ -16,7 +16,7 @@ var rmmetaCmd = &cobra.Command{
 	Use:     "rmmeta [AVU ID|attribute name] ...",
 	Aliases: []string{"rm_meta", "remove_meta", "rm_metadata", "remove_metadata", "delete_meta", "delete_metadata"},
 	Short:   "Remove metadatas for the user",
-	Long:    `This removes metadata of the given collection, data object, user, or a resource.`,
+	Long:    `This removes metadata of the given collection, data object, user, or a resource. Metadata can be selected by value and unit with --value and --unit, and by patterns with --wildcard. Use -r to remove them from all data objects and collections under the collection too.`,
 	RunE:    processRmmetaCommand,
 	Args:    cobra.MinimumNArgs(1),
 }
@@ -26,6 +26,8 @@ func AddRmmetaCommand(rootCmd *cobra.Command) {
 	flag.SetCommonFlags(rmmetaCmd, true)
 
 	flag.SetTargetObjectFlags(rmmetaCmd)
+	flag.SetMetaMatchFlags(rmmetaCmd)
+	flag.SetRecursiveFlags(rmmetaCmd, false)
 
 	rootCmd.AddCommand(rmmetaCmd)
 }
@@ -43,6 +45,8 @@ type RmMetaCommand struct {
 	command *cobra.Command
 
 	targetObjectFlagValues *flag.TargetObjectFlagValues
+	metaMatchFlagValues    *flag.MetaMatchFlagValues
+	recursiveFlagValues    *flag.RecursiveFlagValues
 
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
@@ -55,6 +59,12 @@ func NewRmMetaCommand(command *cobra.Command, args []string) (*RmMetaCommand, er
 		command: command,
 
 		targetObjectFlagValues: flag.GetTargetObjectFlagValues(command),
+		metaMatchFlagValues:    flag.GetMetaMatchFlagValues(command),
+		recursiveFlagValues:    flag.GetRecursiveFlagValues(),
+	}
+
+	if rmMeta.recursiveFlagValues.Recursive && !rmMeta.targetObjectFlagValues.PathUpdated {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("recursive is only supported for a path"))
 	}
 
 	// path
@@ -88,6 +98,10 @@ func (rmMeta *RmMetaCommand) Process() error {
 	defer rmMeta.filesystem.Release()
 
 	// remove
+	if rmMeta.requireMatch() {
+		return rmMeta.removeMatched()
+	}
+
 	for _, avuidString := range rmMeta.avuIDs {
 		err = rmMeta.removeOne(avuidString)
 		if err != nil {
@@ -98,6 +112,86 @@ func (rmMeta *RmMetaCommand) Process() error {
 	return nil
 }
 
+// requireMatch returns true if metadata must be listed to find ones to remove
+func (rmMeta *RmMetaCommand) requireMatch() bool {
+	return rmMeta.recursiveFlagValues.Recursive || rmMeta.metaMatchFlagValues.ValueUpdated || rmMeta.metaMatchFlagValues.UnitUpdated || rmMeta.metaMatchFlagValues.Wildcard
+}
+
+func (rmMeta *RmMetaCommand) removeMatched() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "RmMetaCommand",
+		"function": "removeMatched",
+	})
+
+	targets, err := getMetaTargets(rmMeta.filesystem, rmMeta.targetObjectFlagValues, rmMeta.recursiveFlagValues.Recursive)
+	if err != nil {
+		return err
+	}
+
+	avuIDs := map[int64]bool{}
+	conditions := []commons.MetaMatchCondition{}
+	for _, avuidString := range rmMeta.avuIDs {
+		if commons.IsDigitsOnly(avuidString) {
+			// avu ID
+			avuid, err := strconv.ParseInt(avuidString, 10, 64)
+			if err != nil {
+				return xerrors.Errorf("failed to parse AVUID: %w", err)
+			}
+
+			avuIDs[avuid] = true
+			continue
+		}
+
+		// possibly name
+		conditions = append(conditions, commons.MetaMatchCondition{
+			Attribute: avuidString,
+			Value:     rmMeta.metaMatchFlagValues.Value,
+			HasValue:  rmMeta.metaMatchFlagValues.ValueUpdated,
+			Unit:      rmMeta.metaMatchFlagValues.Unit,
+			HasUnit:   rmMeta.metaMatchFlagValues.UnitUpdated,
+			Wildcard:  rmMeta.metaMatchFlagValues.Wildcard,
+		})
+	}
+
+	connection, err := rmMeta.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer rmMeta.filesystem.ReturnMetadataConnection(connection)
+
+	for _, target := range targets {
+		metas, err := commons.ListMetadata(rmMeta.filesystem, target)
+		if err != nil {
+			return err
+		}
+
+		for _, meta := range metas {
+			matched := avuIDs[meta.AVUID]
+			for _, condition := range conditions {
+				if matched {
+					break
+				}
+
+				matched = condition.Match(meta)
+			}
+
+			if !matched {
+				continue
+			}
+
+			logger.Debugf("remove metadata %d from %q (attr %q, value %q, unit %q)", meta.AVUID, target.Name, meta.Name, meta.Value, meta.Units)
+
+			err = commons.RemoveMetadata(connection, target, meta.AVUID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (rmMeta *RmMetaCommand) removeOne(avuidString string) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "subcmd",
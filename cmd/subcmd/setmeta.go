@@ -0,0 +1,158 @@
+package subcmd
+
+import (
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var setmetaCmd = &cobra.Command{
+	Use:     "setmeta [attribute name] [attribute value] [attribute unit (optional)]",
+	Aliases: []string{"set_meta", "set_metadata"},
+	Short:   "Set a metadata",
+	Long:    `This replaces all values of the attribute with the given value in a single request, or adds a metadata if the attribute does not exist. It works for the given collection, data object, user, or a resource. Use -r to set it to all data objects and collections under the collection too.`,
+	RunE:    processSetmetaCommand,
+	Args:    cobra.RangeArgs(2, 3),
+}
+
+func AddSetmetaCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(setmetaCmd, true)
+
+	flag.SetTargetObjectFlags(setmetaCmd)
+	flag.SetRecursiveFlags(setmetaCmd, false)
+
+	rootCmd.AddCommand(setmetaCmd)
+}
+
+func processSetmetaCommand(command *cobra.Command, args []string) error {
+	setMeta, err := NewSetMetaCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return setMeta.Process()
+}
+
+type SetMetaCommand struct {
+	command *cobra.Command
+
+	targetObjectFlagValues *flag.TargetObjectFlagValues
+	recursiveFlagValues    *flag.RecursiveFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	attribute string
+	value     string
+	unit      string
+}
+
+func NewSetMetaCommand(command *cobra.Command, args []string) (*SetMetaCommand, error) {
+	setMeta := &SetMetaCommand{
+		command: command,
+
+		targetObjectFlagValues: flag.GetTargetObjectFlagValues(command),
+		recursiveFlagValues:    flag.GetRecursiveFlagValues(),
+	}
+
+	if setMeta.recursiveFlagValues.Recursive && !setMeta.targetObjectFlagValues.PathUpdated {
+		return nil, commons.NewUsageError(command.CommandPath(), xerrors.Errorf("recursive is only supported for a path"))
+	}
+
+	// get avu
+	setMeta.attribute = args[0]
+	setMeta.value = args[1]
+	setMeta.unit = ""
+	if len(args) >= 3 {
+		setMeta.unit = args[2]
+	}
+
+	return setMeta, nil
+}
+
+func (setMeta *SetMetaCommand) Process() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "SetMetaCommand",
+		"function": "Process",
+	})
+
+	cont, err := flag.ProcessCommonFlags(setMeta.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// Create a file system
+	setMeta.account = commons.GetAccount()
+	setMeta.filesystem, err = commons.GetIRODSFSClient(setMeta.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer setMeta.filesystem.Release()
+
+	targets, err := getMetaTargets(setMeta.filesystem, setMeta.targetObjectFlagValues, setMeta.recursiveFlagValues.Recursive)
+	if err != nil {
+		return err
+	}
+
+	connection, err := setMeta.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer setMeta.filesystem.ReturnMetadataConnection(connection)
+
+	// set meta
+	for _, target := range targets {
+		logger.Debugf("set metadata of %q (attr %q, value %q, unit %q)", target.Name, setMeta.attribute, setMeta.value, setMeta.unit)
+
+		err = commons.SetMetadata(connection, target, setMeta.attribute, setMeta.value, setMeta.unit)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getMetaTargets returns targets to modify metadata of from target object flags
+func getMetaTargets(filesystem *irodsclient_fs.FileSystem, targetObjectFlagValues *flag.TargetObjectFlagValues, recursive bool) ([]commons.MetaTarget, error) {
+	if targetObjectFlagValues.PathUpdated {
+		cwd := commons.GetCWD()
+		home := commons.GetHomeDir()
+		zone := commons.GetZone()
+		targetPath := commons.MakeIRODSPath(cwd, home, zone, targetObjectFlagValues.Path)
+
+		targets, err := commons.ListMetaTargets(filesystem, targetPath, recursive)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get targets of path %q: %w", targetPath, err)
+		}
+
+		return targets, nil
+	} else if targetObjectFlagValues.UserUpdated {
+		return []commons.MetaTarget{
+			{ItemType: irodsclient_types.IRODSUserMetaItemType, Name: targetObjectFlagValues.User},
+		}, nil
+	} else if targetObjectFlagValues.ResourceUpdated {
+		return []commons.MetaTarget{
+			{ItemType: irodsclient_types.IRODSResourceMetaItemType, Name: targetObjectFlagValues.Resource},
+		}, nil
+	}
+
+	// nothing updated
+	return nil, xerrors.Errorf("path, user, or resource must be given")
+}
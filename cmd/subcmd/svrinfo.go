@@ -41,6 +41,8 @@ func processSvrinfoCommand(command *cobra.Command, args []string) error {
 type SvrInfoCommand struct {
 	command *cobra.Command
 
+	outputFlagValues *flag.OutputFlagValues
+
 	account    *irodsclient_types.IRODSAccount
 	filesystem *irodsclient_fs.FileSystem
 }
@@ -48,6 +50,8 @@ type SvrInfoCommand struct {
 func NewSvrInfoCommand(command *cobra.Command, args []string) (*SvrInfoCommand, error) {
 	svrInfo := &SvrInfoCommand{
 		command: command,
+
+		outputFlagValues: flag.GetOutputFlagValues(),
 	}
 
 	return svrInfo, nil
@@ -100,6 +104,16 @@ func (svrInfo *SvrInfoCommand) displayInfo() error {
 		return xerrors.Errorf("failed to get server version: %w", err)
 	}
 
+	if svrInfo.outputFlagValues.Format.IsStructured() {
+		record := commons.ServerInfoRecord{
+			ReleaseVersion: ver.ReleaseVersion,
+			APIVersion:     ver.APIVersion,
+			Zone:           svrInfo.account.ClientZone,
+		}
+
+		return commons.PrintRecord(svrInfo.outputFlagValues.Format, record)
+	}
+
 	t := table.NewWriter()
 	t.SetOutputMirror(os.Stdout)
 
@@ -15,7 +15,7 @@ var syncCmd = &cobra.Command{
 	Use:     "sync i:[collection] [local dir] or sync [local dir] i:[collection]",
 	Aliases: []string{"isync"},
 	Short:   "Sync local directory with iRODS collection",
-	Long:    `This synchronizes a local directory with the given iRODS collection.`,
+	Long:    `This synchronizes a local directory with the given iRODS collection. Use --provenance to record provenance metadata on every uploaded data object.`,
 	RunE:    processSyncCommand,
 	Args:    cobra.MinimumNArgs(2),
 }
@@ -29,9 +29,14 @@ func AddSyncCommand(rootCmd *cobra.Command) {
 	flag.SetForceFlags(syncCmd, true)
 	flag.SetProgressFlags(syncCmd)
 	flag.SetRetryFlags(syncCmd)
+	flag.SetContinueOnErrorFlags(syncCmd)
 	flag.SetDifferentialTransferFlags(syncCmd, false)
 	flag.SetNoRootFlags(syncCmd)
 	flag.SetSyncFlags(syncCmd, true)
+	flag.SetFilterFlags(syncCmd)
+	flag.SetPreserveFlags(syncCmd)
+	flag.SetDryRunFlags(syncCmd)
+	flag.SetProvenanceFlags(syncCmd)
 
 	rootCmd.AddCommand(syncCmd)
 }
@@ -48,8 +53,10 @@ func processSyncCommand(command *cobra.Command, args []string) error {
 type SyncCommand struct {
 	command *cobra.Command
 
-	retryFlagValues *flag.RetryFlagValues
-	syncFlagValues  *flag.SyncFlagValues
+	retryFlagValues      *flag.RetryFlagValues
+	syncFlagValues       *flag.SyncFlagValues
+	preserveFlagValues   *flag.PreserveFlagValues
+	provenanceFlagValues *flag.ProvenanceFlagValues
 
 	sourcePaths []string
 	targetPath  string
@@ -59,8 +66,10 @@ func NewSyncCommand(command *cobra.Command, args []string) (*SyncCommand, error)
 	sync := &SyncCommand{
 		command: command,
 
-		retryFlagValues: flag.GetRetryFlagValues(),
-		syncFlagValues:  flag.GetSyncFlagValues(),
+		retryFlagValues:      flag.GetRetryFlagValues(),
+		syncFlagValues:       flag.GetSyncFlagValues(),
+		preserveFlagValues:   flag.GetPreserveFlagValues(),
+		provenanceFlagValues: flag.GetProvenanceFlagValues(),
 	}
 
 	// path
@@ -86,15 +95,6 @@ func (sync *SyncCommand) Process() error {
 		return xerrors.Errorf("failed to input missing fields: %w", err)
 	}
 
-	// handle retry
-	if sync.retryFlagValues.RetryNumber > 0 && !sync.retryFlagValues.RetryChild {
-		err = commons.RunWithRetry(sync.retryFlagValues.RetryNumber, sync.retryFlagValues.RetryIntervalSeconds)
-		if err != nil {
-			return xerrors.Errorf("failed to run with retry %d: %w", sync.retryFlagValues.RetryNumber, err)
-		}
-		return nil
-	}
-
 	localSourcePaths := []string{}
 	irodsSourcePaths := []string{}
 
@@ -142,6 +142,10 @@ func (sync *SyncCommand) syncLocal(targetPath string) error {
 }
 
 func (sync *SyncCommand) syncIRODS(targetPath string) error {
+	if sync.provenanceFlagValues.Provenance {
+		return xerrors.Errorf("failed to sync from iRODS, provenance is only recorded when uploading")
+	}
+
 	if strings.HasPrefix(targetPath, "i:") {
 		// iRODS to iRODS
 		err := sync.syncIRODSToIRODS()
@@ -185,15 +189,7 @@ func (sync *SyncCommand) getNewCommandArgs() ([]string, error) {
 	newArgs = append(newArgs, "--sync")
 	newArgs = append(newArgs, osArgs[commandIdx+1:]...)
 
-	// filter out retry flag
-	newArgsWoRetryFlag := []string{}
-	for _, arg := range newArgs {
-		if arg != "--retry_child" {
-			newArgsWoRetryFlag = append(newArgsWoRetryFlag, arg)
-		}
-	}
-
-	return newArgsWoRetryFlag, nil
+	return newArgs, nil
 }
 
 func (sync *SyncCommand) syncLocalToIRODS() error {
@@ -205,10 +201,14 @@ func (sync *SyncCommand) syncLocalToIRODS() error {
 
 	newArgs, err := sync.getNewCommandArgs()
 	if err != nil {
-		return xerrors.Errorf("failed to get new command args for retry: %w", err)
+		return xerrors.Errorf("failed to get new command args: %w", err)
 	}
 
 	if sync.syncFlagValues.BulkUpload {
+		if sync.preserveFlagValues.Preserve {
+			return xerrors.Errorf("failed to sync with bulk upload, preserving attributes is not supported")
+		}
+
 		// run bput
 		logger.Debugf("run bput with args: %v", newArgs)
 		bputCmd.ParseFlags(newArgs)
@@ -232,7 +232,11 @@ func (sync *SyncCommand) syncIRODSToIRODS() error {
 
 	newArgs, err := sync.getNewCommandArgs()
 	if err != nil {
-		return xerrors.Errorf("failed to get new command args for retry: %w", err)
+		return xerrors.Errorf("failed to get new command args: %w", err)
+	}
+
+	if sync.preserveFlagValues.Preserve {
+		return xerrors.Errorf("failed to sync between iRODS collections, preserving attributes is not supported")
 	}
 
 	// run cp
@@ -251,7 +255,7 @@ func (sync *SyncCommand) syncIRODSToLocal() error {
 
 	newArgs, err := sync.getNewCommandArgs()
 	if err != nil {
-		return xerrors.Errorf("failed to get new command args for retry: %w", err)
+		return xerrors.Errorf("failed to get new command args: %w", err)
 	}
 
 	// run get
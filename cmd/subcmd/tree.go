@@ -0,0 +1,373 @@
+package subcmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_irodsfs "github.com/cyverse/go-irodsclient/irods/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/dustin/go-humanize"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"golang.org/x/xerrors"
+)
+
+var treeCmd = &cobra.Command{
+	Use:     "tree [collection1] [collection2] ...",
+	Aliases: []string{"itree"},
+	Short:   "Display iRODS collection hierarchy",
+	Long:    `This displays data objects and collections in iRODS collections as a tree.`,
+	RunE:    processTreeCommand,
+	Args:    cobra.ArbitraryArgs,
+}
+
+func AddTreeCommand(rootCmd *cobra.Command) {
+	// attach common flags
+	flag.SetCommonFlags(treeCmd, false)
+
+	flag.SetListFlags(treeCmd)
+	flag.SetTreeFlags(treeCmd)
+	flag.SetTicketAccessFlags(treeCmd)
+	flag.SetDecryptionFlags(treeCmd)
+	flag.SetHiddenFileFlags(treeCmd)
+
+	rootCmd.AddCommand(treeCmd)
+}
+
+func processTreeCommand(command *cobra.Command, args []string) error {
+	tree, err := NewTreeCommand(command, args)
+	if err != nil {
+		return err
+	}
+
+	return tree.Process()
+}
+
+type TreeCommand struct {
+	command *cobra.Command
+
+	ticketAccessFlagValues *flag.TicketAccessFlagValues
+	listFlagValues         *flag.ListFlagValues
+	treeFlagValues         *flag.TreeFlagValues
+	decryptionFlagValues   *flag.DecryptionFlagValues
+	hiddenFileFlagValues   *flag.HiddenFileFlagValues
+
+	account    *irodsclient_types.IRODSAccount
+	filesystem *irodsclient_fs.FileSystem
+
+	sourcePaths []string
+
+	collectionCount int
+	dataObjectCount int
+}
+
+func NewTreeCommand(command *cobra.Command, args []string) (*TreeCommand, error) {
+	tree := &TreeCommand{
+		command: command,
+
+		ticketAccessFlagValues: flag.GetTicketAccessFlagValues(),
+		listFlagValues:         flag.GetListFlagValues(),
+		treeFlagValues:         flag.GetTreeFlagValues(),
+		decryptionFlagValues:   flag.GetDecryptionFlagValues(command),
+		hiddenFileFlagValues:   flag.GetHiddenFileFlagValues(),
+	}
+
+	// path
+	tree.sourcePaths = args[:]
+
+	if len(args) == 0 {
+		tree.sourcePaths = []string{"."}
+	}
+
+	return tree, nil
+}
+
+func (tree *TreeCommand) Process() error {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "TreeCommand",
+		"function": "Process",
+	})
+
+	cont, err := flag.ProcessCommonFlags(tree.command)
+	if err != nil {
+		return xerrors.Errorf("failed to process common flags: %w", err)
+	}
+
+	if !cont {
+		return nil
+	}
+
+	// handle local flags
+	_, err = commons.InputMissingFields()
+	if err != nil {
+		return xerrors.Errorf("failed to input missing fields: %w", err)
+	}
+
+	// config
+	appConfig := commons.GetConfig()
+	syncAccount := false
+	if len(tree.ticketAccessFlagValues.Name) > 0 {
+		logger.Debugf("use ticket %q", tree.ticketAccessFlagValues.Name)
+		appConfig.Ticket = tree.ticketAccessFlagValues.Name
+		syncAccount = true
+	}
+
+	if syncAccount {
+		err := commons.SyncAccount()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create a file system
+	tree.account = commons.GetAccount()
+	tree.filesystem, err = commons.GetIRODSFSClient(tree.account)
+	if err != nil {
+		return xerrors.Errorf("failed to get iRODS FS Client: %w", err)
+	}
+	defer tree.filesystem.Release()
+
+	// set default key for decryption
+	if len(tree.decryptionFlagValues.Key) == 0 {
+		tree.decryptionFlagValues.Key = tree.account.Password
+	}
+
+	// run
+	for _, sourcePath := range tree.sourcePaths {
+		err = tree.treeOne(sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to display tree of path %q: %w", sourcePath, err)
+		}
+	}
+
+	commons.Printf("\n%d collections, %d data objects\n", tree.collectionCount, tree.dataObjectCount)
+
+	return nil
+}
+
+func (tree *TreeCommand) requireDecryption(sourcePath string) bool {
+	if tree.decryptionFlagValues.NoDecryption {
+		return false
+	}
+
+	if !tree.decryptionFlagValues.Decryption {
+		return false
+	}
+
+	mode := commons.DetectEncryptionMode(sourcePath)
+	return mode != commons.EncryptionModeUnknown
+}
+
+func (tree *TreeCommand) getEncryptionManagerForDecryption(mode commons.EncryptionMode) *commons.EncryptionManager {
+	manager := commons.NewEncryptionManager(mode)
+
+	switch mode {
+	case commons.EncryptionModeWinSCP, commons.EncryptionModePGP:
+		manager.SetKey([]byte(tree.decryptionFlagValues.Key))
+	case commons.EncryptionModeSSH:
+		manager.SetPublicPrivateKey(tree.decryptionFlagValues.PrivateKeyPath)
+	}
+
+	return manager
+}
+
+func (tree *TreeCommand) treeOne(sourcePath string) error {
+	cwd := commons.GetCWD()
+	home := commons.GetHomeDir()
+	zone := commons.GetZone()
+	sourcePath = commons.MakeIRODSPath(cwd, home, zone, sourcePath)
+
+	sourceEntry, err := tree.filesystem.Stat(sourcePath)
+	if err != nil {
+		if !irodsclient_types.IsFileNotFoundError(err) {
+			return xerrors.Errorf("failed to find data-object/collection %q: %w", sourcePath, err)
+		}
+
+		return xerrors.Errorf("failed to stat %q: %w", sourcePath, err)
+	}
+
+	connection, err := tree.filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer tree.filesystem.ReturnMetadataConnection(connection)
+
+	if sourceEntry.IsDir() {
+		// collection
+		collection, err := irodsclient_irodsfs.GetCollection(connection, sourcePath)
+		if err != nil {
+			return xerrors.Errorf("failed to get collection %q: %w", sourcePath, err)
+		}
+
+		list := func(collection *irodsclient_types.IRODSCollection) ([]*irodsclient_types.IRODSCollection, []*irodsclient_types.IRODSDataObject, error) {
+			colls, err := irodsclient_irodsfs.ListSubCollections(connection, collection.Path)
+			if err != nil {
+				return nil, nil, xerrors.Errorf("failed to list sub-collections in %q: %w", collection.Path, err)
+			}
+
+			objs, err := irodsclient_irodsfs.ListDataObjects(connection, collection)
+			if err != nil {
+				return nil, nil, xerrors.Errorf("failed to list data-objects in %q: %w", collection.Path, err)
+			}
+
+			return colls, objs, nil
+		}
+
+		commons.Printf("%s\n", collection.Path)
+		return tree.printCollection(list, collection, "", 1)
+	}
+
+	// data object
+	entry, err := irodsclient_irodsfs.GetDataObjectWithoutCollection(connection, sourcePath)
+	if err != nil {
+		return xerrors.Errorf("failed to get data-object %q: %w", sourcePath, err)
+	}
+
+	tree.dataObjectCount++
+	commons.Printf("%s\n", tree.getDataObjectLabel(entry))
+
+	return nil
+}
+
+// printCollection prints entries in the collection, and descends into sub-collections until the depth reaches the max depth
+// list returns sub-collections and data objects in a collection
+func (tree *TreeCommand) printCollection(list func(*irodsclient_types.IRODSCollection) ([]*irodsclient_types.IRODSCollection, []*irodsclient_types.IRODSDataObject, error), collection *irodsclient_types.IRODSCollection, prefix string, depth int) error {
+	colls, objs, err := list(collection)
+	if err != nil {
+		return err
+	}
+
+	// filter out hidden files
+	filteredColls := tree.filterHiddenCollections(colls)
+	filteredObjs := tree.filterHiddenDataObjects(objs)
+
+	sort.SliceStable(filteredObjs, getDataObjectSortFunction(filteredObjs, tree.listFlagValues.SortOrder, tree.listFlagValues.SortReverse))
+	sort.SliceStable(filteredColls, getCollectionSortFunction(filteredColls, tree.listFlagValues.SortOrder, tree.listFlagValues.SortReverse))
+
+	// data objects come first, same as ls
+	remaining := len(filteredObjs) + len(filteredColls)
+
+	for _, obj := range filteredObjs {
+		remaining--
+		tree.dataObjectCount++
+		commons.Printf("%s%s%s\n", prefix, tree.getBranch(remaining == 0), tree.getDataObjectLabel(obj))
+	}
+
+	for _, coll := range filteredColls {
+		remaining--
+		tree.collectionCount++
+		commons.Printf("%s%s%s\n", prefix, tree.getBranch(remaining == 0), coll.Name)
+
+		if tree.treeFlagValues.Depth > 0 && depth >= tree.treeFlagValues.Depth {
+			// reached max depth
+			continue
+		}
+
+		childPrefix := prefix + "│   "
+		if remaining == 0 {
+			childPrefix = prefix + "    "
+		}
+
+		err = tree.printCollection(list, coll, childPrefix, depth+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tree *TreeCommand) getBranch(last bool) string {
+	if last {
+		return "└── "
+	}
+	return "├── "
+}
+
+func (tree *TreeCommand) getDataObjectLabel(entry *irodsclient_types.IRODSDataObject) string {
+	logger := log.WithFields(log.Fields{
+		"package":  "subcmd",
+		"struct":   "TreeCommand",
+		"function": "getDataObjectLabel",
+	})
+
+	label := entry.Name
+
+	if tree.requireDecryption(entry.Path) {
+		// need to decrypt
+		encryptionMode := commons.DetectEncryptionMode(entry.Name)
+		if encryptionMode != commons.EncryptionModeUnknown {
+			encryptManager := tree.getEncryptionManagerForDecryption(encryptionMode)
+
+			decryptedFilename, err := encryptManager.DecryptFilename(entry.Name)
+			if err != nil {
+				logger.Debugf("%+v", err)
+				label = fmt.Sprintf("%s\t(decryption_failed)", label)
+			} else {
+				label = fmt.Sprintf("%s\t(encrypted: %q)", label, decryptedFilename)
+			}
+		}
+	}
+
+	if tree.listFlagValues.Format == commons.ListFormatNormal || len(entry.Replicas) == 0 {
+		return label
+	}
+
+	size := fmt.Sprintf("%v", entry.Size)
+	if tree.listFlagValues.HumanReadableSizes {
+		size = humanize.Bytes(uint64(entry.Size))
+	}
+
+	modTime := commons.MakeDateTimeString(getDataObjectModifyTime(entry))
+	owner := entry.Replicas[0].Owner
+
+	if tree.listFlagValues.Format == commons.ListFormatVeryLong {
+		checksum := ""
+		if entry.Replicas[0].Checksum != nil {
+			checksum = entry.Replicas[0].Checksum.IRODSChecksumString
+		}
+
+		return fmt.Sprintf("%s\t[%s  %s  %s  %d replicas  %s]", label, owner, size, modTime, len(entry.Replicas), checksum)
+	}
+
+	return fmt.Sprintf("%s\t[%s  %s  %s]", label, owner, size, modTime)
+}
+
+func (tree *TreeCommand) filterHiddenCollections(entries []*irodsclient_types.IRODSCollection) []*irodsclient_types.IRODSCollection {
+	if !tree.hiddenFileFlagValues.Exclude {
+		return entries
+	}
+
+	filteredEntries := []*irodsclient_types.IRODSCollection{}
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name, ".") {
+			// not hidden
+			filteredEntries = append(filteredEntries, entry)
+		}
+	}
+
+	return filteredEntries
+}
+
+func (tree *TreeCommand) filterHiddenDataObjects(entries []*irodsclient_types.IRODSDataObject) []*irodsclient_types.IRODSDataObject {
+	if !tree.hiddenFileFlagValues.Exclude {
+		return entries
+	}
+
+	filteredEntries := []*irodsclient_types.IRODSDataObject{}
+
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name, ".") {
+			// not hidden
+			filteredEntries = append(filteredEntries, entry)
+		}
+	}
+
+	return filteredEntries
+}
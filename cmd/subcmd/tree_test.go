@@ -0,0 +1,142 @@
+package subcmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/cyverse/gocommands/cmd/flag"
+	"github.com/cyverse/gocommands/commons"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestTree(t *testing.T) {
+	t.Run("test PrintCollectionDepth", testPrintCollectionDepth)
+}
+
+func testPrintCollectionDepth(t *testing.T) {
+	coll := func(p string) *irodsclient_types.IRODSCollection {
+		return &irodsclient_types.IRODSCollection{Path: p, Name: p[strings.LastIndex(p, "/")+1:]}
+	}
+	obj := func(p string) *irodsclient_types.IRODSDataObject {
+		return &irodsclient_types.IRODSDataObject{Path: p, Name: p[strings.LastIndex(p, "/")+1:]}
+	}
+
+	colls := map[string][]*irodsclient_types.IRODSCollection{
+		"/data":          {coll("/data/sub"), coll("/data/empty")},
+		"/data/sub":      {coll("/data/sub/deep")},
+		"/data/sub/deep": {},
+		"/data/empty":    {},
+	}
+	objs := map[string][]*irodsclient_types.IRODSDataObject{
+		"/data":          {obj("/data/a.txt")},
+		"/data/sub":      {obj("/data/sub/b.txt")},
+		"/data/sub/deep": {obj("/data/sub/deep/c.txt")},
+	}
+
+	list := func(collection *irodsclient_types.IRODSCollection) ([]*irodsclient_types.IRODSCollection, []*irodsclient_types.IRODSDataObject, error) {
+		subColls, ok := colls[collection.Path]
+		if !ok {
+			return nil, nil, xerrors.Errorf("collection %q not found", collection.Path)
+		}
+		return subColls, objs[collection.Path], nil
+	}
+
+	testCases := []struct {
+		name            string
+		depth           int
+		expected        []string
+		collectionCount int
+		dataObjectCount int
+	}{
+		{
+			name:  "unlimited",
+			depth: 0,
+			expected: []string{
+				"├── a.txt",
+				"├── empty",
+				"└── sub",
+				"    ├── b.txt",
+				"    └── deep",
+				"        └── c.txt",
+			},
+			collectionCount: 3,
+			dataObjectCount: 3,
+		},
+		{
+			name:  "depth 1 lists only the collection",
+			depth: 1,
+			expected: []string{
+				"├── a.txt",
+				"├── empty",
+				"└── sub",
+			},
+			collectionCount: 2,
+			dataObjectCount: 1,
+		},
+		{
+			name:  "depth 2 descends one level",
+			depth: 2,
+			expected: []string{
+				"├── a.txt",
+				"├── empty",
+				"└── sub",
+				"    ├── b.txt",
+				"    └── deep",
+			},
+			collectionCount: 3,
+			dataObjectCount: 2,
+		},
+		{
+			name:  "depth deeper than the tree",
+			depth: 5,
+			expected: []string{
+				"├── a.txt",
+				"├── empty",
+				"└── sub",
+				"    ├── b.txt",
+				"    └── deep",
+				"        └── c.txt",
+			},
+			collectionCount: 3,
+			dataObjectCount: 3,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			commons.InitTerminalOutput()
+			defer commons.InitTerminalOutput()
+
+			buffer := &bytes.Buffer{}
+			commons.SetTerminalOutput(buffer)
+
+			tree := &TreeCommand{
+				listFlagValues:       &flag.ListFlagValues{Format: commons.ListFormatNormal, SortOrder: commons.ListSortOrderName},
+				treeFlagValues:       &flag.TreeFlagValues{Depth: testCase.depth},
+				decryptionFlagValues: &flag.DecryptionFlagValues{},
+				hiddenFileFlagValues: &flag.HiddenFileFlagValues{},
+			}
+
+			err := tree.printCollection(list, coll("/data"), "", 1)
+			assert.NoError(t, err)
+
+			assert.Equal(t, strings.Join(testCase.expected, "\n")+"\n", buffer.String())
+			assert.Equal(t, testCase.collectionCount, tree.collectionCount)
+			assert.Equal(t, testCase.dataObjectCount, tree.dataObjectCount)
+		})
+	}
+
+	// listing errors are returned
+	tree := &TreeCommand{
+		listFlagValues:       &flag.ListFlagValues{SortOrder: commons.ListSortOrderName},
+		treeFlagValues:       &flag.TreeFlagValues{},
+		decryptionFlagValues: &flag.DecryptionFlagValues{},
+		hiddenFileFlagValues: &flag.HiddenFileFlagValues{},
+	}
+
+	err := tree.printCollection(list, coll("/missing"), "", 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	irodsclient_irodsfs "github.com/cyverse/go-irodsclient/irods/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"golang.org/x/xerrors"
+)
+
+// GetAccessLevel returns IRODSAccessLevelType from string, null, read, write or own
+func GetAccessLevel(level string) (irodsclient_types.IRODSAccessLevelType, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "null":
+		return irodsclient_types.IRODSAccessLevelNull, nil
+	case "read", string(irodsclient_types.IRODSAccessLevelReadObject):
+		return irodsclient_types.IRODSAccessLevelReadObject, nil
+	case "write", "modify", string(irodsclient_types.IRODSAccessLevelModifyObject):
+		return irodsclient_types.IRODSAccessLevelModifyObject, nil
+	case "own":
+		return irodsclient_types.IRODSAccessLevelOwner, nil
+	default:
+		return irodsclient_types.IRODSAccessLevelNull, xerrors.Errorf("unknown access level %q, must be one of null, read, write or own", level)
+	}
+}
+
+// SplitUserZone splits "user#zone" into user and zone
+// zone is empty if not given, then the server uses the local zone
+func SplitUserZone(name string) (string, string) {
+	user, zone, _ := strings.Cut(name, "#")
+	return user, zone
+}
+
+// GetAccessString returns a string of the access, e.g., "user#zone:own", groups are prefixed with "g:"
+func GetAccessString(access *irodsclient_types.IRODSAccess) string {
+	prefix := ""
+	if access.UserType == irodsclient_types.IRODSUserRodsGroup {
+		prefix = "g:"
+	}
+
+	return fmt.Sprintf("%s%s#%s:%s", prefix, access.UserName, access.UserZone, access.AccessLevel.ChmodString())
+}
+
+// ChangeAccess changes access of the user or the group to the data object or the collection at the path
+// it does not change access of entries under the collection, callers change them one by one to run them as parallel jobs with progress
+func ChangeAccess(conn *irodsclient_conn.IRODSConnection, irodsPath string, isDir bool, access irodsclient_types.IRODSAccessLevelType, user string, zone string) error {
+	var err error
+	if isDir {
+		err = irodsclient_irodsfs.ChangeCollectionAccess(conn, irodsPath, access, user, zone, false, false)
+	} else {
+		err = irodsclient_irodsfs.ChangeDataObjectAccess(conn, irodsPath, access, user, zone, false)
+	}
+
+	if err != nil {
+		return xerrors.Errorf("failed to change access of %q to %q for %q: %w", irodsPath, access.ChmodString(), user, err)
+	}
+
+	return nil
+}
+
+// SetAccessInheritance turns on or off access inheritance of the collection at the path
+func SetAccessInheritance(conn *irodsclient_conn.IRODSConnection, irodsPath string, inherit bool) error {
+	err := irodsclient_irodsfs.SetAccessInherit(conn, irodsPath, inherit, false, false)
+	if err != nil {
+		return xerrors.Errorf("failed to set access inheritance of %q to %t: %w", irodsPath, inherit, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package commons
+
+import (
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACL(t *testing.T) {
+	t.Run("test GetAccessLevel", testGetAccessLevel)
+	t.Run("test SplitUserZone", testSplitUserZone)
+	t.Run("test GetAccessString", testGetAccessString)
+}
+
+func testGetAccessLevel(t *testing.T) {
+	levels := map[string]irodsclient_types.IRODSAccessLevelType{
+		"null":          irodsclient_types.IRODSAccessLevelNull,
+		"read":          irodsclient_types.IRODSAccessLevelReadObject,
+		"write":         irodsclient_types.IRODSAccessLevelModifyObject,
+		"modify_object": irodsclient_types.IRODSAccessLevelModifyObject,
+		"OWN":           irodsclient_types.IRODSAccessLevelOwner,
+	}
+
+	for input, expected := range levels {
+		level, err := GetAccessLevel(input)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, level)
+	}
+
+	_, err := GetAccessLevel("curate")
+	assert.Error(t, err)
+
+	_, err = GetAccessLevel("")
+	assert.Error(t, err)
+}
+
+func testSplitUserZone(t *testing.T) {
+	user, zone := SplitUserZone("alice#tempZone")
+	assert.Equal(t, "alice", user)
+	assert.Equal(t, "tempZone", zone)
+
+	user, zone = SplitUserZone("alice")
+	assert.Equal(t, "alice", user)
+	assert.Empty(t, zone)
+}
+
+func testGetAccessString(t *testing.T) {
+	access := &irodsclient_types.IRODSAccess{
+		UserName:    "alice",
+		UserZone:    "tempZone",
+		UserType:    irodsclient_types.IRODSUserRodsUser,
+		AccessLevel: irodsclient_types.IRODSAccessLevelOwner,
+	}
+	assert.Equal(t, "alice#tempZone:own", GetAccessString(access))
+
+	access = &irodsclient_types.IRODSAccess{
+		UserName:    "lab",
+		UserZone:    "tempZone",
+		UserType:    irodsclient_types.IRODSUserRodsGroup,
+		AccessLevel: irodsclient_types.IRODSAccessLevelReadObject,
+	}
+	assert.Equal(t, "g:lab#tempZone:read", GetAccessString(access))
+}
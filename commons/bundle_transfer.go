@@ -187,6 +187,10 @@ type BundleTransferManager struct {
 	progressWriter          progress.Writer
 	progressTrackers        map[string]*progress.Tracker
 	progressTrackerCallback ProgressTrackerCallback
+	retryPolicy             *RetryPolicy
+	continueOnError         bool
+	provenance              *Provenance
+	failures                []*JobFailure
 	lastError               error
 	mutex                   sync.RWMutex
 
@@ -228,6 +232,10 @@ func NewBundleTransferManager(fs *irodsclient_fs.FileSystem, transferReportManag
 		progressWriter:          nil,
 		progressTrackers:        map[string]*progress.Tracker{},
 		progressTrackerCallback: nil,
+		retryPolicy:             nil,
+		continueOnError:         false,
+		provenance:              nil,
+		failures:                []*JobFailure{},
 		lastError:               nil,
 		mutex:                   sync.RWMutex{},
 		scheduleWait:            sync.WaitGroup{},
@@ -258,6 +266,70 @@ func (manager *BundleTransferManager) GetFilesystem() *irodsclient_fs.FileSystem
 	return manager.filesystem
 }
 
+// GetLocalBundleRootPath returns the local directory that target paths are relative to
+func (manager *BundleTransferManager) GetLocalBundleRootPath() string {
+	return manager.localBundleRootPath
+}
+
+// SetRetryPolicy sets how failed bundle tasks are retried
+func (manager *BundleTransferManager) SetRetryPolicy(policy *RetryPolicy) {
+	manager.retryPolicy = policy
+}
+
+// SetContinueOnError sets whether to keep processing other bundles when a bundle fails
+// errors are collected for all files in failed bundles and returned at the end
+func (manager *BundleTransferManager) SetContinueOnError(continueOnError bool) {
+	manager.continueOnError = continueOnError
+}
+
+// SetProvenance sets provenance to record on every uploaded data object
+func (manager *BundleTransferManager) SetProvenance(provenance *Provenance) {
+	manager.provenance = provenance
+}
+
+// AddFailure adds a failure found before a file is scheduled, e.g., while walking directories
+// it returns nil if it continues on error, or the error otherwise
+func (manager *BundleTransferManager) AddFailure(path string, err error) error {
+	if !manager.continueOnError {
+		return err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.failures = append(manager.failures, &JobFailure{
+		Path:  path,
+		Error: err,
+	})
+
+	return nil
+}
+
+// markBundleError marks the bundle failed
+func (manager *BundleTransferManager) markBundleError(bundle *Bundle, taskName string, err error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if manager.continueOnError {
+		if bundle.LastError != nil {
+			// already failed in other task
+			return
+		}
+
+		for _, entry := range bundle.Entries {
+			manager.failures = append(manager.failures, &JobFailure{
+				Path:  entry.LocalPath,
+				Error: err,
+			})
+		}
+	} else {
+		manager.lastError = err
+	}
+
+	bundle.LastError = err
+	bundle.LastErrorTaskName = taskName
+}
+
 func (manager *BundleTransferManager) getNextBundleIndex() int64 {
 	idx := manager.nextBundleIndex
 	manager.nextBundleIndex++
@@ -367,6 +439,10 @@ func (manager *BundleTransferManager) Wait() error {
 		return manager.lastError
 	}
 
+	if len(manager.failures) > 0 {
+		return NewJobFailuresError(manager.failures)
+	}
+
 	if manager.bundlesDoneCounter != manager.bundlesScheduledCounter {
 		return xerrors.Errorf("bundles '%d/%d' were not completed!", manager.bundlesDoneCounter, manager.bundlesScheduledCounter)
 	}
@@ -383,7 +459,7 @@ func (manager *BundleTransferManager) CleanUpBundles() {
 
 	logger.Debugf("clearing bundle files in %q", manager.irodsTempDirPath)
 
-	err := CleanUpOldIRODSBundles(manager.filesystem, manager.irodsTempDirPath, true, true)
+	err := CleanUpOldIRODSBundles(manager.filesystem, manager.irodsTempDirPath, true, true, false)
 	logger.WithError(err).Warnf("failed to clear up staging directory %q", manager.irodsTempDirPath)
 }
 
@@ -522,21 +598,15 @@ func (manager *BundleTransferManager) Start() {
 			cont := true
 
 			manager.mutex.RLock()
-			if manager.lastError != nil {
+			if manager.lastError != nil || bundle.LastError != nil {
 				cont = false
 			}
 			manager.mutex.RUnlock()
 
 			if cont && len(bundle.Entries) > 0 {
-				err := manager.processBundleTar(bundle)
+				err := manager.runBundleTask(bundle, BundleTaskNameTar, manager.processBundleTar)
 				if err != nil {
-					// mark error
-					manager.mutex.Lock()
-					manager.lastError = err
-					manager.mutex.Unlock()
-
-					bundle.LastError = err
-					bundle.LastErrorTaskName = BundleTaskNameTar
+					manager.markBundleError(bundle, BundleTaskNameTar, err)
 
 					logger.Error(err)
 					// don't stop here
@@ -560,21 +630,15 @@ func (manager *BundleTransferManager) Start() {
 				cont := true
 
 				manager.mutex.RLock()
-				if manager.lastError != nil {
+				if manager.lastError != nil || bundle.LastError != nil {
 					cont = false
 				}
 				manager.mutex.RUnlock()
 
 				if cont && len(bundle.Entries) > 0 {
-					err := manager.processBundleUpload(bundle)
+					err := manager.runBundleTask(bundle, BundleTaskNameUpload, manager.processBundleUpload)
 					if err != nil {
-						// mark error
-						manager.mutex.Lock()
-						manager.lastError = err
-						manager.mutex.Unlock()
-
-						bundle.LastError = err
-						bundle.LastErrorTaskName = BundleTaskNameUpload
+						manager.markBundleError(bundle, BundleTaskNameUpload, err)
 
 						logger.Error(err)
 						// don't stop here
@@ -610,21 +674,15 @@ func (manager *BundleTransferManager) Start() {
 			cont := true
 
 			manager.mutex.RLock()
-			if manager.lastError != nil {
+			if manager.lastError != nil || bundle.LastError != nil {
 				cont = false
 			}
 			manager.mutex.RUnlock()
 
 			if cont && len(bundle.Entries) > 0 {
-				err := manager.processBundleRemoveFilesAndMakeDirs(bundle)
+				err := manager.runBundleTask(bundle, BundleTaskNameRemoveFilesAndMakeDirs, manager.processBundleRemoveFilesAndMakeDirs)
 				if err != nil {
-					// mark error
-					manager.mutex.Lock()
-					manager.lastError = err
-					manager.mutex.Unlock()
-
-					bundle.LastError = err
-					bundle.LastErrorTaskName = BundleTaskNameRemoveFilesAndMakeDirs
+					manager.markBundleError(bundle, BundleTaskNameRemoveFilesAndMakeDirs, err)
 
 					logger.Error(err)
 					// don't stop here
@@ -659,21 +717,15 @@ func (manager *BundleTransferManager) Start() {
 						cont := true
 
 						manager.mutex.RLock()
-						if manager.lastError != nil {
+						if manager.lastError != nil || bundle1.LastError != nil {
 							cont = false
 						}
 						manager.mutex.RUnlock()
 
 						if cont && len(bundle1.Entries) > 0 {
-							err := manager.processBundleExtract(bundle1)
+							err := manager.runBundleTask(bundle1, BundleTaskNameExtract, manager.processBundleExtract)
 							if err != nil {
-								// mark error
-								manager.mutex.Lock()
-								manager.lastError = err
-								manager.mutex.Unlock()
-
-								bundle1.LastError = err
-								bundle1.LastErrorTaskName = BundleTaskNameExtract
+								manager.markBundleError(bundle1, BundleTaskNameExtract, err)
 
 								logger.Error(err)
 								// don't stop here
@@ -708,21 +760,15 @@ func (manager *BundleTransferManager) Start() {
 						cont := true
 
 						manager.mutex.RLock()
-						if manager.lastError != nil {
+						if manager.lastError != nil || bundle2.LastError != nil {
 							cont = false
 						}
 						manager.mutex.RUnlock()
 
 						if cont && len(bundle2.Entries) > 0 {
-							err := manager.processBundleExtract(bundle2)
+							err := manager.runBundleTask(bundle2, BundleTaskNameExtract, manager.processBundleExtract)
 							if err != nil {
-								// mark error
-								manager.mutex.Lock()
-								manager.lastError = err
-								manager.mutex.Unlock()
-
-								bundle2.LastError = err
-								bundle2.LastErrorTaskName = BundleTaskNameExtract
+								manager.markBundleError(bundle2, BundleTaskNameExtract, err)
 
 								logger.Error(err)
 								// don't stop here
@@ -765,6 +811,26 @@ func (manager *BundleTransferManager) Start() {
 	}()
 }
 
+// runBundleTask runs the task for the bundle, and retries it on transient errors
+func (manager *BundleTransferManager) runBundleTask(bundle *Bundle, taskName string, task func(bundle *Bundle) error) error {
+	progressName := manager.getProgressName(bundle, taskName)
+
+	retryCallback := func(name string, attempt int, backoff time.Duration, err error) {
+		manager.transferReportManager.AddRetry(TransferMethodBput, bundle.LocalBundlePath, bundle.IRODSBundlePath, attempt, xerrors.Errorf("failed to process %q: %w", name, err))
+	}
+
+	return manager.retryPolicy.Run(progressName, func(attempt int) error {
+		if attempt > 0 {
+			// start over with a new progress tracker
+			manager.mutex.Lock()
+			delete(manager.progressTrackers, progressName)
+			manager.mutex.Unlock()
+		}
+
+		return task(bundle)
+	}, retryCallback)
+}
+
 func (manager *BundleTransferManager) processBundleRemoveFilesAndMakeDirs(bundle *Bundle) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "commons",
@@ -1045,6 +1111,16 @@ func (manager *BundleTransferManager) processBundleUploadWithoutTar(bundle *Bund
 			return xerrors.Errorf("failed to upload file %q in bundle %d to %q: %w", file.LocalPath, bundle.Index, file.IRODSPath, err)
 		}
 
+		if manager.provenance != nil {
+			err = manager.provenance.Apply(manager.filesystem, file.LocalPath, file.IRODSPath, uploadResult.CheckSumAlgorithm, uploadResult.LocalCheckSum)
+			if err != nil {
+				manager.progress(progressName, 0, bundle.Size, progress.UnitsBytes, true)
+				return xerrors.Errorf("failed to record provenance of %q in bundle %d: %w", file.IRODSPath, bundle.Index, err)
+			}
+
+			notes = append(notes, "provenance")
+		}
+
 		err = manager.transferReportManager.AddTransfer(uploadResult, TransferMethodPut, err, notes)
 		if err != nil {
 			manager.progress(progressName, 0, bundle.Size, progress.UnitsBytes, true)
@@ -1091,6 +1167,25 @@ func (manager *BundleTransferManager) processBundleExtract(bundle *Bundle) error
 	logger.Debugf("removing bundle %d at %q", bundle.Index, bundle.IRODSBundlePath)
 	manager.filesystem.RemoveFile(bundle.IRODSBundlePath, true)
 
+	notes := []string{"bundle_extracted"}
+
+	// provenance
+	if manager.provenance != nil {
+		for _, file := range bundle.Entries {
+			if file.Dir {
+				continue
+			}
+
+			err = manager.provenance.Apply(manager.filesystem, file.LocalPath, file.IRODSPath, "", nil)
+			if err != nil {
+				manager.progress(progressName, 0, totalFileNum, progress.UnitsDefault, true)
+				return xerrors.Errorf("failed to record provenance of %q in bundle %d: %w", file.IRODSPath, bundle.Index, err)
+			}
+		}
+
+		notes = append(notes, "provenance")
+	}
+
 	manager.progress(progressName, totalFileNum, totalFileNum, progress.UnitsDefault, false)
 
 	// set it done
@@ -1109,7 +1204,7 @@ func (manager *BundleTransferManager) processBundleExtract(bundle *Bundle) error
 
 			DestPath: file.IRODSPath,
 			DestSize: file.Size,
-			Notes:    []string{"bundle_extracted"},
+			Notes:    notes,
 		}
 
 		manager.transferReportManager.AddFile(reportFile)
@@ -1123,7 +1218,7 @@ func (manager *BundleTransferManager) getProgressName(bundle *Bundle, taskName s
 	return fmt.Sprintf("bundle %d - %q", bundle.Index, taskName)
 }
 
-func CleanUpOldLocalBundles(localTempDirPath string, force bool) {
+func CleanUpOldLocalBundles(localTempDirPath string, force bool, dryRun bool) {
 	logger := log.WithFields(log.Fields{
 		"package":  "commons",
 		"struct":   "BundleTransferManager",
@@ -1147,6 +1242,13 @@ func CleanUpOldLocalBundles(localTempDirPath string, force bool) {
 		}
 	}
 
+	if dryRun {
+		for _, entry := range bundleEntries {
+			Printf("[DRY RUN] DELETE\t%q\t(old local bundle)\n", entry)
+		}
+		return
+	}
+
 	deletedCount := 0
 	for _, entry := range bundleEntries {
 		if force {
@@ -1175,7 +1277,7 @@ func CleanUpOldLocalBundles(localTempDirPath string, force bool) {
 	logger.Debugf("deleted %d old local bundles in %q", deletedCount, localTempDirPath)
 }
 
-func CleanUpOldIRODSBundles(fs *irodsclient_fs.FileSystem, stagingPath string, removeDir bool, force bool) error {
+func CleanUpOldIRODSBundles(fs *irodsclient_fs.FileSystem, stagingPath string, removeDir bool, force bool, dryRun bool) error {
 	logger := log.WithFields(log.Fields{
 		"package":  "commons",
 		"struct":   "BundleTransferManager",
@@ -1198,6 +1300,11 @@ func CleanUpOldIRODSBundles(fs *irodsclient_fs.FileSystem, stagingPath string, r
 		// filter only bundle files
 		if entry.Type == irodsclient_fs.FileEntry {
 			if IsBundleFilename(entry.Name) {
+				if dryRun {
+					Printf("[DRY RUN] DELETE\t%q\t(old irods bundle)\n", entry.Path)
+					continue
+				}
+
 				logger.Debugf("deleting old irods bundle %q", entry.Path)
 				removeErr := fs.RemoveFile(entry.Path, force)
 				if removeErr != nil {
@@ -1209,6 +1316,13 @@ func CleanUpOldIRODSBundles(fs *irodsclient_fs.FileSystem, stagingPath string, r
 		}
 	}
 
+	if dryRun {
+		if removeDir && IsStagingDirInTargetPath(stagingPath) {
+			Printf("[DRY RUN] DELETE\t%q\t(staging collection)\n", stagingPath)
+		}
+		return nil
+	}
+
 	Printf("deleted %d old irods bundles in %q\n", deletedCount, stagingPath)
 	logger.Debugf("deleted %d old irods bundles in %q", deletedCount, stagingPath)
 
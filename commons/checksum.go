@@ -0,0 +1,86 @@
+package commons
+
+import (
+	"bytes"
+	"path"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_irodsfs "github.com/cyverse/go-irodsclient/irods/fs"
+	irodsclient_message "github.com/cyverse/go-irodsclient/irods/message"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"golang.org/x/xerrors"
+)
+
+// ComputeDataObjectChecksum asks the server to compute and register checksums of all replicas of the data object
+// checksums already registered are kept as they are
+// if verify is set, the server also verifies registered checksums against the content of replicas
+func ComputeDataObjectChecksum(filesystem *irodsclient_fs.FileSystem, irodsPath string, verify bool) (*irodsclient_types.IRODSChecksum, error) {
+	conn, err := filesystem.GetMetadataConnection()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer filesystem.ReturnMetadataConnection(conn)
+
+	request := irodsclient_message.NewIRODSMessageChecksumRequest(irodsPath, "")
+	request.AddKeyVal(irodsclient_common.CHKSUM_ALL_KW, "")
+	if verify {
+		request.AddKeyVal(irodsclient_common.VERIFY_CHKSUM_KW, "")
+	}
+
+	response := irodsclient_message.IRODSMessageChecksumResponse{}
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	err = conn.RequestAndCheck(request, &response, nil)
+	if err != nil {
+		if irodsclient_types.GetIRODSErrorCode(err) == irodsclient_common.CAT_NO_ROWS_FOUND {
+			return nil, xerrors.Errorf("failed to find the data object for path %q: %w", irodsPath, irodsclient_types.NewFileNotFoundError(irodsPath))
+		} else if irodsclient_types.GetIRODSErrorCode(err) == irodsclient_common.USER_CHKSUM_MISMATCH {
+			return nil, xerrors.Errorf("failed to verify checksum of %q: %w", irodsPath, NewChecksumMismatchError(irodsPath, "replicas differ from the recorded checksum"))
+		}
+		return nil, xerrors.Errorf("failed to compute checksum of %q: %w", irodsPath, err)
+	}
+
+	checksum, err := irodsclient_types.CreateIRODSChecksum(response.Checksum)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse checksum of %q: %w", irodsPath, err)
+	}
+
+	return checksum, nil
+}
+
+// GetDataObjectReplicas returns replicas of the data object
+func GetDataObjectReplicas(filesystem *irodsclient_fs.FileSystem, irodsPath string) ([]*irodsclient_types.IRODSReplica, error) {
+	conn, err := filesystem.GetMetadataConnection()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer filesystem.ReturnMetadataConnection(conn)
+
+	collection, err := irodsclient_irodsfs.GetCollection(conn, path.Dir(irodsPath))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get collection %q: %w", path.Dir(irodsPath), err)
+	}
+
+	dataObject, err := irodsclient_irodsfs.GetDataObject(conn, collection, path.Base(irodsPath))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get data-object %q: %w", irodsPath, err)
+	}
+
+	return dataObject.Replicas, nil
+}
+
+// VerifyReplicaChecksums checks if all replicas have the same checksum as the given checksum
+// returns paths of replicas having different checksums in the form of "resource hierarchy"
+func VerifyReplicaChecksums(replicas []*irodsclient_types.IRODSReplica, checksum *irodsclient_types.IRODSChecksum) []string {
+	mismatches := []string{}
+	for _, replica := range replicas {
+		if replica.Checksum == nil || !bytes.Equal(replica.Checksum.Checksum, checksum.Checksum) {
+			mismatches = append(mismatches, replica.ResourceHierarchy)
+		}
+	}
+
+	return mismatches
+}
@@ -3,12 +3,17 @@ package commons
 import "time"
 
 const (
-	clientProgramName          string        = "gocommands"
-	filesystemTimeout          time.Duration = 10 * time.Minute
-	TransferThreadNumDefault   int           = 5
-	UploadThreadNumMax         int           = 20
-	TcpBufferSizeDefault       int           = 4 * 1024 * 1024
-	TcpBufferSizeStringDefault string        = "4MB"
+	clientProgramName             string        = "gocommands"
+	filesystemTimeout             time.Duration = 10 * time.Minute
+	TransferThreadNumDefault      int           = 5
+	UploadThreadNumMax            int           = 20
+	TcpBufferSizeDefault          int           = 4 * 1024 * 1024
+	TcpBufferSizeStringDefault    string        = "4MB"
+	StreamBufferSizeDefault       int           = 1024 * 1024
+	StreamBufferSizeStringDefault string        = "1MB"
+	RetryInitialBackoffDefault    time.Duration = 1 * time.Second
+	RetryMaxBackoffDefault        time.Duration = 1 * time.Minute
 
 	RedirectToResourceMinSize int64 = 1024 * 1024 * 1024 // 1GB
+	ResumableUploadMinSize    int64 = 100 * 1024 * 1024  // 100MB
 )
@@ -0,0 +1,77 @@
+package commons
+
+import (
+	"strings"
+	"time"
+)
+
+type DiffMode string
+
+const (
+	DiffModeSize      DiffMode = "size"
+	DiffModeMtime     DiffMode = "mtime"
+	DiffModeSizeMtime DiffMode = "size+mtime"
+	DiffModeChecksum  DiffMode = "checksum"
+	DiffModeUnknown   DiffMode = ""
+)
+
+// GetDiffMode returns DiffMode from string
+func GetDiffMode(mode string) DiffMode {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case string(DiffModeSize), "no_hash":
+		return DiffModeSize
+	case string(DiffModeMtime), "time":
+		return DiffModeMtime
+	case string(DiffModeSizeMtime), "mtime+size", "size,mtime":
+		return DiffModeSizeMtime
+	case string(DiffModeChecksum), "hash":
+		return DiffModeChecksum
+	default:
+		return DiffModeUnknown
+	}
+}
+
+// IsModTimeUpToDate checks if the target is not older than the source, allowing the given tolerance
+func IsModTimeUpToDate(sourceModTime time.Time, targetModTime time.Time, tolerance time.Duration) bool {
+	return !targetModTime.Add(tolerance).Before(sourceModTime)
+}
+
+type DiffStatus string
+
+const (
+	DiffStatusIdentical       DiffStatus = "identical"
+	DiffStatusOnlyLocal       DiffStatus = "only-local"
+	DiffStatusOnlyRemote      DiffStatus = "only-remote"
+	DiffStatusOnlySource      DiffStatus = "only-source"
+	DiffStatusOnlyTarget      DiffStatus = "only-target"
+	DiffStatusTypeDiffers     DiffStatus = "type-differs"
+	DiffStatusSizeDiffers     DiffStatus = "size-differs"
+	DiffStatusMtimeDiffers    DiffStatus = "mtime-differs"
+	DiffStatusChecksumDiffers DiffStatus = "checksum-differs"
+)
+
+// DiffResult is a result of comparing a path in two trees
+type DiffResult struct {
+	Path   string     `json:"path"`
+	Status DiffStatus `json:"status"`
+
+	SourcePath     string `json:"source_path,omitempty"`
+	SourceSize     int64  `json:"source_size"`
+	SourceChecksum string `json:"source_checksum,omitempty"`
+
+	TargetPath     string `json:"target_path,omitempty"`
+	TargetSize     int64  `json:"target_size"`
+	TargetChecksum string `json:"target_checksum,omitempty"`
+
+	ChecksumAlgorithm string `json:"checksum_algorithm,omitempty"`
+}
+
+// IsModTimeDifferent checks if two modification times differ more than the given tolerance
+func IsModTimeDifferent(modTime1 time.Time, modTime2 time.Time, tolerance time.Duration) bool {
+	diff := modTime1.Sub(modTime2)
+	if diff < 0 {
+		diff = -diff
+	}
+
+	return diff > tolerance
+}
@@ -0,0 +1,46 @@
+package commons
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDifferential(t *testing.T) {
+	t.Run("test GetDiffMode", testGetDiffMode)
+	t.Run("test IsModTimeUpToDate", testIsModTimeUpToDate)
+}
+
+func testGetDiffMode(t *testing.T) {
+	modes := map[string]DiffMode{
+		"size":        DiffModeSize,
+		"no_hash":     DiffModeSize,
+		"mtime":       DiffModeMtime,
+		"TIME":        DiffModeMtime,
+		"size+mtime":  DiffModeSizeMtime,
+		"mtime+size":  DiffModeSizeMtime,
+		"size,mtime":  DiffModeSizeMtime,
+		" checksum ":  DiffModeChecksum,
+		"hash":        DiffModeChecksum,
+		"":            DiffModeUnknown,
+		"   ":         DiffModeUnknown,
+		"size+length": DiffModeUnknown,
+	}
+
+	for input, expected := range modes {
+		assert.Equal(t, expected, GetDiffMode(input), "mode %q", input)
+	}
+}
+
+func testIsModTimeUpToDate(t *testing.T) {
+	source := time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, IsModTimeUpToDate(source, source, 0))
+	assert.True(t, IsModTimeUpToDate(source, source.Add(time.Hour), 0))
+	assert.False(t, IsModTimeUpToDate(source, source.Add(-time.Second), 0))
+
+	// within the tolerance
+	assert.True(t, IsModTimeUpToDate(source, source.Add(-2*time.Second), 2*time.Second))
+	assert.False(t, IsModTimeUpToDate(source, source.Add(-3*time.Second), 2*time.Second))
+}
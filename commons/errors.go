@@ -3,6 +3,9 @@ package commons
 import (
 	"errors"
 	"fmt"
+	"os"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
 )
 
 type NotDirError struct {
@@ -66,3 +69,175 @@ func (err *NotFileError) ToString() string {
 func IsNotFileError(err error) bool {
 	return errors.Is(err, &NotFileError{})
 }
+
+type DifferenceFoundError struct {
+	Count int
+}
+
+func NewDifferenceFoundError(count int) error {
+	return &DifferenceFoundError{
+		Count: count,
+	}
+}
+
+// Error returns error message
+func (err *DifferenceFoundError) Error() string {
+	return fmt.Sprintf("found %d differences", err.Count)
+}
+
+// Is tests type of error
+func (err *DifferenceFoundError) Is(other error) bool {
+	_, ok := other.(*DifferenceFoundError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *DifferenceFoundError) ToString() string {
+	return fmt.Sprintf("DifferenceFoundError: %d", err.Count)
+}
+
+// IsDifferenceFoundError evaluates if the given error is DifferenceFoundError
+func IsDifferenceFoundError(err error) bool {
+	return errors.Is(err, &DifferenceFoundError{})
+}
+
+type ChecksumMismatchError struct {
+	Path   string
+	Reason string
+}
+
+func NewChecksumMismatchError(path string, reason string) error {
+	return &ChecksumMismatchError{
+		Path:   path,
+		Reason: reason,
+	}
+}
+
+// Error returns error message
+func (err *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch of %q: %s", err.Path, err.Reason)
+}
+
+// Is tests type of error
+func (err *ChecksumMismatchError) Is(other error) bool {
+	_, ok := other.(*ChecksumMismatchError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *ChecksumMismatchError) ToString() string {
+	return fmt.Sprintf("ChecksumMismatchError: %q", err.Path)
+}
+
+// IsChecksumMismatchError evaluates if the given error is ChecksumMismatchError
+func IsChecksumMismatchError(err error) bool {
+	return errors.Is(err, &ChecksumMismatchError{})
+}
+
+// JobFailure is a failed job
+type JobFailure struct {
+	Path  string
+	Error error
+}
+
+type JobFailuresError struct {
+	Failures []*JobFailure
+}
+
+func NewJobFailuresError(failures []*JobFailure) error {
+	return &JobFailuresError{
+		Failures: failures,
+	}
+}
+
+// Error returns error message
+func (err *JobFailuresError) Error() string {
+	return fmt.Sprintf("%d jobs failed", len(err.Failures))
+}
+
+// Is tests type of error
+func (err *JobFailuresError) Is(other error) bool {
+	_, ok := other.(*JobFailuresError)
+	return ok
+}
+
+// ToString stringifies the object
+func (err *JobFailuresError) ToString() string {
+	return fmt.Sprintf("JobFailuresError: %d", len(err.Failures))
+}
+
+// IsJobFailuresError evaluates if the given error is JobFailuresError
+func IsJobFailuresError(err error) bool {
+	return errors.Is(err, &JobFailuresError{})
+}
+
+// GetErrorType returns a short description of the type of the error, used to group errors
+func GetErrorType(err error) string {
+	switch {
+	case irodsclient_types.IsConnectionConfigError(err), irodsclient_types.IsConnectionError(err):
+		return "connection failure"
+	case irodsclient_types.IsConnectionPoolFullError(err):
+		return "connection pool full"
+	case irodsclient_types.IsAuthError(err):
+		return "authentication failure"
+	case irodsclient_types.IsFileNotFoundError(err), errors.Is(err, os.ErrNotExist):
+		return "file or directory not found"
+	case irodsclient_types.IsFileAlreadyExistError(err):
+		return "file or directory already exists"
+	case irodsclient_types.IsCollectionNotEmptyError(err):
+		return "directory not empty"
+	case IsNotDirError(err):
+		return "not a directory"
+	case IsNotFileError(err):
+		return "not a file"
+	case IsChecksumMismatchError(err):
+		return "checksum mismatch"
+	case errors.Is(err, os.ErrPermission):
+		return "permission denied"
+	case irodsclient_types.IsIRODSError(err):
+		return fmt.Sprintf("iRODS error %d", irodsclient_types.GetIRODSErrorCode(err))
+	case IsTransientError(err):
+		return "network failure"
+	default:
+		return "other error"
+	}
+}
+
+// UsageError is an error from invalid arguments or flags
+type UsageError struct {
+	CommandPath string
+	Err         error
+}
+
+func NewUsageError(commandPath string, err error) error {
+	return &UsageError{
+		CommandPath: commandPath,
+		Err:         err,
+	}
+}
+
+// Error returns error message
+func (err *UsageError) Error() string {
+	return err.Err.Error()
+}
+
+// Is tests type of error
+func (err *UsageError) Is(other error) bool {
+	_, ok := other.(*UsageError)
+	return ok
+}
+
+// Unwrap returns the original error
+func (err *UsageError) Unwrap() error {
+	return err.Err
+}
+
+// ToString stringifies the object
+func (err *UsageError) ToString() string {
+	return fmt.Sprintf("UsageError: %q", err.Err.Error())
+}
+
+// IsUsageError evaluates if the given error is UsageError
+func IsUsageError(err error) bool {
+	return errors.Is(err, &UsageError{})
+}
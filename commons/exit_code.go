@@ -0,0 +1,26 @@
+package commons
+
+// ExitCode is a process exit code
+// codes are documented in README.md, do not change the values
+type ExitCode int
+
+const (
+	// ExitCodeSuccess is for successful runs
+	ExitCodeSuccess ExitCode = 0
+	// ExitCodeError is for unexpected errors, also diff uses it when differences are found
+	ExitCodeError ExitCode = 1
+	// ExitCodeUsage is for invalid arguments or flags
+	ExitCodeUsage ExitCode = 2
+	// ExitCodeConnection is for connection failures, retrying later may succeed
+	ExitCodeConnection ExitCode = 10
+	// ExitCodeAuth is for authentication failures
+	ExitCodeAuth ExitCode = 11
+	// ExitCodeNotFound is for missing files, directories, tickets or users
+	ExitCodeNotFound ExitCode = 12
+	// ExitCodeAlreadyExists is for files or directories that already exist or are not empty
+	ExitCodeAlreadyExists ExitCode = 13
+	// ExitCodeChecksumMismatch is for data objects whose checksums do not match their replicas or local files
+	ExitCodeChecksumMismatch ExitCode = 14
+	// ExitCodePartialFailure is for transfers that failed for some files with --continue_on_error
+	ExitCodePartialFailure ExitCode = 20
+)
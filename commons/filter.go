@@ -0,0 +1,195 @@
+package commons
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+type PathFilterRuleType string
+
+const (
+	PathFilterRuleInclude PathFilterRuleType = "include"
+	PathFilterRuleExclude PathFilterRuleType = "exclude"
+)
+
+// PathFilterRule is a glob pattern to include or exclude paths
+// paths are relative to the transfer root, the parent directory of the source
+// a pattern without '/' is matched against the name of an entry
+// a pattern with '/' is matched against the trailing components of the path
+// a pattern starting with '/' is matched against the whole path from the transfer root
+// a pattern ending with '/' only matches directories
+// '*' matches any characters except '/', '**' matches any characters, '?' matches a single character
+type PathFilterRule struct {
+	Type    PathFilterRuleType
+	Pattern string
+
+	dirOnly bool
+	regex   *regexp.Regexp
+}
+
+// NewPathFilterRule creates a new PathFilterRule
+func NewPathFilterRule(ruleType PathFilterRuleType, pattern string) (*PathFilterRule, error) {
+	glob := strings.TrimSpace(pattern)
+	if len(glob) == 0 {
+		return nil, xerrors.Errorf("empty filter pattern")
+	}
+
+	dirOnly := false
+	if strings.HasSuffix(glob, "/") {
+		dirOnly = true
+		glob = strings.TrimRight(glob, "/")
+	}
+
+	anchor := "(^|/)"
+	if strings.HasPrefix(glob, "/") {
+		anchor = "^"
+		glob = strings.TrimLeft(glob, "/")
+	}
+
+	if len(glob) == 0 {
+		return nil, xerrors.Errorf("invalid filter pattern %q", pattern)
+	}
+
+	regex, err := regexp.Compile(anchor + globToRegex(glob) + "$")
+	if err != nil {
+		return nil, xerrors.Errorf("failed to compile filter pattern %q: %w", pattern, err)
+	}
+
+	return &PathFilterRule{
+		Type:    ruleType,
+		Pattern: pattern,
+		dirOnly: dirOnly,
+		regex:   regex,
+	}, nil
+}
+
+// Match checks if the rule matches the given path relative to the transfer root
+func (rule *PathFilterRule) Match(p string, isDir bool) bool {
+	if rule.dirOnly && !isDir {
+		return false
+	}
+
+	return rule.regex.MatchString(p)
+}
+
+func globToRegex(glob string) string {
+	sb := strings.Builder{}
+	runes := []rune(glob)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch c {
+		case '*':
+			if i+1 < len(runes) && runes[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := -1
+			for j := i + 1; j < len(runes); j++ {
+				if runes[j] == ']' {
+					end = j
+					break
+				}
+			}
+
+			if end < 0 {
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+
+			class := string(runes[i+1 : end])
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			sb.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i = end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return sb.String()
+}
+
+// PathFilter filters paths with include and exclude rules
+// rules are checked in the order they were added and the first matching rule wins, like rsync
+// paths not matching any rules are included
+type PathFilter struct {
+	rules []*PathFilterRule
+}
+
+// NewPathFilter creates a new PathFilter
+func NewPathFilter() *PathFilter {
+	return &PathFilter{
+		rules: []*PathFilterRule{},
+	}
+}
+
+// AddRule adds a rule
+func (filter *PathFilter) AddRule(ruleType PathFilterRuleType, pattern string) error {
+	rule, err := NewPathFilterRule(ruleType, pattern)
+	if err != nil {
+		return err
+	}
+
+	filter.rules = append(filter.rules, rule)
+	return nil
+}
+
+// GetRules returns rules
+func (filter *PathFilter) GetRules() []*PathFilterRule {
+	return filter.rules
+}
+
+// IsEmpty checks if there are no rules
+func (filter *PathFilter) IsEmpty() bool {
+	return len(filter.rules) == 0
+}
+
+// IsExcluded checks if the given path under the transfer root is excluded
+// patterns are matched against the path relative to the root, so they never match directories above the root
+func (filter *PathFilter) IsExcluded(rootPath string, p string, isDir bool) bool {
+	relPath := GetFilterRelativePath(rootPath, p)
+
+	for _, rule := range filter.rules {
+		if rule.Match(relPath, isDir) {
+			return rule.Type == PathFilterRuleExclude
+		}
+	}
+
+	return false
+}
+
+// GetFilterRootPath returns the transfer root of the source path, the parent directory of the source
+// the source itself is the root if its content is transferred without the directory, e.g., with --no_root
+func GetFilterRootPath(sourcePath string, noRoot bool) string {
+	if noRoot {
+		return sourcePath
+	}
+
+	// works for both local and iRODS paths as paths are compared in slash-separated form
+	return filepath.Dir(sourcePath)
+}
+
+// GetFilterRelativePath returns the path relative to the transfer root in slash-separated form
+// only the name is returned if the path is not under the root
+func GetFilterRelativePath(rootPath string, p string) string {
+	root := strings.TrimRight(filepath.ToSlash(rootPath), "/")
+	p = filepath.ToSlash(p)
+
+	if strings.HasPrefix(p, root+"/") {
+		return p[len(root)+1:]
+	}
+
+	return path.Base(p)
+}
@@ -0,0 +1,80 @@
+package commons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	t.Run("test Exclude", testExclude)
+	t.Run("test Precedence", testPrecedence)
+	t.Run("test TransferRoot", testTransferRoot)
+}
+
+func testExclude(t *testing.T) {
+	filter := NewPathFilter()
+	assert.True(t, filter.IsEmpty())
+	assert.False(t, filter.IsExcluded("/data", "/data/a.tmp", false))
+
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "*.tmp"))
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "__pycache__/"))
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "*.bam.bai"))
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "logs/**/*.log"))
+
+	assert.True(t, filter.IsExcluded("/data", "/data/a.tmp", false))
+	assert.False(t, filter.IsExcluded("/data", "/data/a.tmp.txt", false))
+	assert.True(t, filter.IsExcluded("/data", "/data/src/__pycache__", true))
+	assert.False(t, filter.IsExcluded("/data", "/data/src/__pycache__", false))
+	assert.True(t, filter.IsExcluded("/data", "/data/sample.bam.bai", false))
+	assert.False(t, filter.IsExcluded("/data", "/data/sample.bam", false))
+	assert.True(t, filter.IsExcluded("/data", "/data/logs/2024/01/run.log", false))
+	assert.False(t, filter.IsExcluded("/data", "/data/run.log", false))
+
+	assert.Error(t, filter.AddRule(PathFilterRuleExclude, ""))
+}
+
+func testPrecedence(t *testing.T) {
+	filter := NewPathFilter()
+
+	// first matching rule wins
+	assert.NoError(t, filter.AddRule(PathFilterRuleInclude, "keep.tmp"))
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "*.tmp"))
+	assert.NoError(t, filter.AddRule(PathFilterRuleInclude, "*.tmp"))
+
+	assert.False(t, filter.IsExcluded("/data", "/data/keep.tmp", false))
+	assert.True(t, filter.IsExcluded("/data", "/data/drop.tmp", false))
+	assert.False(t, filter.IsExcluded("/data", "/data/file.txt", false))
+
+	filter = NewPathFilter()
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "file[0-9].txt"))
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "data?.csv"))
+
+	assert.True(t, filter.IsExcluded("/data", "/data/file1.txt", false))
+	assert.False(t, filter.IsExcluded("/data", "/data/fileA.txt", false))
+	assert.True(t, filter.IsExcluded("/data", "/data/data1.csv", false))
+	assert.False(t, filter.IsExcluded("/data", "/data/data12.csv", false))
+}
+
+func testTransferRoot(t *testing.T) {
+	filter := NewPathFilter()
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "tmp/"))
+	assert.NoError(t, filter.AddRule(PathFilterRuleExclude, "/src/*.log"))
+
+	// directories above the root are not matched
+	assert.False(t, filter.IsExcluded("/tmp/work", "/tmp/work/src", true))
+	assert.False(t, filter.IsExcluded("/tmp/work", "/tmp/work/src/a.txt", false))
+	assert.True(t, filter.IsExcluded("/tmp/work", "/tmp/work/src/tmp", true))
+
+	// anchored patterns only match from the root
+	assert.True(t, filter.IsExcluded("/tmp/work", "/tmp/work/src/run.log", false))
+	assert.False(t, filter.IsExcluded("/tmp/work", "/tmp/work/other/src/run.log", false))
+	assert.False(t, filter.IsExcluded("/tmp/work/src", "/tmp/work/src/run.log", false))
+
+	assert.Equal(t, "src/a.txt", GetFilterRelativePath("/tmp/work/", "/tmp/work/src/a.txt"))
+	assert.Equal(t, "a.txt", GetFilterRelativePath("/", "/a.txt"))
+	assert.Equal(t, "a.txt", GetFilterRelativePath("/other", "/tmp/a.txt"))
+
+	assert.Equal(t, "/tmp/work", GetFilterRootPath("/tmp/work/src", false))
+	assert.Equal(t, "/tmp/work/src", GetFilterRootPath("/tmp/work/src", true))
+}
@@ -0,0 +1,603 @@
+package commons
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	irodsclient_message "github.com/cyverse/go-irodsclient/irods/message"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// select options of iCAT query
+	icatSelectNormal int = 1
+	icatSelectSum    int = 4
+	icatSelectCount  int = 6
+)
+
+// FindMetaCondition is a metadata that found entries must have
+type FindMetaCondition struct {
+	Name  string
+	Value string
+}
+
+// FindCondition is a set of predicates to find data objects and collections
+// predicates are pushed down to iCAT queries where possible, and checked again on results
+type FindCondition struct {
+	Name           string // glob pattern of entry name
+	IgnoreCase     bool
+	MinSize        int64 // -1 if not set
+	MaxSize        int64 // -1 if not set
+	ModifiedAfter  time.Time
+	ModifiedBefore time.Time
+	Metas          []FindMetaCondition
+}
+
+// NewFindCondition creates a new FindCondition that matches everything
+func NewFindCondition() *FindCondition {
+	return &FindCondition{
+		MinSize: -1,
+		MaxSize: -1,
+		Metas:   []FindMetaCondition{},
+	}
+}
+
+// ParseFindSize parses size expression, "+N" for larger than N, "-N" for smaller than N, "N" for exactly N
+// returns min and max size, -1 if not bounded
+func ParseFindSize(size string) (int64, int64, error) {
+	size = strings.TrimSpace(size)
+	if len(size) == 0 {
+		return -1, -1, nil
+	}
+
+	op := size[0]
+	if op == '+' || op == '-' {
+		size = size[1:]
+	}
+
+	if len(size) == 0 {
+		return -1, -1, xerrors.Errorf("failed to parse size, size is empty")
+	}
+
+	sizeNum, err := ParseSize(size)
+	if err != nil {
+		return -1, -1, xerrors.Errorf("failed to parse size %q: %w", size, err)
+	}
+
+	switch op {
+	case '+':
+		return sizeNum + 1, -1, nil
+	case '-':
+		if sizeNum <= 0 {
+			return -1, -1, xerrors.Errorf("size must be larger than 0 to find smaller files")
+		}
+		return -1, sizeNum - 1, nil
+	default:
+		return sizeNum, sizeNum, nil
+	}
+}
+
+// ParseFindModifyTime parses modify time expression relative to now
+// "-N" or "N" for modified within N, "+N" for modified more than N ago, N is in ParseTime syntax, e.g., 7d
+// returns modified-after and modified-before times, zero if not bounded
+func ParseFindModifyTime(t string, now time.Time) (time.Time, time.Time, error) {
+	t = strings.TrimSpace(t)
+	if len(t) == 0 {
+		return time.Time{}, time.Time{}, nil
+	}
+
+	op := t[0]
+	if op == '+' || op == '-' {
+		t = t[1:]
+	}
+
+	if len(t) == 0 {
+		return time.Time{}, time.Time{}, xerrors.Errorf("failed to parse time, time is empty")
+	}
+
+	seconds, err := ParseTime(t)
+	if err != nil {
+		return time.Time{}, time.Time{}, xerrors.Errorf("failed to parse time %q: %w", t, err)
+	}
+
+	boundary := now.Add(-time.Duration(seconds) * time.Second)
+	if op == '+' {
+		return time.Time{}, boundary, nil
+	}
+
+	return boundary, time.Time{}, nil
+}
+
+// ParseFindMetaCondition parses metadata expression "attr=value"
+func ParseFindMetaCondition(meta string) (FindMetaCondition, error) {
+	idx := strings.Index(meta, "=")
+	if idx <= 0 {
+		return FindMetaCondition{}, xerrors.Errorf("failed to parse metadata %q, must be in attr=value form", meta)
+	}
+
+	return FindMetaCondition{
+		Name:  meta[:idx],
+		Value: meta[idx+1:],
+	}, nil
+}
+
+// MatchName checks if the given name matches Name pattern
+func (cond *FindCondition) MatchName(name string) bool {
+	if len(cond.Name) == 0 {
+		return true
+	}
+
+	pattern := cond.Name
+	if cond.IgnoreCase {
+		pattern = strings.ToLower(pattern)
+		name = strings.ToLower(name)
+	}
+
+	matched, err := path.Match(pattern, name)
+	if err != nil {
+		return false
+	}
+
+	return matched
+}
+
+// MatchModifyTime checks if the given time is in the range
+func (cond *FindCondition) MatchModifyTime(t time.Time) bool {
+	if !cond.ModifiedAfter.IsZero() && t.Before(cond.ModifiedAfter) {
+		return false
+	}
+
+	if !cond.ModifiedBefore.IsZero() && t.After(cond.ModifiedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// MatchSize checks if the given size is in the range
+func (cond *FindCondition) MatchSize(size int64) bool {
+	if cond.MinSize >= 0 && size < cond.MinSize {
+		return false
+	}
+
+	if cond.MaxSize >= 0 && size > cond.MaxSize {
+		return false
+	}
+
+	return true
+}
+
+// HasSize returns true if size is given, collections never match size
+func (cond *FindCondition) HasSize() bool {
+	return cond.MinSize >= 0 || cond.MaxSize >= 0
+}
+
+// MatchMetas checks if the given metadata has all Metas
+func (cond *FindCondition) MatchMetas(metas []*irodsclient_types.IRODSMeta) bool {
+	for _, metaCond := range cond.Metas {
+		found := false
+		for _, meta := range metas {
+			if meta.Name == metaCond.Name && meta.Value == metaCond.Value {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchDataObject checks if the given data object matches all predicates but Metas
+func (cond *FindCondition) MatchDataObject(object *irodsclient_types.IRODSDataObject) bool {
+	if !cond.MatchName(object.Name) || !cond.MatchSize(object.Size) {
+		return false
+	}
+
+	for _, replica := range object.Replicas {
+		if cond.MatchModifyTime(replica.ModifyTime) {
+			return true
+		}
+	}
+
+	return len(object.Replicas) == 0 && cond.ModifiedAfter.IsZero() && cond.ModifiedBefore.IsZero()
+}
+
+// MatchCollection checks if the given collection matches all predicates but Metas
+func (cond *FindCondition) MatchCollection(collection *irodsclient_types.IRODSCollection) bool {
+	if cond.HasSize() {
+		return false
+	}
+
+	return cond.MatchName(collection.Name) && cond.MatchModifyTime(collection.ModifyTime)
+}
+
+// isQuerySafe checks if the value can be put in iCAT query condition
+func isQuerySafe(value string) bool {
+	return !strings.Contains(value, "'")
+}
+
+// checkQuerySafe checks if the collection path and metadata can be put in iCAT query conditions
+func (cond *FindCondition) checkQuerySafe(collectionPath string) error {
+	if !isQuerySafe(collectionPath) {
+		return xerrors.Errorf("collection path %q containing a single quote is not supported", collectionPath)
+	}
+
+	if len(cond.Metas) > 0 && (!isQuerySafe(cond.Metas[0].Name) || !isQuerySafe(cond.Metas[0].Value)) {
+		return xerrors.Errorf("metadata %q containing a single quote is not supported", cond.Metas[0].Name)
+	}
+
+	return nil
+}
+
+// RequireMetaCheck returns true if some of Metas are not checked in iCAT queries
+// only one metadata can be given in a query
+func (cond *FindCondition) RequireMetaCheck() bool {
+	return len(cond.Metas) > 1
+}
+
+// makeQueryLikePattern converts glob pattern to SQL like pattern
+// returns false if the pattern cannot be converted
+func makeQueryLikePattern(pattern string) (string, bool) {
+	if !isQuerySafe(pattern) || strings.ContainsAny(pattern, "[\\") {
+		return "", false
+	}
+
+	pattern = strings.ReplaceAll(pattern, "*", "%")
+	pattern = strings.ReplaceAll(pattern, "?", "_")
+	return pattern, true
+}
+
+func makeQueryCollectionCondition(collectionPath string) string {
+	if collectionPath == "/" {
+		return "like '/%'"
+	}
+
+	return fmt.Sprintf("= '%s' || like '%s/%%'", collectionPath, collectionPath)
+}
+
+func makeQueryTimeString(t time.Time) string {
+	return fmt.Sprintf("%011d", t.Unix())
+}
+
+func makeQueryRangeCondition(min string, max string) string {
+	conds := []string{}
+	if len(min) > 0 {
+		conds = append(conds, fmt.Sprintf(">= '%s'", min))
+	}
+
+	if len(max) > 0 {
+		conds = append(conds, fmt.Sprintf("<= '%s'", max))
+	}
+
+	return strings.Join(conds, " && ")
+}
+
+func (cond *FindCondition) getModifyTimeQueryCondition() string {
+	min := ""
+	if !cond.ModifiedAfter.IsZero() {
+		min = makeQueryTimeString(cond.ModifiedAfter)
+	}
+
+	max := ""
+	if !cond.ModifiedBefore.IsZero() {
+		max = makeQueryTimeString(cond.ModifiedBefore)
+	}
+
+	return makeQueryRangeCondition(min, max)
+}
+
+// runICATQuery runs iCAT query and calls rowFunc for each row with values of selected columns
+// selects have select options of columns, e.g., icatSelectSum to get sum of values
+func runICATQuery(conn *irodsclient_conn.IRODSConnection, selects map[irodsclient_common.ICATColumnNumber]int, conditions map[irodsclient_common.ICATColumnNumber]string, rowFunc func(values map[irodsclient_common.ICATColumnNumber]string) error) error {
+	if conn == nil || !conn.IsConnected() {
+		return xerrors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	continueIndex := 0
+	for {
+		query := irodsclient_message.NewIRODSMessageQueryRequest(irodsclient_common.MaxQueryRows, continueIndex, 0, 0)
+		query.AddKeyVal(irodsclient_common.ZONE_KW, conn.GetAccount().ClientZone)
+
+		for column, option := range selects {
+			query.AddSelect(column, option)
+		}
+
+		for column, condition := range conditions {
+			if len(condition) > 0 {
+				query.AddCondition(column, condition)
+			}
+		}
+
+		queryResult := irodsclient_message.IRODSMessageQueryResponse{}
+		err := conn.Request(query, &queryResult, nil)
+		if err != nil {
+			return xerrors.Errorf("failed to receive a query result message: %w", err)
+		}
+
+		err = queryResult.CheckError()
+		if err != nil {
+			if irodsclient_types.GetIRODSErrorCode(err) == irodsclient_common.CAT_NO_ROWS_FOUND {
+				// empty
+				return nil
+			}
+			return xerrors.Errorf("received query error: %w", err)
+		}
+
+		if queryResult.RowCount == 0 {
+			return nil
+		}
+
+		if queryResult.AttributeCount > len(queryResult.SQLResult) {
+			return xerrors.Errorf("failed to receive attributes - requires %d, but received %d attributes", queryResult.AttributeCount, len(queryResult.SQLResult))
+		}
+
+		for row := 0; row < queryResult.RowCount; row++ {
+			values := map[irodsclient_common.ICATColumnNumber]string{}
+			for attr := 0; attr < queryResult.AttributeCount; attr++ {
+				sqlResult := queryResult.SQLResult[attr]
+				if len(sqlResult.Values) != queryResult.RowCount {
+					return xerrors.Errorf("failed to receive rows - requires %d, but received %d attributes", queryResult.RowCount, len(sqlResult.Values))
+				}
+
+				values[irodsclient_common.ICATColumnNumber(sqlResult.AttributeIndex)] = sqlResult.Values[row]
+			}
+
+			err = rowFunc(values)
+			if err != nil {
+				return err
+			}
+		}
+
+		continueIndex = queryResult.ContinueIndex
+		if continueIndex == 0 {
+			return nil
+		}
+	}
+}
+
+// FindDataObjects finds data objects under the given collection that match the condition
+// only replicas matching the condition are included
+func FindDataObjects(conn *irodsclient_conn.IRODSConnection, collectionPath string, cond *FindCondition) ([]*irodsclient_types.IRODSDataObject, error) {
+	err := cond.checkQuerySafe(collectionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	selects := map[irodsclient_common.ICATColumnNumber]int{
+		irodsclient_common.ICAT_COLUMN_COLL_ID:         icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_COLL_NAME:       icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_DATA_ID:       icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_DATA_NAME:       icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_DATA_SIZE:       icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_DATA_TYPE_NAME:  icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_DATA_REPL_NUM:   icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_OWNER_NAME:    icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_DATA_CHECKSUM: icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_REPL_STATUS:   icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_RESC_NAME:     icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_DATA_PATH:     icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_RESC_HIER:     icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_CREATE_TIME:   icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_MODIFY_TIME:   icatSelectNormal,
+	}
+
+	conditions := map[irodsclient_common.ICATColumnNumber]string{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME:     makeQueryCollectionCondition(collectionPath),
+		irodsclient_common.ICAT_COLUMN_D_MODIFY_TIME: cond.getModifyTimeQueryCondition(),
+	}
+
+	if !cond.IgnoreCase && len(cond.Name) > 0 {
+		if pattern, ok := makeQueryLikePattern(cond.Name); ok {
+			conditions[irodsclient_common.ICAT_COLUMN_DATA_NAME] = fmt.Sprintf("like '%s'", pattern)
+		}
+	}
+
+	if cond.HasSize() {
+		min := ""
+		if cond.MinSize >= 0 {
+			min = fmt.Sprintf("%d", cond.MinSize)
+		}
+
+		max := ""
+		if cond.MaxSize >= 0 {
+			max = fmt.Sprintf("%d", cond.MaxSize)
+		}
+
+		conditions[irodsclient_common.ICAT_COLUMN_DATA_SIZE] = makeQueryRangeCondition(min, max)
+	}
+
+	// only one metadata can be given in a query, others are checked later
+	if len(cond.Metas) > 0 {
+		conditions[irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_NAME] = fmt.Sprintf("= '%s'", cond.Metas[0].Name)
+		conditions[irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_VALUE] = fmt.Sprintf("= '%s'", cond.Metas[0].Value)
+	}
+
+	objects := []*irodsclient_types.IRODSDataObject{}
+	objectMap := map[int64]*irodsclient_types.IRODSDataObject{}
+	replicaMap := map[string]bool{}
+
+	err = runICATQuery(conn, selects, conditions, func(values map[irodsclient_common.ICATColumnNumber]string) error {
+		objectID, err := strconv.ParseInt(values[irodsclient_common.ICAT_COLUMN_D_DATA_ID], 10, 64)
+		if err != nil {
+			return xerrors.Errorf("failed to parse data object id %q: %w", values[irodsclient_common.ICAT_COLUMN_D_DATA_ID], err)
+		}
+
+		replicaNumber, err := strconv.ParseInt(values[irodsclient_common.ICAT_COLUMN_DATA_REPL_NUM], 10, 64)
+		if err != nil {
+			return xerrors.Errorf("failed to parse replica number %q: %w", values[irodsclient_common.ICAT_COLUMN_DATA_REPL_NUM], err)
+		}
+
+		// metadata join may return the same replica multiple times
+		replicaKey := fmt.Sprintf("%d:%d", objectID, replicaNumber)
+		if replicaMap[replicaKey] {
+			return nil
+		}
+		replicaMap[replicaKey] = true
+
+		object, ok := objectMap[objectID]
+		if !ok {
+			collectionID, err := strconv.ParseInt(values[irodsclient_common.ICAT_COLUMN_COLL_ID], 10, 64)
+			if err != nil {
+				return xerrors.Errorf("failed to parse collection id %q: %w", values[irodsclient_common.ICAT_COLUMN_COLL_ID], err)
+			}
+
+			size, err := strconv.ParseInt(values[irodsclient_common.ICAT_COLUMN_DATA_SIZE], 10, 64)
+			if err != nil {
+				return xerrors.Errorf("failed to parse data object size %q: %w", values[irodsclient_common.ICAT_COLUMN_DATA_SIZE], err)
+			}
+
+			object = &irodsclient_types.IRODSDataObject{
+				ID:           objectID,
+				CollectionID: collectionID,
+				Path:         irodsclient_util.MakeIRODSPath(values[irodsclient_common.ICAT_COLUMN_COLL_NAME], values[irodsclient_common.ICAT_COLUMN_DATA_NAME]),
+				Name:         values[irodsclient_common.ICAT_COLUMN_DATA_NAME],
+				Size:         size,
+				DataType:     values[irodsclient_common.ICAT_COLUMN_DATA_TYPE_NAME],
+				Replicas:     []*irodsclient_types.IRODSReplica{},
+			}
+
+			objectMap[objectID] = object
+			objects = append(objects, object)
+		}
+
+		checksum, err := irodsclient_types.CreateIRODSChecksum(values[irodsclient_common.ICAT_COLUMN_D_DATA_CHECKSUM])
+		if err != nil {
+			return xerrors.Errorf("failed to parse data object checksum %q: %w", values[irodsclient_common.ICAT_COLUMN_D_DATA_CHECKSUM], err)
+		}
+
+		createTime, err := irodsclient_util.GetIRODSDateTime(values[irodsclient_common.ICAT_COLUMN_D_CREATE_TIME])
+		if err != nil {
+			return xerrors.Errorf("failed to parse create time %q: %w", values[irodsclient_common.ICAT_COLUMN_D_CREATE_TIME], err)
+		}
+
+		modifyTime, err := irodsclient_util.GetIRODSDateTime(values[irodsclient_common.ICAT_COLUMN_D_MODIFY_TIME])
+		if err != nil {
+			return xerrors.Errorf("failed to parse modify time %q: %w", values[irodsclient_common.ICAT_COLUMN_D_MODIFY_TIME], err)
+		}
+
+		object.Replicas = append(object.Replicas, &irodsclient_types.IRODSReplica{
+			Number:            replicaNumber,
+			Owner:             values[irodsclient_common.ICAT_COLUMN_D_OWNER_NAME],
+			Checksum:          checksum,
+			Status:            values[irodsclient_common.ICAT_COLUMN_D_REPL_STATUS],
+			ResourceName:      values[irodsclient_common.ICAT_COLUMN_D_RESC_NAME],
+			Path:              values[irodsclient_common.ICAT_COLUMN_D_DATA_PATH],
+			ResourceHierarchy: values[irodsclient_common.ICAT_COLUMN_D_RESC_HIER],
+			CreateTime:        createTime,
+			ModifyTime:        modifyTime,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find data objects in %q: %w", collectionPath, err)
+	}
+
+	// like patterns and ranges may not be exact, so check again
+	matchedObjects := []*irodsclient_types.IRODSDataObject{}
+	for _, object := range objects {
+		if IsSubIRODSPath(collectionPath, object.Path) && cond.MatchDataObject(object) {
+			matchedObjects = append(matchedObjects, object)
+		}
+	}
+
+	return matchedObjects, nil
+}
+
+// FindCollections finds collections under the given collection that match the condition
+func FindCollections(conn *irodsclient_conn.IRODSConnection, collectionPath string, cond *FindCondition) ([]*irodsclient_types.IRODSCollection, error) {
+	err := cond.checkQuerySafe(collectionPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if cond.HasSize() {
+		// collections do not have size
+		return []*irodsclient_types.IRODSCollection{}, nil
+	}
+
+	selects := map[irodsclient_common.ICATColumnNumber]int{
+		irodsclient_common.ICAT_COLUMN_COLL_ID:          icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_COLL_NAME:        icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_COLL_OWNER_NAME:  icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_COLL_CREATE_TIME: icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_COLL_MODIFY_TIME: icatSelectNormal,
+	}
+
+	conditions := map[irodsclient_common.ICATColumnNumber]string{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME:        makeQueryCollectionCondition(collectionPath),
+		irodsclient_common.ICAT_COLUMN_COLL_MODIFY_TIME: cond.getModifyTimeQueryCondition(),
+	}
+
+	// only one metadata can be given in a query, others are checked later
+	if len(cond.Metas) > 0 {
+		conditions[irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_NAME] = fmt.Sprintf("= '%s'", cond.Metas[0].Name)
+		conditions[irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_VALUE] = fmt.Sprintf("= '%s'", cond.Metas[0].Value)
+	}
+
+	collections := []*irodsclient_types.IRODSCollection{}
+	collectionMap := map[int64]bool{}
+
+	err = runICATQuery(conn, selects, conditions, func(values map[irodsclient_common.ICATColumnNumber]string) error {
+		collectionID, err := strconv.ParseInt(values[irodsclient_common.ICAT_COLUMN_COLL_ID], 10, 64)
+		if err != nil {
+			return xerrors.Errorf("failed to parse collection id %q: %w", values[irodsclient_common.ICAT_COLUMN_COLL_ID], err)
+		}
+
+		// metadata join may return the same collection multiple times
+		if collectionMap[collectionID] {
+			return nil
+		}
+		collectionMap[collectionID] = true
+
+		createTime, err := irodsclient_util.GetIRODSDateTime(values[irodsclient_common.ICAT_COLUMN_COLL_CREATE_TIME])
+		if err != nil {
+			return xerrors.Errorf("failed to parse create time %q: %w", values[irodsclient_common.ICAT_COLUMN_COLL_CREATE_TIME], err)
+		}
+
+		modifyTime, err := irodsclient_util.GetIRODSDateTime(values[irodsclient_common.ICAT_COLUMN_COLL_MODIFY_TIME])
+		if err != nil {
+			return xerrors.Errorf("failed to parse modify time %q: %w", values[irodsclient_common.ICAT_COLUMN_COLL_MODIFY_TIME], err)
+		}
+
+		collectionPath := values[irodsclient_common.ICAT_COLUMN_COLL_NAME]
+		collections = append(collections, &irodsclient_types.IRODSCollection{
+			ID:         collectionID,
+			Path:       collectionPath,
+			Name:       path.Base(collectionPath),
+			Owner:      values[irodsclient_common.ICAT_COLUMN_COLL_OWNER_NAME],
+			CreateTime: createTime,
+			ModifyTime: modifyTime,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to find collections in %q: %w", collectionPath, err)
+	}
+
+	// like patterns and ranges may not be exact, so check again
+	matchedCollections := []*irodsclient_types.IRODSCollection{}
+	for _, collection := range collections {
+		if IsSubIRODSPath(collectionPath, collection.Path) && cond.MatchCollection(collection) {
+			matchedCollections = append(matchedCollections, collection)
+		}
+	}
+
+	return matchedCollections, nil
+}
@@ -0,0 +1,101 @@
+package commons
+
+import (
+	"testing"
+	"time"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+	t.Run("test ParseFindSize", testParseFindSize)
+	t.Run("test ParseFindModifyTime", testParseFindModifyTime)
+	t.Run("test MatchDataObject", testMatchDataObject)
+	t.Run("test MakeQueryCondition", testMakeQueryCondition)
+}
+
+func testParseFindSize(t *testing.T) {
+	min, max, err := ParseFindSize("+10G")
+	assert.NoError(t, err)
+	assert.Equal(t, 10*GigaBytes+1, min)
+	assert.Equal(t, int64(-1), max)
+
+	min, max, err = ParseFindSize("-1K")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(-1), min)
+	assert.Equal(t, KiloBytes-1, max)
+
+	min, max, err = ParseFindSize("100")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), min)
+	assert.Equal(t, int64(100), max)
+
+	_, _, err = ParseFindSize("+")
+	assert.Error(t, err)
+}
+
+func testParseFindModifyTime(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	after, before, err := ParseFindModifyTime("-7d", now)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), after)
+	assert.True(t, before.IsZero())
+
+	after, before, err = ParseFindModifyTime("+1h", now)
+	assert.NoError(t, err)
+	assert.True(t, after.IsZero())
+	assert.Equal(t, time.Date(2024, 1, 9, 23, 0, 0, 0, time.UTC), before)
+}
+
+func testMatchDataObject(t *testing.T) {
+	modTime := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	object := &irodsclient_types.IRODSDataObject{
+		Path: "/zone/home/user/sample.CRAM",
+		Name: "sample.CRAM",
+		Size: 20 * GigaBytes,
+		Replicas: []*irodsclient_types.IRODSReplica{
+			{Number: 0, ModifyTime: modTime},
+		},
+	}
+
+	cond := NewFindCondition()
+	cond.Name = "*.cram"
+	assert.False(t, cond.MatchDataObject(object))
+
+	cond.IgnoreCase = true
+	assert.True(t, cond.MatchDataObject(object))
+
+	cond.MinSize = 10*GigaBytes + 1
+	assert.True(t, cond.MatchDataObject(object))
+
+	cond.ModifiedAfter = modTime.Add(time.Hour)
+	assert.False(t, cond.MatchDataObject(object))
+
+	collection := &irodsclient_types.IRODSCollection{Path: "/zone/home/user/a.cram", Name: "a.cram"}
+	assert.False(t, cond.MatchCollection(collection))
+
+	metas := []*irodsclient_types.IRODSMeta{{Name: "sample_id", Value: "XYZ"}}
+	cond.Metas = []FindMetaCondition{{Name: "sample_id", Value: "XYZ"}}
+	assert.True(t, cond.MatchMetas(metas))
+
+	cond.Metas = append(cond.Metas, FindMetaCondition{Name: "study", Value: "1"})
+	assert.False(t, cond.MatchMetas(metas))
+}
+
+func testMakeQueryCondition(t *testing.T) {
+	pattern, ok := makeQueryLikePattern("*.cr?m")
+	assert.True(t, ok)
+	assert.Equal(t, "%.cr_m", pattern)
+
+	_, ok = makeQueryLikePattern("[ab].txt")
+	assert.False(t, ok)
+
+	assert.Equal(t, "= '/zone/home' || like '/zone/home/%'", makeQueryCollectionCondition("/zone/home"))
+	assert.Equal(t, ">= '10' && <= '20'", makeQueryRangeCondition("10", "20"))
+
+	assert.True(t, IsSubIRODSPath("/zone/home", "/zone/home/a"))
+	assert.True(t, IsSubIRODSPath("/zone/home", "/zone/home"))
+	assert.False(t, IsSubIRODSPath("/zone/home", "/zone/homes/a"))
+}
@@ -0,0 +1,329 @@
+package commons
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+)
+
+type MetaOperation string
+
+const (
+	MetaOperationAdd    MetaOperation = "add"
+	MetaOperationSet    MetaOperation = "set"
+	MetaOperationRemove MetaOperation = "remove"
+)
+
+// GetMetaOperation returns MetaOperation from string
+func GetMetaOperation(operation string) (MetaOperation, error) {
+	switch strings.ToLower(strings.TrimSpace(operation)) {
+	case string(MetaOperationAdd):
+		return MetaOperationAdd, nil
+	case string(MetaOperationSet):
+		return MetaOperationSet, nil
+	case string(MetaOperationRemove), "rm", "delete":
+		return MetaOperationRemove, nil
+	default:
+		return MetaOperationAdd, xerrors.Errorf("unknown metadata operation %q, must be one of add, set or remove", operation)
+	}
+}
+
+// PathMetaRecord is a metadata of a data object or a collection, used for import and export
+// Operation is optional, the default operation is used if it is empty
+type PathMetaRecord struct {
+	Path      string        `json:"path" yaml:"path"`
+	Attribute string        `json:"attribute" yaml:"attribute"`
+	Value     string        `json:"value" yaml:"value"`
+	Unit      string        `json:"unit" yaml:"unit"`
+	Operation MetaOperation `json:"operation,omitempty" yaml:"operation,omitempty"`
+}
+
+var (
+	pathMetaCSVHeader = []string{"path", "attribute", "value", "unit", "operation"}
+)
+
+func (record PathMetaRecord) GetCSVHeader() []string {
+	return pathMetaCSVHeader[:4]
+}
+
+func (record PathMetaRecord) GetCSVRows() [][]string {
+	return [][]string{
+		{record.Path, record.Attribute, record.Value, record.Unit},
+	}
+}
+
+// GetMetaFormatFromPath returns a format of metadata file from its extension
+func GetMetaFormatFromPath(p string) (OutputFormat, error) {
+	switch strings.ToLower(filepath.Ext(p)) {
+	case ".csv":
+		return OutputFormatCSV, nil
+	case ".json":
+		return OutputFormatJSON, nil
+	case ".yaml", ".yml":
+		return OutputFormatYAML, nil
+	default:
+		return OutputFormatTable, xerrors.Errorf("failed to detect format of %q from its extension, must be one of csv, json or yaml", p)
+	}
+}
+
+// ReadPathMetaRecords reads metadata records in the given format
+// the operation of a record is set to defaultOperation if not given
+func ReadPathMetaRecords(reader io.Reader, format OutputFormat, defaultOperation MetaOperation) ([]PathMetaRecord, error) {
+	records, err := readMetaRecords(reader, format, pathMetaCSVHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range records {
+		record := &records[idx]
+
+		if len(record.Operation) == 0 {
+			record.Operation = defaultOperation
+		} else {
+			operation, err := GetMetaOperation(string(record.Operation))
+			if err != nil {
+				return nil, xerrors.Errorf("invalid record %d: %w", idx+1, err)
+			}
+			record.Operation = operation
+		}
+
+		if len(record.Path) == 0 {
+			return nil, xerrors.Errorf("invalid record %d: path is empty", idx+1)
+		}
+
+		if len(record.Attribute) == 0 {
+			return nil, xerrors.Errorf("invalid record %d: attribute is empty", idx+1)
+		}
+
+		// empty value is only allowed for removing all values of the attribute
+		if len(record.Value) == 0 && record.Operation != MetaOperationRemove {
+			return nil, xerrors.Errorf("invalid record %d: value is empty", idx+1)
+		}
+	}
+
+	return records, nil
+}
+
+// readMetaRecords reads metadata records in the given format without validation
+// defaultColumns are columns of csv rows if the header is not given
+func readMetaRecords(reader io.Reader, format OutputFormat, defaultColumns []string) ([]PathMetaRecord, error) {
+	records := []PathMetaRecord{}
+
+	switch format {
+	case OutputFormatCSV:
+		csvRecords, err := readPathMetaRecordsFromCSV(reader, defaultColumns)
+		if err != nil {
+			return nil, err
+		}
+		records = csvRecords
+	case OutputFormatJSON:
+		decoder := json.NewDecoder(reader)
+		decoder.DisallowUnknownFields()
+		err := decoder.Decode(&records)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to unmarshal json: %w", err)
+		}
+	case OutputFormatYAML:
+		err := yaml.NewDecoder(reader).Decode(&records)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, xerrors.Errorf("failed to unmarshal yaml: %w", err)
+		}
+	default:
+		return nil, xerrors.Errorf("unsupported metadata format %q, must be one of csv, json or yaml", format)
+	}
+
+	return records, nil
+}
+
+// isPathMetaCSVHeader checks if all columns of the row are known column names
+func isPathMetaCSVHeader(row []string) bool {
+	if len(row) == 0 {
+		return false
+	}
+
+	for _, column := range row {
+		known := false
+		for _, header := range pathMetaCSVHeader {
+			if strings.EqualFold(strings.TrimSpace(column), header) {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return false
+		}
+	}
+
+	return true
+}
+
+// readPathMetaRecordsFromCSV reads csv rows of path, attribute, value, unit and operation
+// header row is optional, columns can be in any order if the header is given
+func readPathMetaRecordsFromCSV(reader io.Reader, defaultColumns []string) ([]PathMetaRecord, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	columns := defaultColumns
+	records := []PathMetaRecord{}
+
+	for line := 1; ; line++ {
+		row, err := csvReader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, xerrors.Errorf("failed to read csv: %w", err)
+		}
+
+		if line == 1 && isPathMetaCSVHeader(row) {
+			// header
+			columns = []string{}
+			for _, column := range row {
+				columns = append(columns, strings.ToLower(strings.TrimSpace(column)))
+			}
+			continue
+		}
+
+		if len(row) > len(columns) {
+			return nil, xerrors.Errorf("failed to read csv line %d: too many columns", line)
+		}
+
+		record := PathMetaRecord{}
+		for idx, value := range row {
+			switch columns[idx] {
+			case "path":
+				record.Path = value
+			case "attribute":
+				record.Attribute = value
+			case "value":
+				record.Value = value
+			case "unit":
+				record.Unit = value
+			case "operation":
+				record.Operation = MetaOperation(value)
+			default:
+				return nil, xerrors.Errorf("failed to read csv line %d: unknown column %q", line, columns[idx])
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// ListPathMetaRecords returns metadata of a collection, and data objects and collections under the collection
+// only direct children of the collection are returned if recursive is false
+func ListPathMetaRecords(conn *irodsclient_conn.IRODSConnection, collectionPath string, recursive bool) ([]PathMetaRecord, error) {
+	if !isQuerySafe(collectionPath) {
+		return nil, xerrors.Errorf("collection path %q containing a single quote is not supported", collectionPath)
+	}
+
+	records := []PathMetaRecord{}
+
+	// data objects
+	dataObjectSelects := map[irodsclient_common.ICATColumnNumber]int{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME:            icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_DATA_NAME:            icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_NAME:  icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_VALUE: icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_UNITS: icatSelectNormal,
+	}
+
+	dataObjectCollectionCondition := makeQueryCollectionCondition(collectionPath)
+	if !recursive {
+		dataObjectCollectionCondition = fmt.Sprintf("= '%s'", collectionPath)
+	}
+
+	dataObjectConditions := map[irodsclient_common.ICATColumnNumber]string{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME: dataObjectCollectionCondition,
+	}
+
+	err := runICATQuery(conn, dataObjectSelects, dataObjectConditions, func(values map[irodsclient_common.ICATColumnNumber]string) error {
+		// '_' and '%' in the collection path are wildcards of LIKE, so other collections may match
+		collPath := values[irodsclient_common.ICAT_COLUMN_COLL_NAME]
+		if !IsSubIRODSPath(collectionPath, collPath) {
+			return nil
+		}
+
+		records = append(records, PathMetaRecord{
+			Path:      path.Join(collPath, values[irodsclient_common.ICAT_COLUMN_DATA_NAME]),
+			Attribute: values[irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_NAME],
+			Value:     values[irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_VALUE],
+			Unit:      values[irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_UNITS],
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list metadata of data objects in %q: %w", collectionPath, err)
+	}
+
+	// collections
+	collectionSelects := map[irodsclient_common.ICATColumnNumber]int{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME:            icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_NAME:  icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_VALUE: icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_UNITS: icatSelectNormal,
+	}
+
+	collectionConditions := map[irodsclient_common.ICATColumnNumber]string{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME: makeQueryCollectionCondition(collectionPath),
+	}
+
+	err = runICATQuery(conn, collectionSelects, collectionConditions, func(values map[irodsclient_common.ICATColumnNumber]string) error {
+		collPath := values[irodsclient_common.ICAT_COLUMN_COLL_NAME]
+		if !IsSubIRODSPath(collectionPath, collPath) {
+			return nil
+		}
+
+		if !recursive && collPath != collectionPath && path.Dir(collPath) != collectionPath {
+			return nil
+		}
+
+		records = append(records, PathMetaRecord{
+			Path:      collPath,
+			Attribute: values[irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_NAME],
+			Value:     values[irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_VALUE],
+			Unit:      values[irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_UNITS],
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list metadata of collections in %q: %w", collectionPath, err)
+	}
+
+	SortPathMetaRecords(records)
+	return records, nil
+}
+
+// SortPathMetaRecords sorts records by path, attribute, value and unit
+func SortPathMetaRecords(records []PathMetaRecord) {
+	sort.SliceStable(records, func(i int, j int) bool {
+		if records[i].Path != records[j].Path {
+			return records[i].Path < records[j].Path
+		}
+
+		if records[i].Attribute != records[j].Attribute {
+			return records[i].Attribute < records[j].Attribute
+		}
+
+		if records[i].Value != records[j].Value {
+			return records[i].Value < records[j].Value
+		}
+
+		return records[i].Unit < records[j].Unit
+	})
+}
@@ -0,0 +1,253 @@
+package commons
+
+import (
+	"fmt"
+	"sort"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	irodsclient_message "github.com/cyverse/go-irodsclient/irods/message"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"golang.org/x/xerrors"
+)
+
+// MetaTarget is a data object, a collection, a user or a resource to modify metadata of
+type MetaTarget struct {
+	ItemType irodsclient_types.IRODSMetaItemType
+	Name     string // path for data objects and collections
+}
+
+// ListMetaTargets returns the data object or the collection at the path
+// data objects and collections under the collection are also returned if recursive is set
+func ListMetaTargets(filesystem *irodsclient_fs.FileSystem, targetPath string, recursive bool) ([]MetaTarget, error) {
+	entry, err := filesystem.Stat(targetPath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to stat %q: %w", targetPath, err)
+	}
+
+	if !entry.IsDir() {
+		return []MetaTarget{
+			{ItemType: irodsclient_types.IRODSDataObjectMetaItemType, Name: entry.Path},
+		}, nil
+	}
+
+	if !recursive {
+		return []MetaTarget{
+			{ItemType: irodsclient_types.IRODSCollectionMetaItemType, Name: entry.Path},
+		}, nil
+	}
+
+	conn, err := filesystem.GetMetadataConnection()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer filesystem.ReturnMetadataConnection(conn)
+
+	// collections include the collection itself
+	collections, err := FindCollections(conn, entry.Path, NewFindCondition())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list sub-collections of %q: %w", entry.Path, err)
+	}
+
+	dataObjects, err := FindDataObjects(conn, entry.Path, NewFindCondition())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list data objects in %q: %w", entry.Path, err)
+	}
+
+	targets := []MetaTarget{}
+	for _, collection := range collections {
+		targets = append(targets, MetaTarget{ItemType: irodsclient_types.IRODSCollectionMetaItemType, Name: collection.Path})
+	}
+
+	for _, dataObject := range dataObjects {
+		targets = append(targets, MetaTarget{ItemType: irodsclient_types.IRODSDataObjectMetaItemType, Name: dataObject.Path})
+	}
+
+	sort.SliceStable(targets, func(i int, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+
+	return targets, nil
+}
+
+func requestModifyMetadata(conn *irodsclient_conn.IRODSConnection, request *irodsclient_message.IRODSMessageModifyMetadataRequest) error {
+	if conn == nil || !conn.IsConnected() {
+		return xerrors.Errorf("connection is nil or disconnected")
+	}
+
+	// lock the connection
+	conn.Lock()
+	defer conn.Unlock()
+
+	response := irodsclient_message.IRODSMessageModifyMetadataResponse{}
+	return conn.RequestAndCheck(request, &response, nil)
+}
+
+// AddMetadata adds an AVU to the target
+func AddMetadata(conn *irodsclient_conn.IRODSConnection, target MetaTarget, attribute string, value string, unit string) error {
+	meta := &irodsclient_types.IRODSMeta{
+		Name:  attribute,
+		Value: value,
+		Units: unit,
+	}
+
+	request := irodsclient_message.NewIRODSMessageAddMetadataRequest(target.ItemType, target.Name, meta)
+	err := requestModifyMetadata(conn, request)
+	if err != nil {
+		return xerrors.Errorf("failed to add metadata to %q (attr %q, value %q, unit %q): %w", target.Name, attribute, value, unit, err)
+	}
+
+	return nil
+}
+
+// SetMetadata replaces all values of the attribute of the target with the given value in a single request
+// the AVU is added if the target does not have the attribute
+func SetMetadata(conn *irodsclient_conn.IRODSConnection, target MetaTarget, attribute string, value string, unit string) error {
+	meta := &irodsclient_types.IRODSMeta{
+		Name:  attribute,
+		Value: value,
+		Units: unit,
+	}
+
+	request := irodsclient_message.NewIRODSMessageSetMetadataRequest(target.ItemType, target.Name, meta)
+	err := requestModifyMetadata(conn, request)
+	if err != nil {
+		return xerrors.Errorf("failed to set metadata of %q (attr %q, value %q, unit %q): %w", target.Name, attribute, value, unit, err)
+	}
+
+	return nil
+}
+
+// ModifyMetadata changes value and unit of an AVU of the target in a single request
+func ModifyMetadata(conn *irodsclient_conn.IRODSConnection, target MetaTarget, meta *irodsclient_types.IRODSMeta, newValue string, newUnit string) error {
+	// changes are given with prefixes, "v:" for value and "u:" for unit
+	changes := []string{}
+	if newValue != meta.Value {
+		changes = append(changes, fmt.Sprintf("v:%s", newValue))
+	}
+
+	if newUnit != meta.Units {
+		changes = append(changes, fmt.Sprintf("u:%s", newUnit))
+	}
+
+	if len(changes) == 0 {
+		// nothing to change
+		return nil
+	}
+
+	// the unit is omitted if empty, then changes start from the unit argument
+	if len(meta.Units) > 0 {
+		changes = append([]string{meta.Units}, changes...)
+	}
+
+	args := make([]string, 4)
+	copy(args, changes)
+
+	request := &irodsclient_message.IRODSMessageModifyMetadataRequest{
+		Operation:    "mod",
+		ItemType:     string(target.ItemType),
+		ItemName:     target.Name,
+		AttrName:     meta.Name,
+		AttrValue:    meta.Value,
+		AttrUnits:    args[0],
+		NewAttrName:  args[1],
+		NewAttrValue: args[2],
+		NewAttrUnits: args[3],
+		KeyVals: irodsclient_message.IRODSMessageSSKeyVal{
+			Length: 0,
+		},
+	}
+
+	err := requestModifyMetadata(conn, request)
+	if err != nil {
+		return xerrors.Errorf("failed to modify metadata %d of %q (attr %q, value %q, unit %q): %w", meta.AVUID, target.Name, meta.Name, newValue, newUnit, err)
+	}
+
+	return nil
+}
+
+// RemoveMetadata removes an AVU from the target by its ID
+func RemoveMetadata(conn *irodsclient_conn.IRODSConnection, target MetaTarget, avuID int64) error {
+	request := irodsclient_message.NewIRODSMessageRemoveMetadataByIDRequest(target.ItemType, target.Name, avuID)
+	err := requestModifyMetadata(conn, request)
+	if err != nil {
+		return xerrors.Errorf("failed to delete metadata %d from %q: %w", avuID, target.Name, err)
+	}
+
+	return nil
+}
+
+// RemoveMetadataByValue removes an AVU from the target by its attribute, value and unit
+// this is used to remove AVUs whose IDs are not known, e.g., AVUs just added
+func RemoveMetadataByValue(conn *irodsclient_conn.IRODSConnection, target MetaTarget, attribute string, value string, unit string) error {
+	meta := &irodsclient_types.IRODSMeta{
+		Name:  attribute,
+		Value: value,
+		Units: unit,
+	}
+
+	request := irodsclient_message.NewIRODSMessageRemoveMetadataRequest(target.ItemType, target.Name, meta)
+	err := requestModifyMetadata(conn, request)
+	if err != nil {
+		return xerrors.Errorf("failed to delete metadata from %q (attr %q, value %q, unit %q): %w", target.Name, attribute, value, unit, err)
+	}
+
+	return nil
+}
+
+// MetaMatchCondition selects AVUs by attribute, and optionally by value and unit
+// values are SQL like patterns if Wildcard is set, % for any string and _ for any character
+type MetaMatchCondition struct {
+	Attribute string
+	Value     string
+	HasValue  bool
+	Unit      string
+	HasUnit   bool
+	Wildcard  bool
+}
+
+func (cond *MetaMatchCondition) matchString(pattern string, value string) bool {
+	if cond.Wildcard {
+		return matchLikePattern(pattern, value)
+	}
+
+	return pattern == value
+}
+
+// Match checks if the AVU satisfies the condition
+func (cond *MetaMatchCondition) Match(meta *irodsclient_types.IRODSMeta) bool {
+	if !cond.matchString(cond.Attribute, meta.Name) {
+		return false
+	}
+
+	if cond.HasValue && !cond.matchString(cond.Value, meta.Value) {
+		return false
+	}
+
+	if cond.HasUnit && !cond.matchString(cond.Unit, meta.Units) {
+		return false
+	}
+
+	return true
+}
+
+// ListMetadata lists AVUs of the target
+func ListMetadata(filesystem *irodsclient_fs.FileSystem, target MetaTarget) ([]*irodsclient_types.IRODSMeta, error) {
+	var metas []*irodsclient_types.IRODSMeta
+	var err error
+
+	switch target.ItemType {
+	case irodsclient_types.IRODSUserMetaItemType:
+		metas, err = filesystem.ListUserMetadata(target.Name)
+	case irodsclient_types.IRODSResourceMetaItemType:
+		metas, err = filesystem.ListResourceMetadata(target.Name)
+	default:
+		metas, err = filesystem.ListMetadata(target.Name)
+	}
+
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list meta for %q: %w", target.Name, err)
+	}
+
+	return metas, nil
+}
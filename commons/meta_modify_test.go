@@ -0,0 +1,28 @@
+package commons
+
+import (
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaModify(t *testing.T) {
+	t.Run("test MetaMatchConditionMatch", testMetaMatchConditionMatch)
+}
+
+func testMetaMatchConditionMatch(t *testing.T) {
+	meta := &irodsclient_types.IRODSMeta{Name: "sample_id", Value: "S001", Units: "lims"}
+
+	assert.True(t, (&MetaMatchCondition{Attribute: "sample_id"}).Match(meta))
+	assert.False(t, (&MetaMatchCondition{Attribute: "sample"}).Match(meta))
+
+	assert.True(t, (&MetaMatchCondition{Attribute: "sample_id", Value: "S001", HasValue: true}).Match(meta))
+	assert.False(t, (&MetaMatchCondition{Attribute: "sample_id", Value: "S002", HasValue: true}).Match(meta))
+	assert.False(t, (&MetaMatchCondition{Attribute: "sample_id", Value: "S001", HasValue: true, Unit: "", HasUnit: true}).Match(meta))
+
+	assert.True(t, (&MetaMatchCondition{Attribute: "sample%", Wildcard: true}).Match(meta))
+	assert.True(t, (&MetaMatchCondition{Attribute: "%", Value: "S00_", HasValue: true, Wildcard: true}).Match(meta))
+	assert.False(t, (&MetaMatchCondition{Attribute: "sample%", Value: "S1%", HasValue: true, Wildcard: true}).Match(meta))
+	assert.False(t, (&MetaMatchCondition{Attribute: "sample%"}).Match(meta))
+}
@@ -0,0 +1,271 @@
+package commons
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	"golang.org/x/xerrors"
+)
+
+type MetaQueryTarget string
+
+const (
+	MetaQueryTargetDataObject MetaQueryTarget = "data_object"
+	MetaQueryTargetCollection MetaQueryTarget = "collection"
+	MetaQueryTargetUser       MetaQueryTarget = "user"
+	MetaQueryTargetResource   MetaQueryTarget = "resource"
+)
+
+type MetaQueryOperator string
+
+const (
+	MetaQueryOperatorEqual       MetaQueryOperator = "="
+	MetaQueryOperatorLike        MetaQueryOperator = "like"
+	MetaQueryOperatorLessThan    MetaQueryOperator = "<"
+	MetaQueryOperatorGreaterThan MetaQueryOperator = ">"
+	MetaQueryOperatorBetween     MetaQueryOperator = "between"
+)
+
+// MetaQueryCondition is a condition on values of an attribute
+// Values has two values, lower and upper bounds, for between
+type MetaQueryCondition struct {
+	Attribute string
+	Operator  MetaQueryOperator
+	Values    []string
+}
+
+// ParseMetaQueryConditions parses "attr op value [and attr op value ...]"
+// between takes two values, "attr between lower upper"
+func ParseMetaQueryConditions(args []string) ([]MetaQueryCondition, error) {
+	conditions := []MetaQueryCondition{}
+
+	idx := 0
+	for {
+		if len(args)-idx < 3 {
+			return nil, xerrors.Errorf("failed to parse condition %q, must be in 'attr op value' form", strings.Join(args[idx:], " "))
+		}
+
+		condition := MetaQueryCondition{
+			Attribute: args[idx],
+			Operator:  MetaQueryOperator(strings.ToLower(args[idx+1])),
+		}
+		idx += 2
+
+		switch condition.Operator {
+		case MetaQueryOperatorEqual, MetaQueryOperatorLike, MetaQueryOperatorLessThan, MetaQueryOperatorGreaterThan:
+			condition.Values = []string{args[idx]}
+			idx++
+		case MetaQueryOperatorBetween:
+			if len(args)-idx < 2 {
+				return nil, xerrors.Errorf("failed to parse condition on %q, between requires two values", condition.Attribute)
+			}
+			condition.Values = []string{args[idx], args[idx+1]}
+			idx += 2
+		default:
+			return nil, xerrors.Errorf("unknown operator %q, must be one of =, like, <, > or between", args[idx-1])
+		}
+
+		conditions = append(conditions, condition)
+
+		if idx == len(args) {
+			return conditions, nil
+		}
+
+		if !strings.EqualFold(args[idx], "and") {
+			return nil, xerrors.Errorf("failed to parse conditions, expected 'and' but got %q", args[idx])
+		}
+		idx++
+	}
+}
+
+// compareMetaValues compares values as numbers if both are numbers, otherwise as strings
+func compareMetaValues(a string, b string) int {
+	aNum, aErr := strconv.ParseFloat(a, 64)
+	bNum, bErr := strconv.ParseFloat(b, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case aNum < bNum:
+			return -1
+		case aNum > bNum:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(a, b)
+}
+
+// matchLikePattern checks if the value matches SQL like pattern, % for any string and _ for any character
+func matchLikePattern(pattern string, value string) bool {
+	expr := strings.Builder{}
+	expr.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			expr.WriteString(".*")
+		case '_':
+			expr.WriteString(".")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expr.WriteString("$")
+
+	matched, err := regexp.MatchString(expr.String(), value)
+	if err != nil {
+		return false
+	}
+	return matched
+}
+
+// Match checks if the value satisfies the condition
+func (cond MetaQueryCondition) Match(value string) bool {
+	switch cond.Operator {
+	case MetaQueryOperatorEqual:
+		return value == cond.Values[0]
+	case MetaQueryOperatorLike:
+		return matchLikePattern(cond.Values[0], value)
+	case MetaQueryOperatorLessThan:
+		return compareMetaValues(value, cond.Values[0]) < 0
+	case MetaQueryOperatorGreaterThan:
+		return compareMetaValues(value, cond.Values[0]) > 0
+	case MetaQueryOperatorBetween:
+		return compareMetaValues(value, cond.Values[0]) >= 0 && compareMetaValues(value, cond.Values[1]) <= 0
+	default:
+		return false
+	}
+}
+
+// getValueQueryCondition returns a query condition on values
+// range conditions are checked on results only, as iCAT compares values as strings
+func (cond MetaQueryCondition) getValueQueryCondition() (string, error) {
+	switch cond.Operator {
+	case MetaQueryOperatorEqual, MetaQueryOperatorLike:
+		if !isQuerySafe(cond.Values[0]) {
+			return "", xerrors.Errorf("value %q containing a single quote is not supported", cond.Values[0])
+		}
+		return fmt.Sprintf("%s '%s'", cond.Operator, cond.Values[0]), nil
+	default:
+		return "", nil
+	}
+}
+
+// metaQueryColumns is a set of columns to query metadata of a target type
+type metaQueryColumns struct {
+	selects         []irodsclient_common.ICATColumnNumber
+	attributeColumn irodsclient_common.ICATColumnNumber
+	valueColumn     irodsclient_common.ICATColumnNumber
+	getName         func(values map[irodsclient_common.ICATColumnNumber]string) string
+}
+
+func getMetaQueryColumns(target MetaQueryTarget) (metaQueryColumns, error) {
+	switch target {
+	case MetaQueryTargetDataObject:
+		return metaQueryColumns{
+			selects:         []irodsclient_common.ICATColumnNumber{irodsclient_common.ICAT_COLUMN_COLL_NAME, irodsclient_common.ICAT_COLUMN_DATA_NAME},
+			attributeColumn: irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_NAME,
+			valueColumn:     irodsclient_common.ICAT_COLUMN_META_DATA_ATTR_VALUE,
+			getName: func(values map[irodsclient_common.ICATColumnNumber]string) string {
+				return path.Join(values[irodsclient_common.ICAT_COLUMN_COLL_NAME], values[irodsclient_common.ICAT_COLUMN_DATA_NAME])
+			},
+		}, nil
+	case MetaQueryTargetCollection:
+		return metaQueryColumns{
+			selects:         []irodsclient_common.ICATColumnNumber{irodsclient_common.ICAT_COLUMN_COLL_NAME},
+			attributeColumn: irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_NAME,
+			valueColumn:     irodsclient_common.ICAT_COLUMN_META_COLL_ATTR_VALUE,
+			getName: func(values map[irodsclient_common.ICATColumnNumber]string) string {
+				return values[irodsclient_common.ICAT_COLUMN_COLL_NAME]
+			},
+		}, nil
+	case MetaQueryTargetUser:
+		return metaQueryColumns{
+			selects:         []irodsclient_common.ICATColumnNumber{irodsclient_common.ICAT_COLUMN_USER_NAME},
+			attributeColumn: irodsclient_common.ICAT_COLUMN_META_USER_ATTR_NAME,
+			valueColumn:     irodsclient_common.ICAT_COLUMN_META_USER_ATTR_VALUE,
+			getName: func(values map[irodsclient_common.ICATColumnNumber]string) string {
+				return values[irodsclient_common.ICAT_COLUMN_USER_NAME]
+			},
+		}, nil
+	case MetaQueryTargetResource:
+		return metaQueryColumns{
+			selects:         []irodsclient_common.ICATColumnNumber{irodsclient_common.ICAT_COLUMN_R_RESC_NAME},
+			attributeColumn: irodsclient_common.ICAT_COLUMN_META_RESC_ATTR_NAME,
+			valueColumn:     irodsclient_common.ICAT_COLUMN_META_RESC_ATTR_VALUE,
+			getName: func(values map[irodsclient_common.ICATColumnNumber]string) string {
+				return values[irodsclient_common.ICAT_COLUMN_R_RESC_NAME]
+			},
+		}, nil
+	default:
+		return metaQueryColumns{}, xerrors.Errorf("unknown metadata query target %q", target)
+	}
+}
+
+// QueryMeta returns sorted paths of data objects or collections, or names of users or resources that satisfy all conditions
+// each condition is queried separately and results are intersected, as an entry has one row per AVU
+func QueryMeta(conn *irodsclient_conn.IRODSConnection, target MetaQueryTarget, conditions []MetaQueryCondition) ([]string, error) {
+	columns, err := getMetaQueryColumns(target)
+	if err != nil {
+		return nil, err
+	}
+
+	var matched map[string]bool
+	for _, condition := range conditions {
+		if !isQuerySafe(condition.Attribute) {
+			return nil, xerrors.Errorf("attribute %q containing a single quote is not supported", condition.Attribute)
+		}
+
+		valueCondition, err := condition.getValueQueryCondition()
+		if err != nil {
+			return nil, err
+		}
+
+		selects := map[irodsclient_common.ICATColumnNumber]int{
+			columns.valueColumn: icatSelectNormal,
+		}
+		for _, column := range columns.selects {
+			selects[column] = icatSelectNormal
+		}
+
+		queryConditions := map[irodsclient_common.ICATColumnNumber]string{
+			columns.attributeColumn: fmt.Sprintf("= '%s'", condition.Attribute),
+			columns.valueColumn:     valueCondition,
+		}
+
+		conditionMatched := map[string]bool{}
+		err = runICATQuery(conn, selects, queryConditions, func(values map[irodsclient_common.ICATColumnNumber]string) error {
+			if !condition.Match(values[columns.valueColumn]) {
+				return nil
+			}
+
+			name := columns.getName(values)
+			if matched == nil || matched[name] {
+				conditionMatched[name] = true
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, xerrors.Errorf("failed to query metadata %q: %w", condition.Attribute, err)
+		}
+
+		matched = conditionMatched
+		if len(matched) == 0 {
+			break
+		}
+	}
+
+	names := []string{}
+	for name := range matched {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,65 @@
+package commons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaQuery(t *testing.T) {
+	t.Run("test ParseMetaQueryConditions", testParseMetaQueryConditions)
+	t.Run("test MetaQueryConditionMatch", testMetaQueryConditionMatch)
+}
+
+func testParseMetaQueryConditions(t *testing.T) {
+	conditions, err := ParseMetaQueryConditions([]string{"study", "=", "ABC", "and", "quality", ">", "30", "AND", "date", "between", "20240101", "20241231"})
+	assert.NoError(t, err)
+	assert.Len(t, conditions, 3)
+	assert.Equal(t, MetaQueryCondition{Attribute: "study", Operator: MetaQueryOperatorEqual, Values: []string{"ABC"}}, conditions[0])
+	assert.Equal(t, MetaQueryOperatorGreaterThan, conditions[1].Operator)
+	assert.Equal(t, []string{"20240101", "20241231"}, conditions[2].Values)
+
+	conditions, err = ParseMetaQueryConditions([]string{"name", "LIKE", "sample%"})
+	assert.NoError(t, err)
+	assert.Equal(t, MetaQueryOperatorLike, conditions[0].Operator)
+
+	invalids := [][]string{
+		{"study", "="},
+		{"study", "!=", "ABC"},
+		{"study", "=", "ABC", "or", "quality", ">", "30"},
+		{"study", "=", "ABC", "and"},
+		{"date", "between", "20240101"},
+	}
+
+	for _, invalid := range invalids {
+		_, err = ParseMetaQueryConditions(invalid)
+		assert.Error(t, err, "%v", invalid)
+	}
+}
+
+func testMetaQueryConditionMatch(t *testing.T) {
+	greater := MetaQueryCondition{Attribute: "quality", Operator: MetaQueryOperatorGreaterThan, Values: []string{"30"}}
+	assert.True(t, greater.Match("100"))
+	assert.True(t, greater.Match("30.5"))
+	assert.False(t, greater.Match("4"))
+	assert.False(t, greater.Match("30"))
+
+	less := MetaQueryCondition{Attribute: "name", Operator: MetaQueryOperatorLessThan, Values: []string{"b"}}
+	assert.True(t, less.Match("abc"))
+	assert.False(t, less.Match("c"))
+
+	between := MetaQueryCondition{Attribute: "date", Operator: MetaQueryOperatorBetween, Values: []string{"20240101", "20241231"}}
+	assert.True(t, between.Match("20240101"))
+	assert.True(t, between.Match("20240615"))
+	assert.False(t, between.Match("20250101"))
+
+	like := MetaQueryCondition{Attribute: "name", Operator: MetaQueryOperatorLike, Values: []string{"sample_%.txt"}}
+	assert.True(t, like.Match("sample1.txt"))
+	assert.True(t, like.Match("sample12.txt"))
+	assert.False(t, like.Match("sample.txt"))
+	assert.False(t, like.Match("sample1xtxt"))
+
+	equal := MetaQueryCondition{Attribute: "study", Operator: MetaQueryOperatorEqual, Values: []string{"ABC"}}
+	assert.True(t, equal.Match("ABC"))
+	assert.False(t, equal.Match("abc"))
+}
@@ -0,0 +1,171 @@
+package commons
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"golang.org/x/xerrors"
+)
+
+const (
+	// MetaSidecarSuffix is a suffix of sidecar files that hold metadata of the file next to it, e.g., "data.txt.meta.json"
+	MetaSidecarSuffix string = ".meta"
+	// CollectionMetaSidecarName is a name of sidecar files that hold metadata of the directory, e.g., ".collection.meta.json"
+	CollectionMetaSidecarName string = ".collection" + MetaSidecarSuffix
+)
+
+var (
+	metaSidecarExtensions = []string{".json", ".csv", ".yaml", ".yml"}
+)
+
+// ParseMetaAVU parses metadata expression "attr=value[=unit]"
+func ParseMetaAVU(meta string) (PathMetaRecord, error) {
+	parts := strings.SplitN(meta, "=", 3)
+	if len(parts) < 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return PathMetaRecord{}, xerrors.Errorf("failed to parse metadata %q, must be in attr=value[=unit] form", meta)
+	}
+
+	record := PathMetaRecord{
+		Attribute: parts[0],
+		Value:     parts[1],
+		Operation: MetaOperationAdd,
+	}
+
+	if len(parts) == 3 {
+		record.Unit = parts[2]
+	}
+
+	return record, nil
+}
+
+// GetMetaAVUString returns metadata expression "attr=value[=unit]" of the record
+func GetMetaAVUString(record PathMetaRecord) string {
+	if len(record.Unit) > 0 {
+		return fmt.Sprintf("%s=%s=%s", record.Attribute, record.Value, record.Unit)
+	}
+
+	return fmt.Sprintf("%s=%s", record.Attribute, record.Value)
+}
+
+// GetMetaSidecarPath returns a path of the sidecar file of the file in the given format
+func GetMetaSidecarPath(p string, format OutputFormat) string {
+	return fmt.Sprintf("%s%s.%s", p, MetaSidecarSuffix, format)
+}
+
+// GetCollectionMetaSidecarPath returns a path of the sidecar file of the directory in the given format
+func GetCollectionMetaSidecarPath(dirPath string, format OutputFormat) string {
+	return filepath.Join(dirPath, fmt.Sprintf("%s.%s", CollectionMetaSidecarName, format))
+}
+
+// FindCollectionMetaSidecar returns a path of the sidecar file in the local directory, json, csv and yaml in order
+// returns empty string if the directory does not have a sidecar file
+func FindCollectionMetaSidecar(dirPath string) string {
+	for _, ext := range metaSidecarExtensions {
+		sidecarPath := filepath.Join(dirPath, CollectionMetaSidecarName+ext)
+		if ExistFile(sidecarPath) {
+			return sidecarPath
+		}
+	}
+
+	return ""
+}
+
+// FindMetaSidecar returns a path of the sidecar file next to the local file, json, csv and yaml in order
+// returns empty string if the file does not have a sidecar file
+func FindMetaSidecar(p string) string {
+	for _, ext := range metaSidecarExtensions {
+		sidecarPath := p + MetaSidecarSuffix + ext
+		if ExistFile(sidecarPath) {
+			return sidecarPath
+		}
+	}
+
+	return ""
+}
+
+// IsMetaSidecar checks if the local file is a sidecar file of another file next to it or of the directory
+func IsMetaSidecar(p string) bool {
+	lowerPath := strings.ToLower(p)
+	for _, ext := range metaSidecarExtensions {
+		if filepath.Base(lowerPath) == CollectionMetaSidecarName+ext {
+			return true
+		}
+
+		suffix := MetaSidecarSuffix + ext
+		if strings.HasSuffix(lowerPath, suffix) {
+			return ExistFile(p[:len(p)-len(suffix)])
+		}
+	}
+
+	return false
+}
+
+// ReadMetaSidecar reads metadata from the sidecar file
+// records have attribute, value and unit, path and operation in the file are ignored
+// csv rows without the header are in attribute, value and unit order
+func ReadMetaSidecar(sidecarPath string) ([]PathMetaRecord, error) {
+	format, err := GetMetaFormatFromPath(sidecarPath)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecarFile, err := os.Open(sidecarPath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open %q: %w", sidecarPath, err)
+	}
+	defer sidecarFile.Close()
+
+	records, err := readMetaRecords(sidecarFile, format, pathMetaCSVHeader[1:4])
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read metadata from %q: %w", sidecarPath, err)
+	}
+
+	for idx := range records {
+		record := &records[idx]
+
+		record.Path = ""
+		record.Operation = MetaOperationAdd
+
+		if len(record.Attribute) == 0 {
+			return nil, xerrors.Errorf("invalid record %d in %q: attribute is empty", idx+1, sidecarPath)
+		}
+
+		if len(record.Value) == 0 {
+			return nil, xerrors.Errorf("invalid record %d in %q: value is empty", idx+1, sidecarPath)
+		}
+	}
+
+	return records, nil
+}
+
+// WriteMetaSidecar writes metadata of the data object or the collection at the path to the sidecar file
+// records are in the same format as ReadMetaSidecar reads
+func WriteMetaSidecar(sidecarPath string, format OutputFormat, p string, metas []*irodsclient_types.IRODSMeta) error {
+	records := []PathMetaRecord{}
+	for _, meta := range metas {
+		records = append(records, PathMetaRecord{
+			Path:      p,
+			Attribute: meta.Name,
+			Value:     meta.Value,
+			Unit:      meta.Units,
+		})
+	}
+
+	SortPathMetaRecords(records)
+
+	sidecarFile, err := os.Create(sidecarPath)
+	if err != nil {
+		return xerrors.Errorf("failed to create %q: %w", sidecarPath, err)
+	}
+	defer sidecarFile.Close()
+
+	err = WriteRecords(sidecarFile, format, records)
+	if err != nil {
+		return xerrors.Errorf("failed to write metadata to %q: %w", sidecarPath, err)
+	}
+
+	return nil
+}
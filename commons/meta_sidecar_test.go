@@ -0,0 +1,123 @@
+package commons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetaSidecar(t *testing.T) {
+	t.Run("test ParseMetaAVU", testParseMetaAVU)
+	t.Run("test ReadMetaSidecar", testReadMetaSidecar)
+	t.Run("test CollectionMetaSidecar", testCollectionMetaSidecar)
+	t.Run("test MetaSidecarRoundTrip", testMetaSidecarRoundTrip)
+}
+
+func testParseMetaAVU(t *testing.T) {
+	record, err := ParseMetaAVU("study=ABC")
+	assert.NoError(t, err)
+	assert.Equal(t, PathMetaRecord{Attribute: "study", Value: "ABC", Operation: MetaOperationAdd}, record)
+	assert.Equal(t, "study=ABC", GetMetaAVUString(record))
+
+	record, err = ParseMetaAVU("length=10=m=x")
+	assert.NoError(t, err)
+	assert.Equal(t, "10", record.Value)
+	assert.Equal(t, "m=x", record.Unit)
+	assert.Equal(t, "length=10=m=x", GetMetaAVUString(record))
+
+	for _, invalid := range []string{"study", "=ABC", "study="} {
+		_, err = ParseMetaAVU(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func testReadMetaSidecar(t *testing.T) {
+	dir := t.TempDir()
+	dataPath := filepath.Join(dir, "data.txt")
+	err := os.WriteFile(dataPath, []byte("data"), 0644)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "", FindMetaSidecar(dataPath))
+
+	// csv without header
+	csvPath := GetMetaSidecarPath(dataPath, OutputFormatCSV)
+	err = os.WriteFile(csvPath, []byte("study,ABC\nlength,10,m\n"), 0644)
+	assert.NoError(t, err)
+
+	assert.Equal(t, csvPath, FindMetaSidecar(dataPath))
+	assert.True(t, IsMetaSidecar(csvPath))
+	assert.False(t, IsMetaSidecar(dataPath))
+	assert.False(t, IsMetaSidecar(filepath.Join(dir, "other.txt.meta.csv")))
+
+	records, err := ReadMetaSidecar(csvPath)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, PathMetaRecord{Attribute: "length", Value: "10", Unit: "m", Operation: MetaOperationAdd}, records[1])
+
+	// json takes precedence, path is ignored
+	jsonPath := GetMetaSidecarPath(dataPath, OutputFormatJSON)
+	err = os.WriteFile(jsonPath, []byte(`[{"path": "/zone/data.txt", "attribute": "study", "value": "ABC", "unit": ""}]`), 0644)
+	assert.NoError(t, err)
+
+	assert.Equal(t, jsonPath, FindMetaSidecar(dataPath))
+
+	records, err = ReadMetaSidecar(jsonPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []PathMetaRecord{{Attribute: "study", Value: "ABC", Operation: MetaOperationAdd}}, records)
+
+	// value is required
+	err = os.WriteFile(csvPath, []byte("attribute,value\nstudy,\n"), 0644)
+	assert.NoError(t, err)
+
+	_, err = ReadMetaSidecar(csvPath)
+	assert.Error(t, err)
+}
+
+func testCollectionMetaSidecar(t *testing.T) {
+	dir := t.TempDir()
+	assert.Equal(t, "", FindCollectionMetaSidecar(dir))
+
+	csvPath := filepath.Join(dir, ".collection.meta.csv")
+	err := os.WriteFile(csvPath, []byte("study,ABC\n"), 0644)
+	assert.NoError(t, err)
+
+	assert.Equal(t, csvPath, FindCollectionMetaSidecar(dir))
+	assert.True(t, IsMetaSidecar(csvPath))
+
+	// json takes precedence
+	jsonPath := filepath.Join(dir, ".collection.meta.json")
+	err = os.WriteFile(jsonPath, []byte(`[{"attribute": "study", "value": "ABC", "unit": ""}]`), 0644)
+	assert.NoError(t, err)
+
+	assert.Equal(t, jsonPath, FindCollectionMetaSidecar(dir))
+
+	// other hidden files are uploaded
+	assert.False(t, IsMetaSidecar(filepath.Join(dir, ".collection.json")))
+}
+
+func testMetaSidecarRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	metas := []*irodsclient_types.IRODSMeta{
+		{Name: "study", Value: "ABC"},
+		{Name: "length", Value: "10", Units: "m"},
+	}
+
+	for _, format := range []OutputFormat{OutputFormatJSON, OutputFormatCSV, OutputFormatYAML} {
+		sidecarPath := GetCollectionMetaSidecarPath(dir, format)
+		err := WriteMetaSidecar(sidecarPath, format, "/zone/dir", metas)
+		assert.NoError(t, err)
+		assert.True(t, IsMetaSidecar(sidecarPath))
+
+		records, err := ReadMetaSidecar(sidecarPath)
+		assert.NoError(t, err)
+		assert.Equal(t, []PathMetaRecord{
+			{Attribute: "length", Value: "10", Unit: "m", Operation: MetaOperationAdd},
+			{Attribute: "study", Value: "ABC", Operation: MetaOperationAdd},
+		}, records, format)
+	}
+
+	assert.Equal(t, GetCollectionMetaSidecarPath(dir, OutputFormatJSON), FindCollectionMetaSidecar(dir))
+}
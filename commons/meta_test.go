@@ -0,0 +1,83 @@
+package commons
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeta(t *testing.T) {
+	t.Run("test ReadPathMetaRecords", testReadPathMetaRecords)
+	t.Run("test PathMetaRecordRoundTrip", testPathMetaRecordRoundTrip)
+}
+
+func testReadPathMetaRecords(t *testing.T) {
+	// csv without header
+	records, err := ReadPathMetaRecords(strings.NewReader("/zone/a,attr1,val1,unit1\n/zone/b,attr2,val2\n"), OutputFormatCSV, MetaOperationAdd)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, PathMetaRecord{Path: "/zone/a", Attribute: "attr1", Value: "val1", Unit: "unit1", Operation: MetaOperationAdd}, records[0])
+	assert.Equal(t, "", records[1].Unit)
+
+	// csv with header in a different order
+	records, err = ReadPathMetaRecords(strings.NewReader("path,operation,attribute,value\n/zone/a,set,attr1,val1\n/zone/a,rm,attr2,\n"), OutputFormatCSV, MetaOperationAdd)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, MetaOperationSet, records[0].Operation)
+	assert.Equal(t, "attr1", records[0].Attribute)
+	assert.Equal(t, MetaOperationRemove, records[1].Operation)
+
+	// json
+	records, err = ReadPathMetaRecords(strings.NewReader(`[{"path": "/zone/a", "attribute": "attr1", "value": "val1", "unit": ""}]`), OutputFormatJSON, MetaOperationSet)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, MetaOperationSet, records[0].Operation)
+
+	// yaml
+	records, err = ReadPathMetaRecords(strings.NewReader("- path: /zone/a\n  attribute: attr1\n  value: val1\n  operation: remove\n"), OutputFormatYAML, MetaOperationAdd)
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, MetaOperationRemove, records[0].Operation)
+
+	// invalid
+	_, err = ReadPathMetaRecords(strings.NewReader("/zone/a,attr1,\n"), OutputFormatCSV, MetaOperationAdd)
+	assert.Error(t, err)
+
+	_, err = ReadPathMetaRecords(strings.NewReader("/zone/a,attr1,val1,unit1,move\n"), OutputFormatCSV, MetaOperationAdd)
+	assert.Error(t, err)
+
+	_, err = ReadPathMetaRecords(strings.NewReader("/zone/a,attr1,val1,unit1,add,extra\n"), OutputFormatCSV, MetaOperationAdd)
+	assert.Error(t, err)
+
+	_, err = ReadPathMetaRecords(strings.NewReader(`[{"path": "/zone/a", "attr": "attr1"}]`), OutputFormatJSON, MetaOperationAdd)
+	assert.Error(t, err)
+}
+
+func testPathMetaRecordRoundTrip(t *testing.T) {
+	InitTerminalOutput()
+	defer InitTerminalOutput()
+
+	records := []PathMetaRecord{
+		{Path: "/zone/a", Attribute: "attr1", Value: "val,1", Unit: ""},
+		{Path: "/zone/b", Attribute: "attr2", Value: "val2", Unit: "unit2"},
+	}
+
+	for _, format := range []OutputFormat{OutputFormatCSV, OutputFormatJSON, OutputFormatYAML} {
+		buffer := &bytes.Buffer{}
+		SetTerminalOutput(buffer)
+
+		assert.NoError(t, PrintRecords(format, records))
+
+		imported, err := ReadPathMetaRecords(buffer, format, MetaOperationAdd)
+		assert.NoError(t, err)
+		assert.Len(t, imported, len(records))
+
+		for idx := range records {
+			expected := records[idx]
+			expected.Operation = MetaOperationAdd
+			assert.Equal(t, expected, imported[idx], "format %s", format)
+		}
+	}
+}
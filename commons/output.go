@@ -0,0 +1,177 @@
+package commons
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"golang.org/x/xerrors"
+	"gopkg.in/yaml.v3"
+)
+
+type OutputFormat string
+
+const (
+	// format
+	OutputFormatTable OutputFormat = "table"
+	OutputFormatJSON  OutputFormat = "json"
+	OutputFormatYAML  OutputFormat = "yaml"
+	OutputFormatCSV   OutputFormat = "csv"
+)
+
+// GetOutputFormat returns OutputFormat from string
+func GetOutputFormat(format string) (OutputFormat, error) {
+	switch strings.ToLower(format) {
+	case "", string(OutputFormatTable):
+		return OutputFormatTable, nil
+	case string(OutputFormatJSON):
+		return OutputFormatJSON, nil
+	case string(OutputFormatYAML), "yml":
+		return OutputFormatYAML, nil
+	case string(OutputFormatCSV):
+		return OutputFormatCSV, nil
+	default:
+		return OutputFormatTable, xerrors.Errorf("unknown output format %q, must be one of json, yaml, csv or table", format)
+	}
+}
+
+// IsStructured returns true if the format is machine-readable
+func (format OutputFormat) IsStructured() bool {
+	return format == OutputFormatJSON || format == OutputFormatYAML || format == OutputFormatCSV
+}
+
+// OutputRecord is a record that can be printed in csv
+// a record may span multiple csv rows, e.g., a data object with replicas
+type OutputRecord interface {
+	GetCSVHeader() []string
+	GetCSVRows() [][]string
+}
+
+// PrintRecords prints records in the given format as a list
+func PrintRecords[T OutputRecord](format OutputFormat, records []T) error {
+	if records == nil {
+		// print an empty list rather than null
+		records = []T{}
+	}
+
+	switch format {
+	case OutputFormatJSON:
+		return printJSON(records)
+	case OutputFormatYAML:
+		return printYAML(records)
+	case OutputFormatCSV:
+		var record T
+		rows := [][]string{}
+		for _, record := range records {
+			rows = append(rows, record.GetCSVRows()...)
+		}
+		return printCSV(record.GetCSVHeader(), rows)
+	default:
+		return xerrors.Errorf("unsupported output format %q", format)
+	}
+}
+
+// PrintRecord prints a single record in the given format
+func PrintRecord[T OutputRecord](format OutputFormat, record T) error {
+	switch format {
+	case OutputFormatJSON:
+		return printJSON(record)
+	case OutputFormatYAML:
+		return printYAML(record)
+	case OutputFormatCSV:
+		return printCSV(record.GetCSVHeader(), record.GetCSVRows())
+	default:
+		return xerrors.Errorf("unsupported output format %q", format)
+	}
+}
+
+// WriteRecords writes records in the given format as a list to the writer
+func WriteRecords[T OutputRecord](writer io.Writer, format OutputFormat, records []T) error {
+	if records == nil {
+		// write an empty list rather than null
+		records = []T{}
+	}
+
+	switch format {
+	case OutputFormatJSON:
+		jsonBytes, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return xerrors.Errorf("failed to marshal to json: %w", err)
+		}
+
+		_, err = writer.Write(append(jsonBytes, '\n'))
+		if err != nil {
+			return xerrors.Errorf("failed to write json: %w", err)
+		}
+		return nil
+	case OutputFormatYAML:
+		yamlBytes, err := yaml.Marshal(records)
+		if err != nil {
+			return xerrors.Errorf("failed to marshal to yaml: %w", err)
+		}
+
+		_, err = writer.Write(yamlBytes)
+		if err != nil {
+			return xerrors.Errorf("failed to write yaml: %w", err)
+		}
+		return nil
+	case OutputFormatCSV:
+		var record T
+		csvWriter := csv.NewWriter(writer)
+
+		err := csvWriter.Write(record.GetCSVHeader())
+		if err != nil {
+			return xerrors.Errorf("failed to write csv header: %w", err)
+		}
+
+		for _, record := range records {
+			err = csvWriter.WriteAll(record.GetCSVRows())
+			if err != nil {
+				return xerrors.Errorf("failed to write csv rows: %w", err)
+			}
+		}
+		return nil
+	default:
+		return xerrors.Errorf("unsupported output format %q", format)
+	}
+}
+
+func printJSON(obj interface{}) error {
+	jsonBytes, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return xerrors.Errorf("failed to marshal to json: %w", err)
+	}
+
+	Printf("%s\n", string(jsonBytes))
+	return nil
+}
+
+func printYAML(obj interface{}) error {
+	yamlBytes, err := yaml.Marshal(obj)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal to yaml: %w", err)
+	}
+
+	Print(string(yamlBytes))
+	return nil
+}
+
+func printCSV(header []string, rows [][]string) error {
+	terminalOutput.Lock()
+	defer terminalOutput.Unlock()
+
+	writer := csv.NewWriter(terminalOutput.output)
+
+	err := writer.Write(header)
+	if err != nil {
+		return xerrors.Errorf("failed to write csv header: %w", err)
+	}
+
+	err = writer.WriteAll(rows)
+	if err != nil {
+		return xerrors.Errorf("failed to write csv rows: %w", err)
+	}
+
+	return nil
+}
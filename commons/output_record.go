@@ -0,0 +1,475 @@
+package commons
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+)
+
+const (
+	EntryRecordTypeCollection string = "collection"
+	EntryRecordTypeDataObject string = "data_object"
+)
+
+func makeCSVTimeString(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
+
+// ReplicaRecord is a replica of a data object for structured output
+type ReplicaRecord struct {
+	Number            int64     `json:"number" yaml:"number"`
+	Owner             string    `json:"owner" yaml:"owner"`
+	Status            string    `json:"status" yaml:"status"`
+	ResourceName      string    `json:"resource_name" yaml:"resource_name"`
+	ResourceHierarchy string    `json:"resource_hierarchy" yaml:"resource_hierarchy"`
+	PhysicalPath      string    `json:"physical_path" yaml:"physical_path"`
+	Checksum          string    `json:"checksum" yaml:"checksum"`
+	CreateTime        time.Time `json:"create_time" yaml:"create_time"`
+	ModifyTime        time.Time `json:"modify_time" yaml:"modify_time"`
+}
+
+// NewReplicaRecord creates a new ReplicaRecord
+func NewReplicaRecord(replica *irodsclient_types.IRODSReplica) ReplicaRecord {
+	checksum := ""
+	if replica.Checksum != nil {
+		checksum = replica.Checksum.IRODSChecksumString
+	}
+
+	return ReplicaRecord{
+		Number:            replica.Number,
+		Owner:             replica.Owner,
+		Status:            replica.Status,
+		ResourceName:      replica.ResourceName,
+		ResourceHierarchy: replica.ResourceHierarchy,
+		PhysicalPath:      replica.Path,
+		Checksum:          checksum,
+		CreateTime:        replica.CreateTime,
+		ModifyTime:        replica.ModifyTime,
+	}
+}
+
+// EntryRecord is a data object or a collection for structured output
+type EntryRecord struct {
+	Type          string          `json:"type" yaml:"type"`
+	ID            int64           `json:"id" yaml:"id"`
+	Path          string          `json:"path" yaml:"path"`
+	Name          string          `json:"name" yaml:"name"`
+	DecryptedName string          `json:"decrypted_name,omitempty" yaml:"decrypted_name,omitempty"`
+	Owner         string          `json:"owner" yaml:"owner"`
+	Size          int64           `json:"size" yaml:"size"`
+	DataType      string          `json:"data_type,omitempty" yaml:"data_type,omitempty"`
+	CreateTime    time.Time       `json:"create_time" yaml:"create_time"`
+	ModifyTime    time.Time       `json:"modify_time" yaml:"modify_time"`
+	ACL           []string        `json:"acl,omitempty" yaml:"acl,omitempty"`
+	Replicas      []ReplicaRecord `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+}
+
+// NewCollectionEntryRecord creates a new EntryRecord for a collection
+func NewCollectionEntryRecord(collection *irodsclient_types.IRODSCollection) EntryRecord {
+	return EntryRecord{
+		Type:       EntryRecordTypeCollection,
+		ID:         collection.ID,
+		Path:       collection.Path,
+		Name:       collection.Name,
+		Owner:      collection.Owner,
+		CreateTime: collection.CreateTime,
+		ModifyTime: collection.ModifyTime,
+	}
+}
+
+// NewDataObjectEntryRecord creates a new EntryRecord for a data object
+// owner and times are taken from replicas, replica details are included if withReplicas is set
+func NewDataObjectEntryRecord(object *irodsclient_types.IRODSDataObject, withReplicas bool) EntryRecord {
+	record := EntryRecord{
+		Type:     EntryRecordTypeDataObject,
+		ID:       object.ID,
+		Path:     object.Path,
+		Name:     object.Name,
+		Size:     object.Size,
+		DataType: object.DataType,
+	}
+
+	for idx, replica := range object.Replicas {
+		if idx == 0 {
+			record.Owner = replica.Owner
+			record.CreateTime = replica.CreateTime
+			record.ModifyTime = replica.ModifyTime
+		}
+
+		if replica.CreateTime.Before(record.CreateTime) {
+			record.CreateTime = replica.CreateTime
+		}
+
+		if replica.ModifyTime.After(record.ModifyTime) {
+			record.ModifyTime = replica.ModifyTime
+		}
+
+		if withReplicas {
+			record.Replicas = append(record.Replicas, NewReplicaRecord(replica))
+		}
+	}
+
+	return record
+}
+
+func (record EntryRecord) GetCSVHeader() []string {
+	return []string{
+		"type", "id", "path", "name", "decrypted_name", "owner", "size", "data_type", "create_time", "modify_time", "acl",
+		"replica_number", "replica_owner", "replica_status", "replica_resource_name", "replica_resource_hierarchy", "replica_physical_path", "replica_checksum", "replica_create_time", "replica_modify_time",
+	}
+}
+
+func (record EntryRecord) GetCSVRows() [][]string {
+	row := []string{
+		record.Type,
+		fmt.Sprintf("%d", record.ID),
+		record.Path,
+		record.Name,
+		record.DecryptedName,
+		record.Owner,
+		fmt.Sprintf("%d", record.Size),
+		record.DataType,
+		makeCSVTimeString(record.CreateTime),
+		makeCSVTimeString(record.ModifyTime),
+		strings.Join(record.ACL, " "),
+	}
+
+	if len(record.Replicas) == 0 {
+		return [][]string{append(row, "", "", "", "", "", "", "", "", "")}
+	}
+
+	// one row per replica
+	rows := [][]string{}
+	for _, replica := range record.Replicas {
+		replicaRow := append([]string{}, row...)
+		replicaRow = append(replicaRow,
+			fmt.Sprintf("%d", replica.Number),
+			replica.Owner,
+			replica.Status,
+			replica.ResourceName,
+			replica.ResourceHierarchy,
+			replica.PhysicalPath,
+			replica.Checksum,
+			makeCSVTimeString(replica.CreateTime),
+			makeCSVTimeString(replica.ModifyTime),
+		)
+		rows = append(rows, replicaRow)
+	}
+
+	return rows
+}
+
+// MetaRecord is a metadata (AVU) for structured output
+type MetaRecord struct {
+	ID         int64     `json:"id" yaml:"id"`
+	Name       string    `json:"name" yaml:"name"`
+	Value      string    `json:"value" yaml:"value"`
+	Units      string    `json:"units" yaml:"units"`
+	CreateTime time.Time `json:"create_time" yaml:"create_time"`
+	ModifyTime time.Time `json:"modify_time" yaml:"modify_time"`
+}
+
+// NewMetaRecord creates a new MetaRecord
+func NewMetaRecord(meta *irodsclient_types.IRODSMeta) MetaRecord {
+	return MetaRecord{
+		ID:         meta.AVUID,
+		Name:       meta.Name,
+		Value:      meta.Value,
+		Units:      meta.Units,
+		CreateTime: meta.CreateTime,
+		ModifyTime: meta.ModifyTime,
+	}
+}
+
+func (record MetaRecord) GetCSVHeader() []string {
+	return []string{"id", "name", "value", "units", "create_time", "modify_time"}
+}
+
+func (record MetaRecord) GetCSVRows() [][]string {
+	return [][]string{
+		{
+			fmt.Sprintf("%d", record.ID),
+			record.Name,
+			record.Value,
+			record.Units,
+			makeCSVTimeString(record.CreateTime),
+			makeCSVTimeString(record.ModifyTime),
+		},
+	}
+}
+
+// MetaQueryRecord is a data object, a collection, a user or a resource found by metadata query for structured output
+// Path is a name for users and resources
+type MetaQueryRecord struct {
+	Type string `json:"type" yaml:"type"`
+	Path string `json:"path" yaml:"path"`
+}
+
+func (record MetaQueryRecord) GetCSVHeader() []string {
+	return []string{"type", "path"}
+}
+
+func (record MetaQueryRecord) GetCSVRows() [][]string {
+	return [][]string{{record.Type, record.Path}}
+}
+
+// TicketRestrictionsRecord is restrictions of a ticket for structured output
+type TicketRestrictionsRecord struct {
+	AllowedHosts      []string `json:"allowed_hosts" yaml:"allowed_hosts"`
+	AllowedUserNames  []string `json:"allowed_user_names" yaml:"allowed_user_names"`
+	AllowedGroupNames []string `json:"allowed_group_names" yaml:"allowed_group_names"`
+}
+
+// TicketRecord is a ticket for structured output
+type TicketRecord struct {
+	ID             int64                     `json:"id" yaml:"id"`
+	Name           string                    `json:"name" yaml:"name"`
+	Type           string                    `json:"type" yaml:"type"`
+	Owner          string                    `json:"owner" yaml:"owner"`
+	OwnerZone      string                    `json:"owner_zone" yaml:"owner_zone"`
+	ObjectType     string                    `json:"object_type" yaml:"object_type"`
+	Path           string                    `json:"path" yaml:"path"`
+	UsesLimit      int64                     `json:"uses_limit" yaml:"uses_limit"`
+	UsesCount      int64                     `json:"uses_count" yaml:"uses_count"`
+	WriteFileLimit int64                     `json:"write_file_limit" yaml:"write_file_limit"`
+	WriteFileCount int64                     `json:"write_file_count" yaml:"write_file_count"`
+	WriteByteLimit int64                     `json:"write_byte_limit" yaml:"write_byte_limit"`
+	WriteByteCount int64                     `json:"write_byte_count" yaml:"write_byte_count"`
+	ExpirationTime *time.Time                `json:"expiration_time" yaml:"expiration_time"`
+	Restrictions   *TicketRestrictionsRecord `json:"restrictions,omitempty" yaml:"restrictions,omitempty"`
+}
+
+// NewTicketRecord creates a new TicketRecord, restrictions can be nil
+func NewTicketRecord(ticket *irodsclient_types.IRODSTicket, restrictions *irodsclient_fs.IRODSTicketRestrictions) TicketRecord {
+	record := TicketRecord{
+		ID:             ticket.ID,
+		Name:           ticket.Name,
+		Type:           string(ticket.Type),
+		Owner:          ticket.Owner,
+		OwnerZone:      ticket.OwnerZone,
+		ObjectType:     string(ticket.ObjectType),
+		Path:           ticket.Path,
+		UsesLimit:      ticket.UsesLimit,
+		UsesCount:      ticket.UsesCount,
+		WriteFileLimit: ticket.WriteFileLimit,
+		WriteFileCount: ticket.WriteFileCount,
+		WriteByteLimit: ticket.WriteByteLimit,
+		WriteByteCount: ticket.WriteByteCount,
+	}
+
+	if !ticket.ExpirationTime.IsZero() {
+		expirationTime := ticket.ExpirationTime
+		record.ExpirationTime = &expirationTime
+	}
+
+	if restrictions != nil {
+		record.Restrictions = &TicketRestrictionsRecord{
+			AllowedHosts:      append([]string{}, restrictions.AllowedHosts...),
+			AllowedUserNames:  append([]string{}, restrictions.AllowedUserNames...),
+			AllowedGroupNames: append([]string{}, restrictions.AllowedGroupNames...),
+		}
+	}
+
+	return record
+}
+
+func (record TicketRecord) GetCSVHeader() []string {
+	return []string{
+		"id", "name", "type", "owner", "owner_zone", "object_type", "path",
+		"uses_limit", "uses_count", "write_file_limit", "write_file_count", "write_byte_limit", "write_byte_count", "expiration_time",
+		"allowed_hosts", "allowed_user_names", "allowed_group_names",
+	}
+}
+
+func (record TicketRecord) GetCSVRows() [][]string {
+	expirationTime := ""
+	if record.ExpirationTime != nil {
+		expirationTime = makeCSVTimeString(*record.ExpirationTime)
+	}
+
+	// multiple values are separated by ';'
+	allowedHosts := ""
+	allowedUserNames := ""
+	allowedGroupNames := ""
+	if record.Restrictions != nil {
+		allowedHosts = strings.Join(record.Restrictions.AllowedHosts, ";")
+		allowedUserNames = strings.Join(record.Restrictions.AllowedUserNames, ";")
+		allowedGroupNames = strings.Join(record.Restrictions.AllowedGroupNames, ";")
+	}
+
+	return [][]string{
+		{
+			fmt.Sprintf("%d", record.ID),
+			record.Name,
+			record.Type,
+			record.Owner,
+			record.OwnerZone,
+			record.ObjectType,
+			record.Path,
+			fmt.Sprintf("%d", record.UsesLimit),
+			fmt.Sprintf("%d", record.UsesCount),
+			fmt.Sprintf("%d", record.WriteFileLimit),
+			fmt.Sprintf("%d", record.WriteFileCount),
+			fmt.Sprintf("%d", record.WriteByteLimit),
+			fmt.Sprintf("%d", record.WriteByteCount),
+			expirationTime,
+			allowedHosts,
+			allowedUserNames,
+			allowedGroupNames,
+		},
+	}
+}
+
+// ProcessRecord is an iRODS agent process for structured output
+type ProcessRecord struct {
+	ID            int64     `json:"id" yaml:"id"`
+	ProxyUser     string    `json:"proxy_user" yaml:"proxy_user"`
+	ProxyZone     string    `json:"proxy_zone" yaml:"proxy_zone"`
+	ClientUser    string    `json:"client_user" yaml:"client_user"`
+	ClientZone    string    `json:"client_zone" yaml:"client_zone"`
+	ClientAddress string    `json:"client_address" yaml:"client_address"`
+	ClientProgram string    `json:"client_program" yaml:"client_program"`
+	ServerAddress string    `json:"server_address" yaml:"server_address"`
+	StartTime     time.Time `json:"start_time" yaml:"start_time"`
+}
+
+// NewProcessRecord creates a new ProcessRecord
+func NewProcessRecord(process *irodsclient_types.IRODSProcess) ProcessRecord {
+	return ProcessRecord{
+		ID:            process.ID,
+		ProxyUser:     process.ProxyUser,
+		ProxyZone:     process.ProxyZone,
+		ClientUser:    process.ClientUser,
+		ClientZone:    process.ClientZone,
+		ClientAddress: process.ClientAddress,
+		ClientProgram: process.ClientProgram,
+		ServerAddress: process.ServerAddress,
+		StartTime:     process.StartTime,
+	}
+}
+
+func (record ProcessRecord) GetCSVHeader() []string {
+	return []string{"id", "proxy_user", "proxy_zone", "client_user", "client_zone", "client_address", "client_program", "server_address", "start_time"}
+}
+
+func (record ProcessRecord) GetCSVRows() [][]string {
+	return [][]string{
+		{
+			fmt.Sprintf("%d", record.ID),
+			record.ProxyUser,
+			record.ProxyZone,
+			record.ClientUser,
+			record.ClientZone,
+			record.ClientAddress,
+			record.ClientProgram,
+			record.ServerAddress,
+			makeCSVTimeString(record.StartTime),
+		},
+	}
+}
+
+// ProcessGroupRecord is a number of processes grouped by user or client program for structured output
+type ProcessGroupRecord struct {
+	ProxyUser     string `json:"proxy_user,omitempty" yaml:"proxy_user,omitempty"`
+	ClientUser    string `json:"client_user,omitempty" yaml:"client_user,omitempty"`
+	ClientProgram string `json:"client_program,omitempty" yaml:"client_program,omitempty"`
+	ProcessCount  int    `json:"process_count" yaml:"process_count"`
+}
+
+func (record ProcessGroupRecord) GetCSVHeader() []string {
+	return []string{"proxy_user", "client_user", "client_program", "process_count"}
+}
+
+func (record ProcessGroupRecord) GetCSVRows() [][]string {
+	return [][]string{
+		{
+			record.ProxyUser,
+			record.ClientUser,
+			record.ClientProgram,
+			fmt.Sprintf("%d", record.ProcessCount),
+		},
+	}
+}
+
+// ServerInfoRecord is iRODS server information for structured output
+type ServerInfoRecord struct {
+	ReleaseVersion string `json:"release_version" yaml:"release_version"`
+	APIVersion     string `json:"api_version" yaml:"api_version"`
+	Zone           string `json:"zone" yaml:"zone"`
+}
+
+func (record ServerInfoRecord) GetCSVHeader() []string {
+	return []string{"release_version", "api_version", "zone"}
+}
+
+func (record ServerInfoRecord) GetCSVRows() [][]string {
+	return [][]string{
+		{record.ReleaseVersion, record.APIVersion, record.Zone},
+	}
+}
+
+// EnvironmentRecord is iRODS environment for structured output
+type EnvironmentRecord struct {
+	SessionEnvironmentFile  string `json:"session_environment_file" yaml:"session_environment_file"`
+	EnvironmentFile         string `json:"environment_file" yaml:"environment_file"`
+	AuthenticationFile      string `json:"authentication_file" yaml:"authentication_file"`
+	Host                    string `json:"host" yaml:"host"`
+	Port                    int    `json:"port" yaml:"port"`
+	Zone                    string `json:"zone" yaml:"zone"`
+	Username                string `json:"username" yaml:"username"`
+	DefaultResource         string `json:"default_resource" yaml:"default_resource"`
+	DefaultHashScheme       string `json:"default_hash_scheme" yaml:"default_hash_scheme"`
+	AuthenticationScheme    string `json:"authentication_scheme" yaml:"authentication_scheme"`
+	ClientServerNegotiation string `json:"client_server_negotiation" yaml:"client_server_negotiation"`
+	ClientServerPolicy      string `json:"client_server_policy" yaml:"client_server_policy"`
+	SSLCACertificateFile    string `json:"ssl_ca_certificate_file" yaml:"ssl_ca_certificate_file"`
+	SSLCACertificatePath    string `json:"ssl_ca_certificate_path" yaml:"ssl_ca_certificate_path"`
+	SSLVerifyServer         string `json:"ssl_verify_server" yaml:"ssl_verify_server"`
+	EncryptionKeySize       int    `json:"encryption_key_size" yaml:"encryption_key_size"`
+	EncryptionAlgorithm     string `json:"encryption_algorithm" yaml:"encryption_algorithm"`
+	EncryptionSaltSize      int    `json:"encryption_salt_size" yaml:"encryption_salt_size"`
+	EncryptionNumHashRounds int    `json:"encryption_num_hash_rounds" yaml:"encryption_num_hash_rounds"`
+}
+
+func (record EnvironmentRecord) GetCSVHeader() []string {
+	return []string{
+		"session_environment_file", "environment_file", "authentication_file",
+		"host", "port", "zone", "username", "default_resource", "default_hash_scheme",
+		"authentication_scheme", "client_server_negotiation", "client_server_policy",
+		"ssl_ca_certificate_file", "ssl_ca_certificate_path", "ssl_verify_server",
+		"encryption_key_size", "encryption_algorithm", "encryption_salt_size", "encryption_num_hash_rounds",
+	}
+}
+
+func (record EnvironmentRecord) GetCSVRows() [][]string {
+	return [][]string{
+		{
+			record.SessionEnvironmentFile,
+			record.EnvironmentFile,
+			record.AuthenticationFile,
+			record.Host,
+			fmt.Sprintf("%d", record.Port),
+			record.Zone,
+			record.Username,
+			record.DefaultResource,
+			record.DefaultHashScheme,
+			record.AuthenticationScheme,
+			record.ClientServerNegotiation,
+			record.ClientServerPolicy,
+			record.SSLCACertificateFile,
+			record.SSLCACertificatePath,
+			record.SSLVerifyServer,
+			fmt.Sprintf("%d", record.EncryptionKeySize),
+			record.EncryptionAlgorithm,
+			fmt.Sprintf("%d", record.EncryptionSaltSize),
+			fmt.Sprintf("%d", record.EncryptionNumHashRounds),
+		},
+	}
+}
@@ -0,0 +1,97 @@
+package commons
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutput(t *testing.T) {
+	t.Run("test GetOutputFormat", testGetOutputFormat)
+	t.Run("test EntryRecord", testEntryRecord)
+	t.Run("test PrintRecords", testPrintRecords)
+}
+
+func newTestDataObject() *irodsclient_types.IRODSDataObject {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	return &irodsclient_types.IRODSDataObject{
+		ID:   1,
+		Path: "/zone/home/user/a.txt",
+		Name: "a.txt",
+		Size: 10,
+		Replicas: []*irodsclient_types.IRODSReplica{
+			{Number: 0, Owner: "user", Status: "1", ResourceName: "res1", CreateTime: t1, ModifyTime: t1},
+			{Number: 1, Owner: "user", Status: "0", ResourceName: "res2", CreateTime: t2, ModifyTime: t2},
+		},
+	}
+}
+
+func testGetOutputFormat(t *testing.T) {
+	format, err := GetOutputFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, OutputFormatTable, format)
+
+	format, err = GetOutputFormat("JSON")
+	assert.NoError(t, err)
+	assert.Equal(t, OutputFormatJSON, format)
+	assert.True(t, format.IsStructured())
+
+	_, err = GetOutputFormat("xml")
+	assert.Error(t, err)
+}
+
+func testEntryRecord(t *testing.T) {
+	object := newTestDataObject()
+
+	record := NewDataObjectEntryRecord(object, false)
+	assert.Equal(t, EntryRecordTypeDataObject, record.Type)
+	assert.Equal(t, "user", record.Owner)
+	assert.Equal(t, object.Replicas[0].CreateTime, record.CreateTime)
+	assert.Equal(t, object.Replicas[1].ModifyTime, record.ModifyTime)
+	assert.Empty(t, record.Replicas)
+	assert.Len(t, record.GetCSVRows(), 1)
+
+	record = NewDataObjectEntryRecord(object, true)
+	assert.Len(t, record.Replicas, 2)
+
+	rows := record.GetCSVRows()
+	assert.Len(t, rows, 2)
+	for _, row := range rows {
+		assert.Len(t, row, len(record.GetCSVHeader()))
+	}
+}
+
+func testPrintRecords(t *testing.T) {
+	InitTerminalOutput()
+	defer InitTerminalOutput()
+
+	buffer := &bytes.Buffer{}
+	SetTerminalOutput(buffer)
+
+	records := []EntryRecord{NewDataObjectEntryRecord(newTestDataObject(), true)}
+
+	assert.NoError(t, PrintRecords(OutputFormatJSON, records))
+
+	decoded := []map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(buffer.Bytes(), &decoded))
+	assert.Len(t, decoded, 1)
+	assert.Equal(t, "a.txt", decoded[0]["name"])
+	assert.Len(t, decoded[0]["replicas"], 2)
+
+	buffer.Reset()
+	assert.NoError(t, PrintRecords[EntryRecord](OutputFormatJSON, nil))
+	assert.Equal(t, "[]", strings.TrimSpace(buffer.String()))
+
+	buffer.Reset()
+	assert.NoError(t, PrintRecords(OutputFormatCSV, records))
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "type,id,path,name"))
+}
@@ -21,7 +21,8 @@ type ParallelJob struct {
 	threadsRequired int
 	progressUnit    progress.Units
 
-	done bool
+	attempt int
+	done    bool
 }
 
 func (job *ParallelJob) GetManager() *ParallelJobManager {
@@ -32,6 +33,16 @@ func (job *ParallelJob) Progress(processed int64, total int64, errored bool) {
 	job.manager.progress(job.name, processed, total, job.progressUnit, errored)
 }
 
+// GetAttempt returns the number of retries of the job, 0 for the first run
+func (job *ParallelJob) GetAttempt() int {
+	return job.attempt
+}
+
+// WillRetry checks if the job is retried when the current attempt fails with the error
+func (job *ParallelJob) WillRetry(err error) bool {
+	return job.manager.retryPolicy.WillRetry(job.attempt, err)
+}
+
 func (job *ParallelJob) Done() {
 	job.done = true
 }
@@ -45,7 +56,8 @@ func newParallelJob(manager *ParallelJobManager, index int64, name string, task
 		threadsRequired: threadsRequired,
 		progressUnit:    progressUnit,
 
-		done: false,
+		attempt: 0,
+		done:    false,
 	}
 }
 
@@ -59,6 +71,10 @@ type ParallelJobManager struct {
 	progressWriter          progress.Writer
 	progressTrackers        map[string]*progress.Tracker
 	progressTrackerCallback ProgressTrackerCallback
+	retryPolicy             *RetryPolicy
+	retryCallback           RetryCallback
+	continueOnError         bool
+	failures                []*JobFailure
 	lastError               error
 	mutex                   sync.RWMutex
 
@@ -82,6 +98,10 @@ func NewParallelJobManager(fs *irodsclient_fs.FileSystem, maxThreads int, showPr
 		progressWriter:          nil,
 		progressTrackers:        map[string]*progress.Tracker{},
 		progressTrackerCallback: nil,
+		retryPolicy:             nil,
+		retryCallback:           nil,
+		continueOnError:         false,
+		failures:                []*JobFailure{},
 		lastError:               nil,
 		mutex:                   sync.RWMutex{},
 		scheduleWait:            sync.WaitGroup{},
@@ -102,6 +122,40 @@ func (manager *ParallelJobManager) GetFilesystem() *irodsclient_fs.FileSystem {
 	return manager.filesystem
 }
 
+// SetRetryPolicy sets how failed jobs are retried
+func (manager *ParallelJobManager) SetRetryPolicy(policy *RetryPolicy) {
+	manager.retryPolicy = policy
+}
+
+// SetRetryCallback sets a callback called before a failed job is retried, the job name is given
+func (manager *ParallelJobManager) SetRetryCallback(callback RetryCallback) {
+	manager.retryCallback = callback
+}
+
+// SetContinueOnError sets whether to keep running other jobs when a job fails
+// errors are collected and returned at the end
+func (manager *ParallelJobManager) SetContinueOnError(continueOnError bool) {
+	manager.continueOnError = continueOnError
+}
+
+// AddFailure adds a failure found before a job is scheduled, e.g., while walking directories
+// it returns nil if it continues on error, or the error otherwise
+func (manager *ParallelJobManager) AddFailure(name string, err error) error {
+	if !manager.continueOnError {
+		return err
+	}
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	manager.failures = append(manager.failures, &JobFailure{
+		Path:  name,
+		Error: err,
+	})
+
+	return nil
+}
+
 func (manager *ParallelJobManager) getNextJobIndex() int64 {
 	idx := manager.nextJobIndex
 	manager.nextJobIndex++
@@ -114,6 +168,23 @@ func (manager *ParallelJobManager) progress(name string, processed int64, total
 	}
 }
 
+// runJob runs the job, and retries it on transient errors
+func (manager *ParallelJobManager) runJob(job *ParallelJob) error {
+	return manager.retryPolicy.Run(job.name, func(attempt int) error {
+		if attempt > 0 {
+			job.attempt = attempt
+			job.done = false
+
+			// start over with a new progress tracker
+			manager.mutex.Lock()
+			delete(manager.progressTrackers, job.name)
+			manager.mutex.Unlock()
+		}
+
+		return job.task(job)
+	}, manager.retryCallback)
+}
+
 func (manager *ParallelJobManager) Schedule(name string, task ParallelJobTask, threadsRequired int, progressUnit progress.Units) error {
 	manager.mutex.Lock()
 
@@ -159,6 +230,10 @@ func (manager *ParallelJobManager) Wait() error {
 		return manager.lastError
 	}
 
+	if len(manager.failures) > 0 {
+		return NewJobFailuresError(manager.failures)
+	}
+
 	if manager.jobsDoneCounter != manager.jobsScheduledCounter {
 		return xerrors.Errorf("jobs '%d/%d' were not completed!", manager.jobsDoneCounter, manager.jobsScheduledCounter)
 	}
@@ -186,9 +261,15 @@ func (manager *ParallelJobManager) startProgress() {
 					msg = GetShortPathMessage(name, messageWidth)
 				}
 
+				// negative total means the size is unknown
+				trackerTotal := total
+				if trackerTotal < 0 {
+					trackerTotal = 0
+				}
+
 				tracker = &progress.Tracker{
 					Message: msg,
-					Total:   total,
+					Total:   trackerTotal,
 					Units:   progressUnit,
 				}
 
@@ -204,7 +285,7 @@ func (manager *ParallelJobManager) startProgress() {
 
 			if errored {
 				tracker.MarkAsErrored()
-			} else if processed >= total {
+			} else if total >= 0 && processed >= total {
 				tracker.MarkAsDone()
 			}
 		}
@@ -277,12 +358,19 @@ func (manager *ParallelJobManager) Start() {
 				go func(pjob *ParallelJob) {
 					logger.Debugf("Run job %d, %q", pjob.index, pjob.name)
 
-					err := pjob.task(pjob)
+					err := manager.runJob(pjob)
 
 					if err != nil {
 						// mark error
 						manager.mutex.Lock()
-						manager.lastError = err
+						if manager.continueOnError {
+							manager.failures = append(manager.failures, &JobFailure{
+								Path:  pjob.name,
+								Error: err,
+							})
+						} else {
+							manager.lastError = err
+						}
 						manager.mutex.Unlock()
 
 						logger.Error(err)
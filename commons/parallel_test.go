@@ -0,0 +1,72 @@
+package commons
+
+import (
+	"os"
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/jedib0t/go-pretty/v6/progress"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestParallel(t *testing.T) {
+	t.Run("test AddFailure", testAddFailure)
+	t.Run("test JobFailuresSummary", testJobFailuresSummary)
+}
+
+func testAddFailure(t *testing.T) {
+	jobManager := NewParallelJobManager(nil, 1, false, false)
+	jobManager.SetContinueOnError(true)
+	jobManager.Start()
+
+	// failures found while walking directories do not stop other jobs
+	scanErr := xerrors.Errorf("failed to stat %q: %w", "/data/a", os.ErrPermission)
+	assert.NoError(t, jobManager.AddFailure("/data/a", scanErr))
+
+	failingTask := func(job *ParallelJob) error {
+		return irodsclient_types.NewFileNotFoundError("/data/b")
+	}
+
+	doneTask := func(job *ParallelJob) error {
+		job.Done()
+		return nil
+	}
+
+	assert.NoError(t, jobManager.Schedule("/data/b", failingTask, 1, progress.UnitsDefault))
+	assert.NoError(t, jobManager.Schedule("/data/c", doneTask, 1, progress.UnitsDefault))
+	jobManager.DoneScheduling()
+
+	err := jobManager.Wait()
+	assert.True(t, IsJobFailuresError(err))
+
+	var jobFailuresError *JobFailuresError
+	assert.True(t, xerrors.As(err, &jobFailuresError))
+
+	paths := []string{}
+	for _, failure := range jobFailuresError.Failures {
+		paths = append(paths, failure.Path)
+	}
+	assert.ElementsMatch(t, []string{"/data/a", "/data/b"}, paths)
+
+	// the error is given back if it does not continue on error
+	jobManager = NewParallelJobManager(nil, 1, false, false)
+	assert.Equal(t, scanErr, jobManager.AddFailure("/data/a", scanErr))
+}
+
+func testJobFailuresSummary(t *testing.T) {
+	failures := []*JobFailure{
+		{Path: "/data/c", Error: irodsclient_types.NewFileNotFoundError("/data/c")},
+		{Path: "/data/a", Error: xerrors.Errorf("failed to stat %q: %w", "/data/a", os.ErrPermission)},
+		{Path: "/data/b", Error: irodsclient_types.NewFileNotFoundError("/data/b")},
+	}
+
+	expected := "Failed to process 3 files!\n" +
+		"  file or directory not found (2):\n" +
+		"    - /data/b\n" +
+		"    - /data/c\n" +
+		"  permission denied (1):\n" +
+		"    - /data/a\n"
+
+	assert.Equal(t, expected, getJobFailuresSummary(failures))
+}
@@ -1,7 +1,9 @@
 package commons
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -102,6 +104,15 @@ func GetBasename(p string) string {
 	return p[idx2+1:]
 }
 
+// IsSubIRODSPath returns true if p is parent itself or under parent
+func IsSubIRODSPath(parent string, p string) bool {
+	if parent == "/" || parent == p {
+		return strings.HasPrefix(p, "/")
+	}
+
+	return strings.HasPrefix(p, strings.TrimSuffix(parent, "/")+"/")
+}
+
 // GetParentDirs returns all parent dirs
 func GetParentIRODSDirs(p string) []string {
 	parents := []string{}
@@ -343,3 +354,40 @@ func ResolveSymlink(p string) (string, error) {
 	}
 	return p, nil
 }
+
+// ReadPathList reads paths from a file, one per line, "-" for stdin
+// empty lines are ignored
+func ReadPathList(p string) ([]string, error) {
+	var reader io.Reader
+	if IsStreamPath(p) {
+		reader = os.Stdin
+	} else {
+		listPath := MakeLocalPath(p)
+
+		file, err := os.Open(listPath)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to open file %q: %w", listPath, err)
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	paths := []string{}
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(strings.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		paths = append(paths, line)
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read paths from %q: %w", p, err)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,149 @@
+package commons
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_message "github.com/cyverse/go-irodsclient/irods/message"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"golang.org/x/xerrors"
+)
+
+const (
+	PosixModeMetaName string = "gocommands::posix_mode"
+)
+
+// SetDataObjectModifyTime sets modify time of the data object
+func SetDataObjectModifyTime(filesystem *irodsclient_fs.FileSystem, irodsPath string, modTime time.Time) error {
+	conn, err := filesystem.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer filesystem.ReturnMetadataConnection(conn)
+
+	request := irodsclient_message.NewIRODSMessageTouchRequest(irodsPath, true, "")
+	request.Options["no_create"] = true
+	request.SetSecondsSinceEpoch(int(modTime.Unix()))
+
+	response := irodsclient_message.IRODSMessageTouchResponse{}
+
+	conn.Lock()
+	defer conn.Unlock()
+
+	err = conn.RequestAndCheck(request, &response, nil)
+	if err != nil {
+		if irodsclient_types.GetIRODSErrorCode(err) == irodsclient_common.CAT_NO_ROWS_FOUND {
+			return xerrors.Errorf("failed to find the data object for path %q: %w", irodsPath, irodsclient_types.NewFileNotFoundError(irodsPath))
+		} else if irodsclient_types.GetIRODSErrorCode(err) == irodsclient_common.SYS_UNMATCHED_API_NUM {
+			return xerrors.Errorf("failed to set modify time of %q: %w", irodsPath, irodsclient_types.NewAPINotSupportedError(irodsclient_common.TOUCH_APN))
+		}
+		return xerrors.Errorf("failed to set modify time of %q: %w", irodsPath, err)
+	}
+
+	return nil
+}
+
+// SetPosixModeMeta records the POSIX mode of a local file in the data object's metadata
+func SetPosixModeMeta(filesystem *irodsclient_fs.FileSystem, irodsPath string, mode os.FileMode) error {
+	metas, err := filesystem.ListMetadata(irodsPath)
+	if err != nil {
+		return xerrors.Errorf("failed to list metadata of %q: %w", irodsPath, err)
+	}
+
+	modeString := makePosixModeString(mode)
+
+	for _, meta := range metas {
+		if meta.Name == PosixModeMetaName {
+			if meta.Value == modeString {
+				// already set
+				return nil
+			}
+
+			err = filesystem.DeleteMetadata(irodsPath, meta.AVUID)
+			if err != nil {
+				return xerrors.Errorf("failed to delete metadata %q of %q: %w", PosixModeMetaName, irodsPath, err)
+			}
+		}
+	}
+
+	err = filesystem.AddMetadata(irodsPath, PosixModeMetaName, modeString, "")
+	if err != nil {
+		return xerrors.Errorf("failed to add metadata %q to %q: %w", PosixModeMetaName, irodsPath, err)
+	}
+
+	return nil
+}
+
+// GetPosixModeFromMeta returns the POSIX mode recorded in the data object's metadata
+func GetPosixModeFromMeta(filesystem *irodsclient_fs.FileSystem, irodsPath string) (os.FileMode, bool) {
+	metas, err := filesystem.ListMetadata(irodsPath)
+	if err != nil {
+		return 0, false
+	}
+
+	return findPosixModeMeta(metas)
+}
+
+// makePosixModeString returns the octal string of the permission bits, e.g., "0644"
+func makePosixModeString(mode os.FileMode) string {
+	return "0" + strconv.FormatUint(uint64(mode.Perm()), 8)
+}
+
+// findPosixModeMeta returns the POSIX mode recorded in the metadata
+func findPosixModeMeta(metas []*irodsclient_types.IRODSMeta) (os.FileMode, bool) {
+	for _, meta := range metas {
+		if meta.Name == PosixModeMetaName {
+			mode, err := strconv.ParseUint(strings.TrimSpace(meta.Value), 8, 32)
+			if err != nil {
+				return 0, false
+			}
+
+			return os.FileMode(mode).Perm(), true
+		}
+	}
+
+	return 0, false
+}
+
+// PreserveDataObjectAttributes sets modify time and POSIX mode of the data object from the local file
+func PreserveDataObjectAttributes(filesystem *irodsclient_fs.FileSystem, localPath string, irodsPath string) error {
+	stat, err := os.Stat(localPath)
+	if err != nil {
+		return xerrors.Errorf("failed to stat %q: %w", localPath, err)
+	}
+
+	err = SetPosixModeMeta(filesystem, irodsPath, stat.Mode())
+	if err != nil {
+		return err
+	}
+
+	return SetDataObjectModifyTime(filesystem, irodsPath, stat.ModTime())
+}
+
+// PreserveLocalFileAttributes sets mtime, atime and POSIX mode of the local file from the data object
+func PreserveLocalFileAttributes(filesystem *irodsclient_fs.FileSystem, entry *irodsclient_fs.Entry, localPath string) error {
+	mode, hasMode := GetPosixModeFromMeta(filesystem, entry.Path)
+	return setLocalFileAttributes(localPath, entry.ModifyTime, mode, hasMode)
+}
+
+// setLocalFileAttributes sets mtime and atime of the local file, and POSIX mode if given
+func setLocalFileAttributes(localPath string, modTime time.Time, mode os.FileMode, hasMode bool) error {
+	// iRODS does not track access time
+	err := os.Chtimes(localPath, modTime, modTime)
+	if err != nil {
+		return xerrors.Errorf("failed to change times of %q: %w", localPath, err)
+	}
+
+	if hasMode {
+		err = os.Chmod(localPath, mode)
+		if err != nil {
+			return xerrors.Errorf("failed to change mode of %q: %w", localPath, err)
+		}
+	}
+
+	return nil
+}
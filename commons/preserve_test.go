@@ -0,0 +1,76 @@
+package commons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreserve(t *testing.T) {
+	t.Run("test PosixModeString", testPosixModeString)
+	t.Run("test FindPosixModeMeta", testFindPosixModeMeta)
+	t.Run("test SetLocalFileAttributes", testSetLocalFileAttributes)
+}
+
+func testPosixModeString(t *testing.T) {
+	assert.Equal(t, "0644", makePosixModeString(0644))
+	assert.Equal(t, "0755", makePosixModeString(os.ModeDir|0755))
+	assert.Equal(t, "0600", makePosixModeString(0600))
+}
+
+func testFindPosixModeMeta(t *testing.T) {
+	metas := []*irodsclient_types.IRODSMeta{
+		{Name: "color", Value: "blue"},
+		{Name: PosixModeMetaName, Value: " 0750 "},
+	}
+
+	mode, ok := findPosixModeMeta(metas)
+	assert.True(t, ok)
+	assert.Equal(t, os.FileMode(0750), mode)
+
+	// the recorded string is parsed back to the same mode
+	mode, ok = findPosixModeMeta([]*irodsclient_types.IRODSMeta{
+		{Name: PosixModeMetaName, Value: makePosixModeString(0640)},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, os.FileMode(0640), mode)
+
+	_, ok = findPosixModeMeta([]*irodsclient_types.IRODSMeta{
+		{Name: PosixModeMetaName, Value: "rwxr-xr-x"},
+	})
+	assert.False(t, ok)
+
+	_, ok = findPosixModeMeta(metas[:1])
+	assert.False(t, ok)
+}
+
+func testSetLocalFileAttributes(t *testing.T) {
+	localPath := filepath.Join(t.TempDir(), "a.txt")
+	err := os.WriteFile(localPath, []byte("data"), 0644)
+	assert.NoError(t, err)
+
+	modTime := time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)
+	err = setLocalFileAttributes(localPath, modTime, 0600, true)
+	assert.NoError(t, err)
+
+	stat, err := os.Stat(localPath)
+	assert.NoError(t, err)
+	assert.True(t, modTime.Equal(stat.ModTime()))
+	assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+
+	// the mode is kept if not recorded
+	err = setLocalFileAttributes(localPath, modTime.Add(time.Hour), 0755, false)
+	assert.NoError(t, err)
+
+	stat, err = os.Stat(localPath)
+	assert.NoError(t, err)
+	assert.True(t, modTime.Add(time.Hour).Equal(stat.ModTime()))
+	assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+
+	err = setLocalFileAttributes(filepath.Join(t.TempDir(), "missing.txt"), modTime, 0600, true)
+	assert.Error(t, err)
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 
 	log "github.com/sirupsen/logrus"
@@ -14,13 +16,14 @@ var (
 )
 
 type TerminalWriter struct {
-	mutex sync.Mutex
+	output io.Writer
+	mutex  sync.Mutex
 }
 
 func (writer *TerminalWriter) Write(p []byte) (n int, err error) {
 	writer.mutex.Lock()
 	defer writer.mutex.Unlock()
-	return os.Stdout.Write(p)
+	return writer.output.Write(p)
 }
 
 func (writer *TerminalWriter) Lock() {
@@ -32,7 +35,18 @@ func (writer *TerminalWriter) Unlock() {
 }
 
 func InitTerminalOutput() {
-	terminalOutput = &TerminalWriter{}
+	terminalOutput = &TerminalWriter{
+		output: os.Stdout,
+	}
+}
+
+// SetTerminalOutput changes where messages and progress are written to
+// this is used to keep stdout clean when data is streamed to stdout
+func SetTerminalOutput(output io.Writer) {
+	terminalOutput.Lock()
+	defer terminalOutput.Unlock()
+
+	terminalOutput.output = output
 }
 
 func GetTerminalWriter() *TerminalWriter {
@@ -85,3 +99,42 @@ func PrintErrorf(format string, a ...any) (int, error) {
 
 	return n, err
 }
+
+// PrintJobFailures prints failed jobs grouped by error type
+func PrintJobFailures(failures []*JobFailure) {
+	PrintErrorf("%s", getJobFailuresSummary(failures))
+}
+
+// getJobFailuresSummary returns a summary of failed jobs grouped by error type, most frequent first
+func getJobFailuresSummary(failures []*JobFailure) string {
+	failuresByType := map[string][]string{}
+	errorTypes := []string{}
+
+	for _, failure := range failures {
+		errorType := GetErrorType(failure.Error)
+		if _, ok := failuresByType[errorType]; !ok {
+			errorTypes = append(errorTypes, errorType)
+		}
+
+		failuresByType[errorType] = append(failuresByType[errorType], failure.Path)
+	}
+
+	// most frequent first
+	sort.SliceStable(errorTypes, func(i int, j int) bool {
+		return len(failuresByType[errorTypes[i]]) > len(failuresByType[errorTypes[j]])
+	})
+
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("Failed to process %d files!\n", len(failures)))
+	for _, errorType := range errorTypes {
+		paths := failuresByType[errorType]
+		sort.Strings(paths)
+
+		sb.WriteString(fmt.Sprintf("  %s (%d):\n", errorType, len(paths)))
+		for _, path := range paths {
+			sb.WriteString(fmt.Sprintf("    - %s\n", path))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,183 @@
+package commons
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	"golang.org/x/xerrors"
+)
+
+type ProvenanceField string
+
+const (
+	ProvenanceFieldSourceHost     ProvenanceField = "source_host"
+	ProvenanceFieldSourcePath     ProvenanceField = "source_path"
+	ProvenanceFieldSourceMtime    ProvenanceField = "source_mtime"
+	ProvenanceFieldClientChecksum ProvenanceField = "client_checksum"
+	ProvenanceFieldClientVersion  ProvenanceField = "client_version"
+	ProvenanceFieldRunID          ProvenanceField = "run_id"
+
+	// ProvenanceAttributePrefix is a prefix of provenance metadata attributes, e.g., "gocommands::provenance::run_id"
+	ProvenanceAttributePrefix string = "gocommands::provenance::"
+)
+
+// GetProvenanceFields returns all provenance fields
+func GetProvenanceFields() []ProvenanceField {
+	return []ProvenanceField{
+		ProvenanceFieldSourceHost,
+		ProvenanceFieldSourcePath,
+		ProvenanceFieldSourceMtime,
+		ProvenanceFieldClientChecksum,
+		ProvenanceFieldClientVersion,
+		ProvenanceFieldRunID,
+	}
+}
+
+// GetProvenanceField returns ProvenanceField from string
+func GetProvenanceField(field string) (ProvenanceField, error) {
+	for _, provenanceField := range GetProvenanceFields() {
+		if strings.ToLower(strings.TrimSpace(field)) == string(provenanceField) {
+			return provenanceField, nil
+		}
+	}
+
+	return "", xerrors.Errorf("unknown provenance field %q, must be one of source_host, source_path, source_mtime, client_checksum, client_version or run_id", field)
+}
+
+// NewRunID returns a new ID that identifies an invocation, made of the current time and random bytes
+func NewRunID() (string, error) {
+	randomBytes := make([]byte, 4)
+	_, err := rand.Read(randomBytes)
+	if err != nil {
+		return "", xerrors.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("20060102T150405Z"), hex.EncodeToString(randomBytes)), nil
+}
+
+// Provenance makes provenance metadata of uploaded data objects
+// values shared by the whole invocation, such as the run ID, are determined when it is created
+type Provenance struct {
+	RunID  string
+	Host   string
+	Fields []ProvenanceField
+}
+
+// NewProvenance creates a new Provenance with a new run ID
+func NewProvenance(fields []ProvenanceField) (*Provenance, error) {
+	runID, err := NewRunID()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to create a run ID: %w", err)
+	}
+
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get hostname: %w", err)
+	}
+
+	return &Provenance{
+		RunID:  runID,
+		Host:   host,
+		Fields: fields,
+	}, nil
+}
+
+// GetMetaRecords returns provenance metadata of the data object uploaded from the local file
+// checksum is computed from the local file if not given, fields of stdin uploads not available are omitted
+func (provenance *Provenance) GetMetaRecords(localPath string, checksumAlgorithm irodsclient_types.ChecksumAlgorithm, checksum []byte) ([]PathMetaRecord, error) {
+	records := []PathMetaRecord{}
+
+	isStream := IsStreamPath(localPath)
+
+	for _, field := range provenance.Fields {
+		value := ""
+
+		switch field {
+		case ProvenanceFieldSourceHost:
+			value = provenance.Host
+		case ProvenanceFieldSourcePath:
+			if isStream {
+				continue
+			}
+
+			absPath, err := filepath.Abs(localPath)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to get absolute path of %q: %w", localPath, err)
+			}
+			value = absPath
+		case ProvenanceFieldSourceMtime:
+			if isStream {
+				continue
+			}
+
+			localStat, err := os.Stat(localPath)
+			if err != nil {
+				return nil, xerrors.Errorf("failed to stat %q: %w", localPath, err)
+			}
+			value = localStat.ModTime().UTC().Format(time.RFC3339)
+		case ProvenanceFieldClientChecksum:
+			if len(checksum) == 0 {
+				if isStream {
+					continue
+				}
+
+				checksumAlgorithm = irodsclient_types.ChecksumAlgorithmSHA256
+				localChecksum, err := irodsclient_util.HashLocalFile(localPath, string(checksumAlgorithm))
+				if err != nil {
+					return nil, xerrors.Errorf("failed to get hash of %q: %w", localPath, err)
+				}
+				checksum = localChecksum
+			}
+			value = fmt.Sprintf("%s:%s", checksumAlgorithm, hex.EncodeToString(checksum))
+		case ProvenanceFieldClientVersion:
+			value = GetClientVersion()
+		case ProvenanceFieldRunID:
+			value = provenance.RunID
+		}
+
+		records = append(records, PathMetaRecord{
+			Attribute: ProvenanceAttributePrefix + string(field),
+			Value:     value,
+			Operation: MetaOperationSet,
+		})
+	}
+
+	return records, nil
+}
+
+// Apply sets provenance metadata to the data object uploaded from the local file
+// each attribute is set in a single request, so provenance of earlier uploads is replaced
+func (provenance *Provenance) Apply(fs *irodsclient_fs.FileSystem, localPath string, irodsPath string, checksumAlgorithm irodsclient_types.ChecksumAlgorithm, checksum []byte) error {
+	records, err := provenance.GetMetaRecords(localPath, checksumAlgorithm, checksum)
+	if err != nil {
+		return err
+	}
+
+	conn, err := fs.GetMetadataConnection()
+	if err != nil {
+		return xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer fs.ReturnMetadataConnection(conn)
+
+	target := MetaTarget{
+		ItemType: irodsclient_types.IRODSDataObjectMetaItemType,
+		Name:     irodsPath,
+	}
+
+	for _, record := range records {
+		err = SetMetadata(conn, target, record.Attribute, record.Value, record.Unit)
+		if err != nil {
+			return xerrors.Errorf("failed to set provenance metadata: %w", err)
+		}
+	}
+
+	return nil
+}
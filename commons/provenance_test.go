@@ -0,0 +1,63 @@
+package commons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvenance(t *testing.T) {
+	t.Run("test GetProvenanceField", testGetProvenanceField)
+	t.Run("test ProvenanceMetaRecords", testProvenanceMetaRecords)
+}
+
+func testGetProvenanceField(t *testing.T) {
+	field, err := GetProvenanceField(" Run_ID ")
+	assert.NoError(t, err)
+	assert.Equal(t, ProvenanceFieldRunID, field)
+
+	_, err = GetProvenanceField("user")
+	assert.Error(t, err)
+
+	runID1, err := NewRunID()
+	assert.NoError(t, err)
+	runID2, err := NewRunID()
+	assert.NoError(t, err)
+	assert.NotEqual(t, runID1, runID2)
+}
+
+func testProvenanceMetaRecords(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "data.txt")
+	err := os.WriteFile(p, []byte("data"), 0644)
+	assert.NoError(t, err)
+
+	provenance, err := NewProvenance(GetProvenanceFields())
+	assert.NoError(t, err)
+
+	records, err := provenance.GetMetaRecords(p, "", nil)
+	assert.NoError(t, err)
+	assert.Len(t, records, 6)
+
+	values := map[string]string{}
+	for _, record := range records {
+		assert.Equal(t, MetaOperationSet, record.Operation)
+		values[record.Attribute] = record.Value
+	}
+
+	assert.Equal(t, p, values[ProvenanceAttributePrefix+"source_path"])
+	assert.Equal(t, provenance.RunID, values[ProvenanceAttributePrefix+"run_id"])
+	assert.Equal(t, GetClientVersion(), values[ProvenanceAttributePrefix+"client_version"])
+	// sha256 of "data"
+	assert.Equal(t, "SHA-256:3a6eb0790f39ac87c94f3856b2dd2c5d110e6811602261a9a923d3bb23adc8b7", values[ProvenanceAttributePrefix+"client_checksum"])
+
+	// checksum is given, fields not available for stdin are omitted
+	provenance.Fields = []ProvenanceField{ProvenanceFieldSourcePath, ProvenanceFieldSourceMtime, ProvenanceFieldClientChecksum}
+	records, err = provenance.GetMetaRecords(StreamPath, irodsclient_types.ChecksumAlgorithmMD5, []byte{0x01, 0xab})
+	assert.NoError(t, err)
+	assert.Equal(t, []PathMetaRecord{
+		{Attribute: ProvenanceAttributePrefix + "client_checksum", Value: "MD5:01ab", Operation: MetaOperationSet},
+	}, records)
+}
@@ -1,134 +1,157 @@
 package commons
 
 import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
 	"os"
-	"os/exec"
 	"strings"
+	"syscall"
 	"time"
 
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/xerrors"
 )
 
-func RunWithRetry(retry int, retryInterval int) error {
-	logger := log.WithFields(log.Fields{
-		"package":  "commons",
-		"function": "RunWithRetry",
-	})
+// RetryCallback is called before a failed job is retried
+type RetryCallback func(name string, attempt int, backoff time.Duration, err error)
 
-	var err error
-	for i := 0; i < retry; i++ {
-		logger.Infof("running child process for retry #%d", i)
-		err = runChild()
-		if err == nil {
-			logger.Debug("completed child process successfully")
-			return nil
-		}
-
-		logger.Errorf("%+v", err)
-		PrintErrorf("%+v\n", err)
-
-		logger.Errorf("Waiting %d seconds for next try...", retryInterval)
-		PrintErrorf("Waiting %d seconds for next try...", retryInterval)
+// RetryPolicy determines how many times and how long to wait before retrying a failed job
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
 
-		sleepTime := time.Duration(retryInterval * int(time.Second))
-		time.Sleep(sleepTime)
+// NewRetryPolicy creates a new RetryPolicy
+func NewRetryPolicy(maxRetries int, initialBackoff time.Duration, maxBackoff time.Duration) *RetryPolicy {
+	if initialBackoff <= 0 {
+		initialBackoff = RetryInitialBackoffDefault
 	}
 
-	if err != nil {
-		err = xerrors.Errorf("failed to run after %d retries, job failed: %w", retry, err)
-		logger.Errorf("%+v", err)
-		return err
+	if maxBackoff < initialBackoff {
+		maxBackoff = initialBackoff
 	}
 
-	logger.Info("completed child process successfully")
-	return nil
+	return &RetryPolicy{
+		MaxRetries:     maxRetries,
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     maxBackoff,
+	}
 }
 
-func runChild() error {
-	envManager := GetEnvironmentManager()
-	env := envManager.Environment
+// GetBackoff returns time to wait before the given retry attempt (starting from 1)
+// backoff grows exponentially up to MaxBackoff, a random jitter of up to half of it is subtracted
+func (policy *RetryPolicy) GetBackoff(attempt int) time.Duration {
+	backoff := policy.InitialBackoff
+	for i := 1; i < attempt && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
 
-	configTypeIn := &ConfigTypeIn{
-		Host:     env.Host,
-		Port:     env.Port,
-		Zone:     env.Zone,
-		Username: env.Username,
-		Password: envManager.Password,
+	if backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
 	}
 
-	configTypeInYamlBytes, err := configTypeIn.ToYAML()
-	if err != nil {
-		yamlErr := xerrors.Errorf("failed to get config yaml: %w", err)
-		return yamlErr
+	half := int64(backoff / 2)
+	if half <= 0 {
+		return backoff
 	}
 
-	bin := os.Args[0]
+	return time.Duration(half + rand.Int63n(half+1))
+}
 
-	newArgs := []string{}
-	hasForce := false
-	hasRetryChild := false
-	ignoreArgs := 0
-	for _, arg := range os.Args[1:] {
-		if ignoreArgs > 0 {
-			ignoreArgs--
-			continue
-		}
-		if strings.HasPrefix(arg, "--retry=") {
-			// ignore
-			continue
-		}
-		if arg == "--retry" {
-			ignoreArgs = 1
-			continue
+// Run runs the given function, and retries it if it fails with a transient error
+// callback is called before each retry
+func (policy *RetryPolicy) Run(name string, fn func(attempt int) error, callback RetryCallback) error {
+	logger := log.WithFields(log.Fields{
+		"package":  "commons",
+		"struct":   "RetryPolicy",
+		"function": "Run",
+	})
+
+	attempt := 0
+	for {
+		err := fn(attempt)
+		if err == nil {
+			return nil
 		}
-		if arg == "--force" || arg == "-f" {
-			hasForce = true
+
+		if !policy.WillRetry(attempt, err) {
+			if attempt > 0 {
+				return xerrors.Errorf("failed after %d retries: %w", attempt, err)
+			}
+			return err
 		}
-		if arg == "--retry_child" {
-			hasRetryChild = true
+
+		attempt++
+		backoff := policy.GetBackoff(attempt)
+
+		logger.WithError(err).Warnf("retrying %q (attempt %d/%d) after %s", name, attempt, policy.MaxRetries, backoff)
+
+		if callback != nil {
+			callback(name, attempt, backoff, err)
 		}
 
-		newArgs = append(newArgs, arg)
+		time.Sleep(backoff)
+	}
+}
+
+// WillRetry checks if a job failed with the error at the attempt is retried
+func (policy *RetryPolicy) WillRetry(attempt int, err error) bool {
+	return policy != nil && attempt < policy.MaxRetries && IsTransientError(err)
+}
+
+// IsTransientError checks if the given error is temporary, so the failed job can be retried
+// connection failures, exhausted connection pools and timeouts are transient
+// auth failures and missing files are not
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
 	}
 
-	if !hasRetryChild {
-		newArgs = append(newArgs, "--retry_child")
+	if irodsclient_types.IsAuthError(err) || irodsclient_types.IsConnectionConfigError(err) {
+		return false
 	}
-	if !hasForce {
-		newArgs = append(newArgs, "--force")
+
+	if irodsclient_types.IsFileNotFoundError(err) || irodsclient_types.IsFileAlreadyExistError(err) || irodsclient_types.IsCollectionNotEmptyError(err) {
+		return false
 	}
 
-	cmd := exec.Command(bin, newArgs...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	if irodsclient_types.IsTicketNotFoundError(err) || irodsclient_types.IsUserNotFoundError(err) {
+		return false
+	}
 
-	stdinPipe, err := cmd.StdinPipe()
-	if err != nil {
-		pipeErr := xerrors.Errorf("failed to get stdin pipe: %w", err)
-		return pipeErr
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrPermission) {
+		return false
 	}
 
-	// start
-	err = cmd.Start()
-	if err != nil {
-		cmdErr := xerrors.Errorf("failed to start the child process: %w", err)
-		return cmdErr
+	if irodsclient_types.IsConnectionError(err) || irodsclient_types.IsConnectionPoolFullError(err) {
+		return true
 	}
 
-	// send config to stdin
-	_, err = stdinPipe.Write(configTypeInYamlBytes)
-	if err != nil {
-		writeErr := xerrors.Errorf("failed to send config yaml to stdin pipe: %w", err)
-		return writeErr
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
 	}
 
-	stdinPipe.Close()
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, os.ErrDeadlineExceeded) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
 
-	err = cmd.Wait()
-	if err != nil {
-		cmdErr := xerrors.Errorf("failed to wait the child process: %w", err)
-		return cmdErr
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
 	}
-	return nil
+
+	// some errors are not wrapped, so check messages
+	msg := strings.ToLower(err.Error())
+	for _, transientMsg := range []string{"connection reset", "broken pipe", "i/o timeout", "timed out", "connection refused"} {
+		if strings.Contains(msg, transientMsg) {
+			return true
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,79 @@
+package commons
+
+import (
+	"syscall"
+	"testing"
+	"time"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestRetry(t *testing.T) {
+	t.Run("test TransientError", testTransientError)
+	t.Run("test Backoff", testBackoff)
+	t.Run("test Run", testRun)
+}
+
+func testTransientError(t *testing.T) {
+	assert.False(t, IsTransientError(nil))
+
+	assert.True(t, IsTransientError(xerrors.Errorf("failed to upload: %w", syscall.ECONNRESET)))
+	assert.True(t, IsTransientError(xerrors.Errorf("failed to get connection: %w", irodsclient_types.NewConnectionPoolFullError(10, 10))))
+	assert.True(t, IsTransientError(xerrors.Errorf("read tcp: i/o timeout")))
+
+	assert.False(t, IsTransientError(xerrors.Errorf("failed to stat: %w", irodsclient_types.NewFileNotFoundError("/zone/home/a"))))
+	assert.False(t, IsTransientError(xerrors.Errorf("unknown error")))
+}
+
+func testBackoff(t *testing.T) {
+	policy := NewRetryPolicy(5, 1*time.Second, 4*time.Second)
+
+	for attempt := 1; attempt <= 5; attempt++ {
+		backoff := policy.GetBackoff(attempt)
+		assert.LessOrEqual(t, backoff, 4*time.Second)
+		assert.GreaterOrEqual(t, backoff, 500*time.Millisecond)
+	}
+
+	assert.GreaterOrEqual(t, policy.GetBackoff(5), 2*time.Second)
+}
+
+func testRun(t *testing.T) {
+	policy := NewRetryPolicy(2, time.Millisecond, time.Millisecond)
+
+	// transient errors are retried
+	calls := 0
+	retries := 0
+	err := policy.Run("job", func(attempt int) error {
+		calls++
+		if attempt < 2 {
+			return syscall.ECONNRESET
+		}
+		return nil
+	}, func(name string, attempt int, backoff time.Duration, err error) {
+		retries++
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 2, retries)
+
+	// permanent errors are not retried
+	calls = 0
+	err = policy.Run("job", func(attempt int) error {
+		calls++
+		return irodsclient_types.NewFileNotFoundError("/zone/home/a")
+	}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+
+	// nil policy never retries
+	var nilPolicy *RetryPolicy
+	calls = 0
+	err = nilPolicy.Run("job", func(attempt int) error {
+		calls++
+		return syscall.ECONNRESET
+	}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,189 @@
+package commons
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"hash"
+	"hash/adler32"
+	"io"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	"github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"golang.org/x/xerrors"
+)
+
+// StreamPath is a path representing stdin or stdout
+const StreamPath string = "-"
+
+// IsStreamPath checks if the given path represents stdin or stdout
+func IsStreamPath(p string) bool {
+	return p == StreamPath
+}
+
+// UploadFromStream uploads data read from the reader to an iRODS data object
+// the total size is unknown until EOF, so the callback receives -1 as total until the stream ends
+// the hash of the data is computed while streaming as the stream cannot be read again
+// the result is returned on failure too, with the size uploaded so far
+func UploadFromStream(fs *irodsclient_fs.FileSystem, reader io.Reader, irodsPath string, resource string, bufferSize int, callback common.TrackerCallBack) (*irodsclient_fs.FileTransferResult, error) {
+	result := &irodsclient_fs.FileTransferResult{
+		IRODSPath: irodsPath,
+		LocalPath: StreamPath,
+		StartTime: time.Now(),
+	}
+
+	checksumAlgorithm := irodsclient_types.GetChecksumAlgorithm(GetAccount().DefaultHashScheme)
+	if checksumAlgorithm == irodsclient_types.ChecksumAlgorithmUnknown {
+		checksumAlgorithm = irodsclient_types.ChecksumAlgorithmMD5
+	}
+
+	hasher, err := newChecksumHash(checksumAlgorithm)
+	if err != nil {
+		result.EndTime = time.Now()
+		return result, err
+	}
+
+	handle, err := fs.CreateFile(irodsPath, resource, "w")
+	if err != nil {
+		result.EndTime = time.Now()
+		return result, xerrors.Errorf("failed to create a data object %q: %w", irodsPath, err)
+	}
+
+	processed, err := copyStream(io.MultiWriter(handle, hasher), reader, bufferSize, callback)
+	result.LocalSize = processed
+	if err != nil {
+		handle.Close()
+		result.EndTime = time.Now()
+		return result, xerrors.Errorf("failed to upload stream to a data object %q: %w", irodsPath, err)
+	}
+
+	err = handle.Close()
+	if err != nil {
+		result.EndTime = time.Now()
+		return result, xerrors.Errorf("failed to close a data object %q: %w", irodsPath, err)
+	}
+
+	if callback != nil {
+		callback(processed, processed)
+	}
+
+	result.IRODSSize = processed
+	result.CheckSumAlgorithm = checksumAlgorithm
+	result.LocalCheckSum = hasher.Sum(nil)
+	result.EndTime = time.Now()
+
+	return result, nil
+}
+
+// copyStream copies data from the reader to the writer until EOF, returns the size copied
+// the total size is unknown until EOF, so the callback receives -1 as total
+func copyStream(writer io.Writer, reader io.Reader, bufferSize int, callback common.TrackerCallBack) (int64, error) {
+	if bufferSize <= 0 {
+		bufferSize = StreamBufferSizeDefault
+	}
+
+	processed := int64(0)
+	buf := make([]byte, bufferSize)
+	for {
+		readLen, readErr := reader.Read(buf)
+		if readLen > 0 {
+			_, writeErr := writer.Write(buf[:readLen])
+			if writeErr != nil {
+				return processed, xerrors.Errorf("failed to write: %w", writeErr)
+			}
+
+			processed += int64(readLen)
+			if callback != nil {
+				callback(processed, -1)
+			}
+		}
+
+		if readErr != nil {
+			if readErr == io.EOF {
+				return processed, nil
+			}
+
+			return processed, xerrors.Errorf("failed to read from stream: %w", readErr)
+		}
+	}
+}
+
+// newChecksumHash returns a hash for the checksum algorithm
+func newChecksumHash(algorithm irodsclient_types.ChecksumAlgorithm) (hash.Hash, error) {
+	switch algorithm {
+	case irodsclient_types.ChecksumAlgorithmMD5:
+		return md5.New(), nil
+	case irodsclient_types.ChecksumAlgorithmADLER32:
+		return adler32.New(), nil
+	case irodsclient_types.ChecksumAlgorithmSHA1:
+		return sha1.New(), nil
+	case irodsclient_types.ChecksumAlgorithmSHA256:
+		return sha256.New(), nil
+	case irodsclient_types.ChecksumAlgorithmSHA512:
+		return sha512.New(), nil
+	default:
+		return nil, xerrors.Errorf("unknown checksum algorithm %q", algorithm)
+	}
+}
+
+// DownloadToStream downloads an iRODS data object and writes its content to the writer
+func DownloadToStream(fs *irodsclient_fs.FileSystem, irodsPath string, resource string, writer io.Writer, bufferSize int, callback common.TrackerCallBack) (*irodsclient_fs.FileTransferResult, error) {
+	if bufferSize <= 0 {
+		bufferSize = StreamBufferSizeDefault
+	}
+
+	entry, err := fs.Stat(irodsPath)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to stat %q: %w", irodsPath, err)
+	}
+
+	result := &irodsclient_fs.FileTransferResult{
+		IRODSPath: irodsPath,
+		IRODSSize: entry.Size,
+		LocalPath: StreamPath,
+		StartTime: time.Now(),
+	}
+
+	handle, err := fs.OpenFile(irodsPath, resource, "r")
+	if err != nil {
+		return nil, xerrors.Errorf("failed to open a data object %q: %w", irodsPath, err)
+	}
+	defer handle.Close()
+
+	processed := int64(0)
+	buf := make([]byte, bufferSize)
+	for {
+		readLen, readErr := handle.Read(buf)
+		if readLen > 0 {
+			_, writeErr := writer.Write(buf[:readLen])
+			if writeErr != nil {
+				return nil, xerrors.Errorf("failed to write to stream: %w", writeErr)
+			}
+
+			processed += int64(readLen)
+			if callback != nil {
+				callback(processed, entry.Size)
+			}
+		}
+
+		if readErr != nil {
+			if readErr == io.EOF {
+				break
+			}
+
+			return nil, xerrors.Errorf("failed to read a data object %q: %w", irodsPath, readErr)
+		}
+	}
+
+	if callback != nil {
+		callback(processed, processed)
+	}
+
+	result.LocalSize = processed
+	result.EndTime = time.Now()
+
+	return result, nil
+}
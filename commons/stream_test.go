@@ -0,0 +1,71 @@
+package commons
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStream(t *testing.T) {
+	t.Run("test CopyStream", testCopyStream)
+	t.Run("test ChecksumHash", testChecksumHash)
+}
+
+func testCopyStream(t *testing.T) {
+	data := strings.Repeat("stream data ", 100)
+
+	totals := []int64{}
+	callback := func(processed int64, total int64) {
+		totals = append(totals, total)
+	}
+
+	writer := &bytes.Buffer{}
+	processed, err := copyStream(writer, strings.NewReader(data), 64, callback)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), processed)
+	assert.Equal(t, data, writer.String())
+
+	// the total size is unknown while streaming
+	for _, total := range totals {
+		assert.Equal(t, int64(-1), total)
+	}
+
+	// the size copied so far is returned on failure
+	writer.Reset()
+	processed, err = copyStream(writer, iotest.TimeoutReader(strings.NewReader(data)), 64, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int64(64), processed)
+}
+
+func testChecksumHash(t *testing.T) {
+	data := "stream data"
+
+	algorithms := []irodsclient_types.ChecksumAlgorithm{
+		irodsclient_types.ChecksumAlgorithmMD5,
+		irodsclient_types.ChecksumAlgorithmADLER32,
+		irodsclient_types.ChecksumAlgorithmSHA1,
+		irodsclient_types.ChecksumAlgorithmSHA256,
+		irodsclient_types.ChecksumAlgorithmSHA512,
+	}
+
+	for _, algorithm := range algorithms {
+		hasher, err := newChecksumHash(algorithm)
+		assert.NoError(t, err)
+
+		_, err = hasher.Write([]byte(data))
+		assert.NoError(t, err)
+
+		expected, err := irodsclient_util.HashBuffer(bytes.NewBufferString(data), string(algorithm))
+		assert.NoError(t, err)
+		assert.Equal(t, hex.EncodeToString(expected), hex.EncodeToString(hasher.Sum(nil)), "algorithm %q", algorithm)
+	}
+
+	_, err := newChecksumHash(irodsclient_types.ChecksumAlgorithmUnknown)
+	assert.Error(t, err)
+}
@@ -1,8 +1,10 @@
 package commons
 
 import (
+	"bufio"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	"time"
 
 	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
 	"golang.org/x/xerrors"
 )
 
@@ -25,6 +28,8 @@ const (
 	TransferMethodBput TransferMethod = "BPUT"
 	// TransferMethodCopy is for cp command
 	TransferMethodCopy TransferMethod = "COPY"
+	// TransferMethodChecksum is for checksum command
+	TransferMethodChecksum TransferMethod = "CHECKSUM"
 	// TransferMethodDelete is for delete command
 	TransferMethodDelete TransferMethod = "DELETE"
 	// TransferMethodBputUnknown is for unknown command
@@ -46,7 +51,63 @@ type TransferReportFile struct {
 	DestChecksum      string `json:"dest_checksum"`
 
 	Error error    `json:"error,omitempty"`
-	Notes []string `json:"notes"` // additional notes
+	Notes []string `json:"notes"`            // additional notes
+	RunID string   `json:"run_id,omitempty"` // ID of the invocation, also recorded in provenance metadata
+}
+
+// MarshalJSON returns JSON bytes, error is marshaled to its message
+func (file *TransferReportFile) MarshalJSON() ([]byte, error) {
+	type transferReportFileAlias TransferReportFile
+
+	errorMessage := ""
+	if file.Error != nil {
+		errorMessage = file.Error.Error()
+	}
+
+	return json.Marshal(&struct {
+		*transferReportFileAlias
+		Error string `json:"error,omitempty"`
+	}{
+		transferReportFileAlias: (*transferReportFileAlias)(file),
+		Error:                   errorMessage,
+	})
+}
+
+// UnmarshalJSON parses JSON bytes, error message is restored to an error
+func (file *TransferReportFile) UnmarshalJSON(data []byte) error {
+	type transferReportFileAlias TransferReportFile
+
+	aux := &struct {
+		*transferReportFileAlias
+		Error string `json:"error,omitempty"`
+	}{
+		transferReportFileAlias: (*transferReportFileAlias)(file),
+	}
+
+	err := json.Unmarshal(data, aux)
+	if err != nil {
+		return err
+	}
+
+	file.Error = nil
+	if len(aux.Error) > 0 {
+		file.Error = xerrors.New(aux.Error)
+	}
+
+	return nil
+}
+
+// HasNote checks if the file has any of the given notes
+func (file *TransferReportFile) HasNote(notes ...string) bool {
+	for _, fileNote := range file.Notes {
+		for _, note := range notes {
+			if fileNote == note {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 // GetTransferMethod returns transfer method
@@ -117,13 +178,16 @@ type TransferReportManager struct {
 	reportPath     string
 	report         bool
 	reportToStdout bool
+	dryRun         bool
+	runID          string
 
 	writer io.WriteCloser
 	lock   sync.Mutex
 }
 
 // NewTransferReportManager creates a new TransferReportManager
-func NewTransferReportManager(report bool, reportPath string, reportToStdout bool) (*TransferReportManager, error) {
+// in dry-run mode, files added are marked with "dry_run" note and planned actions are printed
+func NewTransferReportManager(report bool, reportPath string, reportToStdout bool, dryRun bool) (*TransferReportManager, error) {
 	var writer io.WriteCloser
 	if !report {
 		writer = nil
@@ -143,6 +207,7 @@ func NewTransferReportManager(report bool, reportPath string, reportToStdout boo
 		report:         report,
 		reportPath:     reportPath,
 		reportToStdout: reportToStdout,
+		dryRun:         dryRun,
 
 		writer: writer,
 		lock:   sync.Mutex{},
@@ -162,8 +227,30 @@ func (manager *TransferReportManager) Release() {
 	}
 }
 
+// SetRunID sets the run ID that is recorded in all files added
+func (manager *TransferReportManager) SetRunID(runID string) {
+	manager.runID = runID
+}
+
+// IsDryRun returns true if it is in dry-run mode
+func (manager *TransferReportManager) IsDryRun() bool {
+	return manager.dryRun
+}
+
 // AddFile adds a new file transfer
 func (manager *TransferReportManager) AddFile(file *TransferReportFile) error {
+	if len(file.RunID) == 0 {
+		file.RunID = manager.runID
+	}
+
+	if manager.dryRun {
+		file.Notes = append(file.Notes, "dry_run")
+
+		if !manager.report || !manager.reportToStdout {
+			manager.printPlannedAction(file)
+		}
+	}
+
 	if !manager.report {
 		return nil
 	}
@@ -197,6 +284,21 @@ func (manager *TransferReportManager) AddFile(file *TransferReportFile) error {
 	return nil
 }
 
+func (manager *TransferReportManager) printPlannedAction(file *TransferReportFile) {
+	for _, note := range file.Notes {
+		if note == "skip" {
+			// skipped files are printed by commands
+			return
+		}
+	}
+
+	if len(file.DestPath) > 0 {
+		Printf("[DRY RUN] %s\t%q -> %q\t(%s)\n", file.Method, file.SourcePath, file.DestPath, strings.Join(file.Notes, ", "))
+	} else {
+		Printf("[DRY RUN] %s\t%q\t(%s)\n", file.Method, file.SourcePath, strings.Join(file.Notes, ", "))
+	}
+}
+
 // AddTransfer adds a new file transfer
 func (manager *TransferReportManager) AddTransfer(result *irodsclient_fs.FileTransferResult, method TransferMethod, err error, notes []string) error {
 	file, err := NewTransferReportFileFromTransferResult(result, method, err, notes)
@@ -206,3 +308,148 @@ func (manager *TransferReportManager) AddTransfer(result *irodsclient_fs.FileTra
 
 	return manager.AddFile(file)
 }
+
+// AddRetry adds a failed attempt that is going to be retried
+func (manager *TransferReportManager) AddRetry(method TransferMethod, sourcePath string, destPath string, attempt int, err error) error {
+	now := time.Now()
+	file := &TransferReportFile{
+		Method:     method,
+		StartAt:    now,
+		EndAt:      now,
+		SourcePath: sourcePath,
+		DestPath:   destPath,
+		Error:      err,
+		Notes:      []string{"retry", fmt.Sprintf("attempt %d", attempt)},
+	}
+
+	return manager.AddFile(file)
+}
+
+// GetRetryCallback returns a callback that adds failed attempts of jobs to the report before they are retried
+// jobs must be named after their source paths
+func (manager *TransferReportManager) GetRetryCallback(method TransferMethod) RetryCallback {
+	return func(name string, attempt int, backoff time.Duration, err error) {
+		manager.AddRetry(method, name, "", attempt, err)
+	}
+}
+
+// WrapTask returns a task that adds a failed file to the report if the given task fails
+// attempts that are going to be retried are added by the retry callback of the job manager instead
+func (manager *TransferReportManager) WrapTask(method TransferMethod, sourcePath string, sourceSize int64, destPath string, task ParallelJobTask) ParallelJobTask {
+	return func(job *ParallelJob) error {
+		err := task(job)
+		if err != nil && !job.WillRetry(err) {
+			notes := []string{"failed"}
+			if job.GetAttempt() > 0 {
+				notes = append(notes, fmt.Sprintf("retry %d", job.GetAttempt()))
+			}
+
+			now := time.Now()
+			file := &TransferReportFile{
+				Method:     method,
+				StartAt:    now,
+				EndAt:      now,
+				SourcePath: sourcePath,
+				SourceSize: sourceSize,
+				DestPath:   destPath,
+				Error:      err,
+				Notes:      notes,
+			}
+
+			manager.AddFile(file)
+		}
+
+		return err
+	}
+}
+
+// ReadTransferReportFiles reads files from a transfer report in JSON lines
+func ReadTransferReportFiles(reportPath string) ([]*TransferReportFile, error) {
+	reportFile, err := os.Open(reportPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, irodsclient_types.NewFileNotFoundError(reportPath)
+		}
+
+		return nil, xerrors.Errorf("failed to open a report file %q: %w", reportPath, err)
+	}
+	defer reportFile.Close()
+
+	files := []*TransferReportFile{}
+
+	scanner := bufio.NewScanner(reportFile)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+
+		file := &TransferReportFile{}
+		err = json.Unmarshal([]byte(line), file)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to parse line %d of report file %q: %w", lineNum, reportPath, err)
+		}
+
+		files = append(files, file)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read report file %q: %w", reportPath, err)
+	}
+
+	return files, nil
+}
+
+// TransferReportFilter selects files in a transfer report to transfer again
+type TransferReportFilter struct {
+	Method       TransferMethod
+	OnlyFailed   bool
+	IncludeNotes []string
+	ExcludeNotes []string
+}
+
+// Filter returns files matching the filter
+// a file may have multiple records if it is retried or transferred again, only the last record of each source path is used
+func (filter *TransferReportFilter) Filter(files []*TransferReportFile) []*TransferReportFile {
+	lastFiles := map[string]*TransferReportFile{}
+	sourcePaths := []string{}
+
+	for _, file := range files {
+		if file.Method != filter.Method {
+			continue
+		}
+
+		if _, ok := lastFiles[file.SourcePath]; !ok {
+			sourcePaths = append(sourcePaths, file.SourcePath)
+		}
+
+		lastFiles[file.SourcePath] = file
+	}
+
+	matchingFiles := []*TransferReportFile{}
+	for _, sourcePath := range sourcePaths {
+		file := lastFiles[sourcePath]
+
+		if filter.OnlyFailed && file.Error == nil {
+			continue
+		}
+
+		if len(filter.IncludeNotes) > 0 && !file.HasNote(filter.IncludeNotes...) {
+			continue
+		}
+
+		if len(filter.ExcludeNotes) > 0 && file.HasNote(filter.ExcludeNotes...) {
+			continue
+		}
+
+		matchingFiles = append(matchingFiles, file)
+	}
+
+	return matchingFiles
+}
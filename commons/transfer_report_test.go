@@ -0,0 +1,160 @@
+package commons
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	"github.com/jedib0t/go-pretty/v6/progress"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+)
+
+func TestTransferReport(t *testing.T) {
+	t.Run("test ReadTransferReport", testReadTransferReport)
+	t.Run("test FilterTransferReport", testFilterTransferReport)
+	t.Run("test RetryReport", testRetryReport)
+}
+
+func newTestTransferReportFile(method TransferMethod, sourcePath string, err error, notes ...string) *TransferReportFile {
+	now := time.Now()
+	return &TransferReportFile{
+		Method:     method,
+		StartAt:    now,
+		EndAt:      now,
+		SourcePath: sourcePath,
+		DestPath:   "/zone/home/user" + sourcePath,
+		Error:      err,
+		Notes:      notes,
+	}
+}
+
+func testReadTransferReport(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+
+	manager, err := NewTransferReportManager(true, reportPath, false, false)
+	assert.NoError(t, err)
+
+	assert.NoError(t, manager.AddFile(newTestTransferReportFile(TransferMethodPut, "/data/a", nil, "icat")))
+	assert.NoError(t, manager.AddFile(newTestTransferReportFile(TransferMethodPut, "/data/b", xerrors.Errorf("connection reset"), "failed")))
+	manager.Release()
+
+	files, err := ReadTransferReportFiles(reportPath)
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	assert.Equal(t, TransferMethodPut, files[0].Method)
+	assert.Equal(t, "/data/a", files[0].SourcePath)
+	assert.Equal(t, "/zone/home/user/data/a", files[0].DestPath)
+	assert.NoError(t, files[0].Error)
+
+	assert.Equal(t, "/data/b", files[1].SourcePath)
+	assert.EqualError(t, files[1].Error, "connection reset")
+	assert.True(t, files[1].HasNote("failed"))
+}
+
+func testFilterTransferReport(t *testing.T) {
+	files := []*TransferReportFile{
+		newTestTransferReportFile(TransferMethodPut, "/data/a", nil, "icat"),
+		newTestTransferReportFile(TransferMethodPut, "/data/b", xerrors.Errorf("connection reset"), "failed"),
+		newTestTransferReportFile(TransferMethodPut, "/data/c", xerrors.Errorf("connection reset"), "failed"),
+		newTestTransferReportFile(TransferMethodPut, "/data/c", nil, "icat"),
+		newTestTransferReportFile(TransferMethodPut, "/data/d", nil, "skip"),
+		newTestTransferReportFile(TransferMethodGet, "/data/e", xerrors.Errorf("connection reset"), "failed"),
+	}
+
+	// the last record of /data/c succeeded
+	filter := &TransferReportFilter{
+		Method:     TransferMethodPut,
+		OnlyFailed: true,
+	}
+
+	failedFiles := filter.Filter(files)
+	assert.Len(t, failedFiles, 1)
+	assert.Equal(t, "/data/b", failedFiles[0].SourcePath)
+
+	filter = &TransferReportFilter{
+		Method:       TransferMethodPut,
+		ExcludeNotes: []string{"skip"},
+	}
+	assert.Len(t, filter.Filter(files), 3)
+
+	filter = &TransferReportFilter{
+		Method:       TransferMethodPut,
+		IncludeNotes: []string{"skip"},
+	}
+	skippedFiles := filter.Filter(files)
+	assert.Len(t, skippedFiles, 1)
+	assert.Equal(t, "/data/d", skippedFiles[0].SourcePath)
+}
+
+func testRetryReport(t *testing.T) {
+	reportPath := filepath.Join(t.TempDir(), "report.jsonl")
+
+	reportManager, err := NewTransferReportManager(true, reportPath, false, false)
+	assert.NoError(t, err)
+
+	jobManager := NewParallelJobManager(nil, 1, false, false)
+	jobManager.SetRetryPolicy(NewRetryPolicy(2, time.Millisecond, time.Millisecond))
+	jobManager.SetRetryCallback(reportManager.GetRetryCallback(TransferMethodPut))
+	jobManager.SetContinueOnError(true)
+	jobManager.Start()
+
+	// fails once with a transient error, then succeeds and adds the transfer as tasks do
+	flakyTask := func(job *ParallelJob) error {
+		if job.GetAttempt() == 0 {
+			return syscall.ECONNRESET
+		}
+
+		job.Done()
+		return reportManager.AddFile(newTestTransferReportFile(TransferMethodPut, "/data/a", nil))
+	}
+
+	// always fails with a transient error
+	brokenTask := func(job *ParallelJob) error {
+		return syscall.ECONNRESET
+	}
+
+	// fails with a permanent error
+	missingTask := func(job *ParallelJob) error {
+		return irodsclient_types.NewFileNotFoundError("/data/c")
+	}
+
+	assert.NoError(t, jobManager.Schedule("/data/a", reportManager.WrapTask(TransferMethodPut, "/data/a", 1, "/zone/a", flakyTask), 1, progress.UnitsDefault))
+	assert.NoError(t, jobManager.Schedule("/data/b", reportManager.WrapTask(TransferMethodPut, "/data/b", 1, "/zone/b", brokenTask), 1, progress.UnitsDefault))
+	assert.NoError(t, jobManager.Schedule("/data/c", reportManager.WrapTask(TransferMethodPut, "/data/c", 1, "/zone/c", missingTask), 1, progress.UnitsDefault))
+	jobManager.DoneScheduling()
+
+	err = jobManager.Wait()
+	assert.True(t, IsJobFailuresError(err))
+	reportManager.Release()
+
+	files, err := ReadTransferReportFiles(reportPath)
+	assert.NoError(t, err)
+
+	retries := map[string]int{}
+	failures := map[string]int{}
+	for _, file := range files {
+		if file.HasNote("retry") {
+			retries[file.SourcePath]++
+		}
+
+		if file.HasNote("failed") {
+			failures[file.SourcePath]++
+		}
+	}
+
+	// every retried attempt is recorded once, and the final failure once
+	assert.Equal(t, map[string]int{"/data/a": 1, "/data/b": 2}, retries)
+	assert.Equal(t, map[string]int{"/data/b": 1, "/data/c": 1}, failures)
+
+	// only the files that failed at last are transferred again
+	filter := &TransferReportFilter{Method: TransferMethodPut, OnlyFailed: true}
+	sourcePaths := []string{}
+	for _, file := range filter.Filter(files) {
+		sourcePaths = append(sourcePaths, file.SourcePath)
+	}
+	assert.ElementsMatch(t, []string{"/data/b", "/data/c"}, sourcePaths)
+}
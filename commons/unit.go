@@ -23,6 +23,10 @@ func ParseSize(size string) (int64, error) {
 	size = strings.ToUpper(size)
 	size = strings.TrimSuffix(size, "B")
 
+	if len(size) == 0 {
+		return 0, xerrors.Errorf("failed to parse empty size")
+	}
+
 	sizeNum := int64(0)
 	var err error
 
@@ -58,6 +62,10 @@ func ParseTime(t string) (int, error) {
 	t = strings.TrimSpace(t)
 	t = strings.ToUpper(t)
 
+	if len(t) == 0 {
+		return 0, xerrors.Errorf("failed to parse empty time")
+	}
+
 	tNum := int64(0)
 	var err error
 
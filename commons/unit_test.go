@@ -40,6 +40,9 @@ func testSize(t *testing.T) {
 	s6 := "256x"
 	_, err = ParseSize(s6)
 	assert.Error(t, err)
+
+	_, err = ParseSize(" ")
+	assert.Error(t, err)
 }
 
 func testTime(t *testing.T) {
@@ -71,4 +74,7 @@ func testTime(t *testing.T) {
 	s6 := "32e"
 	_, err = ParseTime(s6)
 	assert.Error(t, err)
+
+	_, err = ParseTime("  ")
+	assert.Error(t, err)
 }
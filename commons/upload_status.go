@@ -0,0 +1,575 @@
+package commons
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	irodsclient_fs "github.com/cyverse/go-irodsclient/fs"
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_irodsfs "github.com/cyverse/go-irodsclient/irods/fs"
+	irodsclient_types "github.com/cyverse/go-irodsclient/irods/types"
+	irodsclient_util "github.com/cyverse/go-irodsclient/irods/util"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/xerrors"
+)
+
+const (
+	UploadTransferStatusDirName    string = "upload_status"
+	UploadTransferStatusFileSuffix string = ".trx_status"
+
+	// uploadTransferStatusEntrySize is a size of a line of chunk progress in a status file, padded with spaces
+	// each chunk has a line at a fixed position that is overwritten, so the file does not grow while uploading
+	uploadTransferStatusEntrySize int = 128
+)
+
+// UploadTransferStatus represents the progress of a resumable upload
+type UploadTransferStatus struct {
+	LocalPath      string                                                       `json:"local_path"`
+	IRODSPath      string                                                       `json:"irods_path"`
+	StatusFilePath string                                                       `json:"status_file_path"`
+	Size           int64                                                        `json:"size"`
+	ModTime        time.Time                                                    `json:"mod_time"`
+	Threads        int                                                          `json:"threads"`
+	StatusMap      map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry `json:"-"`
+
+	fileHandle     *os.File
+	entryPositions map[int64]int64 // positions of lines of chunks in the status file
+	mutex          sync.Mutex
+}
+
+// GetUploadTransferStatusFilePath returns the path of the status file for an upload of localPath to irodsPath
+// status files are kept under the config dir so they are never uploaded together with source files
+func GetUploadTransferStatusFilePath(localPath string, irodsPath string) string {
+	hash := sha1.Sum([]byte(localPath + "\n" + irodsPath))
+	statusFilename := hex.EncodeToString(hash[:]) + UploadTransferStatusFileSuffix
+	return filepath.Join(GetDefaultIRODSConfigPath(), UploadTransferStatusDirName, statusFilename)
+}
+
+// NewUploadTransferStatus creates new UploadTransferStatus
+func NewUploadTransferStatus(localPath string, irodsPath string, size int64, modTime time.Time, threads int) *UploadTransferStatus {
+	return &UploadTransferStatus{
+		LocalPath:      localPath,
+		IRODSPath:      irodsPath,
+		StatusFilePath: GetUploadTransferStatusFilePath(localPath, irodsPath),
+		Size:           size,
+		ModTime:        modTime,
+		Threads:        threads,
+		StatusMap:      map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry{},
+	}
+}
+
+// GetUploadTransferStatus reads UploadTransferStatus from local disk
+func GetUploadTransferStatus(localPath string, irodsPath string) (*UploadTransferStatus, error) {
+	statusFilePath := GetUploadTransferStatusFilePath(localPath, irodsPath)
+
+	data, err := os.ReadFile(statusFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := readUploadTransferStatus(bytes.NewReader(data))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read status file %q: %w", statusFilePath, err)
+	}
+
+	return status, nil
+}
+
+// readUploadTransferStatus reads UploadTransferStatus, a header line followed by a line of progress per chunk
+func readUploadTransferStatus(reader io.Reader) (*UploadTransferStatus, error) {
+	bufReader := bufio.NewReader(reader)
+
+	// first line is status
+	line, err := bufReader.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	status := UploadTransferStatus{}
+	err = json.Unmarshal(line, &status)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to unmarshal json data to UploadTransferStatus: %w", err)
+	}
+
+	status.StatusMap = map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry{}
+
+	for {
+		line, err := bufReader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			statusEntry := irodsclient_irodsfs.DataObjectTransferStatusEntry{}
+			jsonErr := json.Unmarshal(line, &statusEntry)
+			if jsonErr == nil {
+				// later entries override earlier ones
+				status.StatusMap[statusEntry.StartOffset] = &statusEntry
+			}
+
+			// a line partially written by a killed process is skipped, the chunk starts over
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, err
+		}
+	}
+
+	return &status, nil
+}
+
+// Validate checks if the status can be used to resume uploading the given file
+func (status *UploadTransferStatus) Validate(localPath string, irodsPath string, size int64, modTime time.Time) bool {
+	if status.LocalPath != localPath || status.IRODSPath != irodsPath {
+		return false
+	}
+
+	if status.Size != size || !status.ModTime.Equal(modTime) {
+		return false
+	}
+
+	if status.Threads <= 0 {
+		return false
+	}
+
+	return true
+}
+
+// GetCompletedLength returns the total number of bytes uploaded
+func (status *UploadTransferStatus) GetCompletedLength() int64 {
+	completed := int64(0)
+	for _, entry := range status.StatusMap {
+		completed += entry.CompletedLength
+	}
+	return completed
+}
+
+// CreateStatusFile creates a status file and writes header and existing entries
+func (status *UploadTransferStatus) CreateStatusFile() error {
+	err := os.MkdirAll(filepath.Dir(status.StatusFilePath), 0700)
+	if err != nil {
+		return xerrors.Errorf("failed to make a directory %q: %w", filepath.Dir(status.StatusFilePath), err)
+	}
+
+	handle, err := os.Create(status.StatusFilePath)
+	if err != nil {
+		return xerrors.Errorf("failed to create file %q: %w", status.StatusFilePath, err)
+	}
+
+	status.fileHandle = handle
+
+	headerBytes, err := json.Marshal(status)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal UploadTransferStatus to json: %w", err)
+	}
+
+	_, err = handle.Write(append(headerBytes, '\n'))
+	if err != nil {
+		return xerrors.Errorf("failed to write status file %q: %w", status.StatusFilePath, err)
+	}
+
+	// a line per chunk in offset order
+	offsets := []int64{}
+	for offset := range status.StatusMap {
+		offsets = append(offsets, offset)
+	}
+
+	sort.Slice(offsets, func(i int, j int) bool {
+		return offsets[i] < offsets[j]
+	})
+
+	status.entryPositions = map[int64]int64{}
+	for idx, offset := range offsets {
+		status.entryPositions[offset] = int64(len(headerBytes)+1) + int64(idx*uploadTransferStatusEntrySize)
+
+		err = status.writeEntry(status.StatusMap[offset])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteStatus records progress of a chunk
+func (status *UploadTransferStatus) WriteStatus(entry *irodsclient_irodsfs.DataObjectTransferStatusEntry) error {
+	status.mutex.Lock()
+	defer status.mutex.Unlock()
+
+	return status.writeEntry(entry)
+}
+
+func (status *UploadTransferStatus) writeEntry(entry *irodsclient_irodsfs.DataObjectTransferStatusEntry) error {
+	if status.fileHandle == nil {
+		return xerrors.Errorf("failed to write status, file handle is nil")
+	}
+
+	position, ok := status.entryPositions[entry.StartOffset]
+	if !ok {
+		return xerrors.Errorf("failed to write status, unknown chunk at offset %d", entry.StartOffset)
+	}
+
+	entryBytes, err := json.Marshal(entry)
+	if err != nil {
+		return xerrors.Errorf("failed to marshal DataObjectTransferStatusEntry to json: %w", err)
+	}
+
+	if len(entryBytes) >= uploadTransferStatusEntrySize {
+		return xerrors.Errorf("failed to write status, entry %q is too long", string(entryBytes))
+	}
+
+	line := bytes.Repeat([]byte{' '}, uploadTransferStatusEntrySize)
+	copy(line, entryBytes)
+	line[uploadTransferStatusEntrySize-1] = '\n'
+
+	_, err = status.fileHandle.WriteAt(line, position)
+	if err != nil {
+		return xerrors.Errorf("failed to write status file %q: %w", status.StatusFilePath, err)
+	}
+
+	return nil
+}
+
+// CloseStatusFile closes the status file
+func (status *UploadTransferStatus) CloseStatusFile() error {
+	var err error
+	if status.fileHandle != nil {
+		err = status.fileHandle.Close()
+		status.fileHandle = nil
+	}
+
+	return err
+}
+
+// DeleteStatusFile deletes the status file
+func (status *UploadTransferStatus) DeleteStatusFile() error {
+	err := os.RemoveAll(status.StatusFilePath)
+	if err != nil {
+		return xerrors.Errorf("failed to delete status file %q: %w", status.StatusFilePath, err)
+	}
+
+	return nil
+}
+
+// HasUploadTransferStatusFile checks if a valid status file exists for the upload of localPath to irodsPath
+func HasUploadTransferStatusFile(localPath string, irodsPath string) bool {
+	stat, err := os.Stat(localPath)
+	if err != nil {
+		return false
+	}
+
+	status, err := GetUploadTransferStatus(localPath, irodsPath)
+	if err != nil {
+		return false
+	}
+
+	return status.Validate(localPath, irodsPath, stat.Size(), stat.ModTime())
+}
+
+// DeleteUploadTransferStatusFile deletes the status file for the upload of localPath to irodsPath
+func DeleteUploadTransferStatusFile(localPath string, irodsPath string) {
+	os.RemoveAll(GetUploadTransferStatusFilePath(localPath, irodsPath))
+}
+
+// makeUploadChunks splits a file into chunks, one per task, in offset order
+// progress of chunks in prevStatusMap is kept if the chunk has the same offset and length
+func makeUploadChunks(fileLength int64, numTasks int, prevStatusMap map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry) []*irodsclient_irodsfs.DataObjectTransferStatusEntry {
+	if numTasks <= 0 {
+		numTasks = 1
+	}
+
+	lengthPerThread := fileLength / int64(numTasks)
+	if fileLength%int64(numTasks) > 0 {
+		lengthPerThread++
+	}
+
+	chunks := []*irodsclient_irodsfs.DataObjectTransferStatusEntry{}
+	for offset := int64(0); offset < fileLength; offset += lengthPerThread {
+		length := lengthPerThread
+		if offset+length > fileLength {
+			length = fileLength - offset
+		}
+
+		chunk := &irodsclient_irodsfs.DataObjectTransferStatusEntry{
+			StartOffset:     offset,
+			Length:          length,
+			CompletedLength: 0,
+		}
+
+		if prevChunk, ok := prevStatusMap[offset]; ok && prevChunk.Length == length && prevChunk.CompletedLength <= length {
+			chunk.CompletedLength = prevChunk.CompletedLength
+		}
+
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks
+}
+
+// UploadFileParallelResumable uploads a file in parallel, recording per-chunk progress in a status file
+// if a valid status file exists, only the missing byte ranges are uploaded
+func UploadFileParallelResumable(fs *irodsclient_fs.FileSystem, localPath string, irodsPath string, resource string, taskNum int, checksum bool, verifyChecksum bool, callback irodsclient_common.TrackerCallBack) (*irodsclient_fs.FileTransferResult, error) {
+	logger := log.WithFields(log.Fields{
+		"package":  "commons",
+		"function": "UploadFileParallelResumable",
+	})
+
+	if !fs.SupportParallelUpload() {
+		// server does not support parallel upload with replica token
+		DeleteUploadTransferStatusFile(localPath, irodsPath)
+		return fs.UploadFile(localPath, irodsPath, resource, false, checksum, verifyChecksum, callback)
+	}
+
+	fileTransferResult := &irodsclient_fs.FileTransferResult{}
+	fileTransferResult.LocalPath = localPath
+	fileTransferResult.IRODSPath = irodsPath
+	fileTransferResult.StartTime = time.Now()
+
+	stat, err := os.Stat(localPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fileTransferResult, xerrors.Errorf("failed to find a file for local path %q: %w", localPath, irodsclient_types.NewFileNotFoundError(localPath))
+		}
+		return fileTransferResult, xerrors.Errorf("failed to stat %q: %w", localPath, err)
+	}
+
+	if stat.IsDir() {
+		return fileTransferResult, xerrors.Errorf("failed to find a file for local path %q, the path is for a directory: %w", localPath, irodsclient_types.NewFileNotFoundError(localPath))
+	}
+
+	fileLength := stat.Size()
+	fileTransferResult.LocalSize = fileLength
+
+	// load status
+	resume := false
+	status, err := GetUploadTransferStatus(localPath, irodsPath)
+	if err == nil && status.Validate(localPath, irodsPath, fileLength, stat.ModTime()) && fs.ExistsFile(irodsPath) {
+		resume = true
+	} else {
+		numTasks := taskNum
+		if numTasks <= 0 {
+			numTasks = irodsclient_util.GetNumTasksForParallelTransfer(fileLength)
+		}
+
+		status = NewUploadTransferStatus(localPath, irodsPath, fileLength, stat.ModTime(), numTasks)
+	}
+
+	numTasks := status.Threads
+
+	keywords := map[irodsclient_common.KeyWord]string{}
+	if checksum {
+		keywords[irodsclient_common.REG_CHKSUM_KW] = ""
+	}
+
+	if verifyChecksum {
+		checksumAlgorithm := irodsclient_types.GetChecksumAlgorithm(GetAccount().DefaultHashScheme)
+		if checksumAlgorithm == irodsclient_types.ChecksumAlgorithmUnknown {
+			checksumAlgorithm = irodsclient_types.ChecksumAlgorithmMD5
+		}
+
+		hashBytes, err := irodsclient_util.HashLocalFile(localPath, string(checksumAlgorithm))
+		if err != nil {
+			return fileTransferResult, xerrors.Errorf("failed to get hash of %q: %w", localPath, err)
+		}
+
+		hashString, err := irodsclient_types.MakeIRODSChecksumString(checksumAlgorithm, hashBytes)
+		if err != nil {
+			return fileTransferResult, xerrors.Errorf("failed to get irods checksum string from algorithm %q: %w", checksumAlgorithm, err)
+		}
+
+		fileTransferResult.CheckSumAlgorithm = checksumAlgorithm
+		fileTransferResult.LocalCheckSum = hashBytes
+
+		keywords[irodsclient_common.VERIFY_CHKSUM_KW] = hashString
+	}
+
+	// build chunks
+	var prevStatusMap map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry
+	if resume {
+		prevStatusMap = status.StatusMap
+	}
+
+	chunks := makeUploadChunks(fileLength, numTasks, prevStatusMap)
+
+	status.StatusMap = map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry{}
+	for _, chunk := range chunks {
+		status.StatusMap[chunk.StartOffset] = chunk
+	}
+
+	err = status.CreateStatusFile()
+	if err != nil {
+		return fileTransferResult, xerrors.Errorf("failed to create transfer status file for %q: %w", localPath, err)
+	}
+	defer status.CloseStatusFile()
+
+	conn, err := fs.GetIOConnection()
+	if err != nil {
+		return fileTransferResult, xerrors.Errorf("failed to get connection: %w", err)
+	}
+	defer fs.ReturnIOConnection(conn)
+
+	// do not truncate the data object when resuming
+	openMode := string(irodsclient_types.FileOpenModeWriteTruncate)
+	if resume {
+		openMode = string(irodsclient_types.FileOpenModeWriteOnly)
+	}
+
+	logger.Debugf("upload a file %q to %q in parallel, size(%d), threads(%d), resume(%t)", localPath, irodsPath, fileLength, numTasks, resume)
+
+	handle, err := irodsclient_irodsfs.OpenDataObjectForPutParallel(conn, irodsPath, resource, openMode, irodsclient_common.OPER_TYPE_NONE, numTasks, fileLength, keywords)
+	if err != nil {
+		return fileTransferResult, xerrors.Errorf("failed to open data object %q: %w", irodsPath, err)
+	}
+
+	replicaToken, resourceHierarchy, err := irodsclient_irodsfs.GetReplicaAccessInfo(conn, handle)
+	if err != nil {
+		irodsclient_irodsfs.CloseDataObject(conn, handle)
+		return fileTransferResult, xerrors.Errorf("failed to get replica access info for %q: %w", irodsPath, err)
+	}
+
+	// a task may send an error and another one on closing the replica
+	errChan := make(chan error, 2*len(chunks))
+	taskWaitGroup := sync.WaitGroup{}
+
+	totalBytesUploaded := status.GetCompletedLength()
+	if callback != nil {
+		callback(totalBytesUploaded, fileLength)
+	}
+
+	uploadTask := func(chunk *irodsclient_irodsfs.DataObjectTransferStatusEntry) {
+		defer taskWaitGroup.Done()
+
+		taskConn, taskErr := fs.GetIOConnection()
+		if taskErr != nil {
+			errChan <- xerrors.Errorf("failed to get connection: %w", taskErr)
+			return
+		}
+		defer fs.ReturnIOConnection(taskConn)
+
+		taskHandle, _, taskErr := irodsclient_irodsfs.OpenDataObjectWithReplicaToken(taskConn, irodsPath, resource, string(irodsclient_types.FileOpenModeWriteOnly), replicaToken, resourceHierarchy, numTasks, fileLength, keywords)
+		if taskErr != nil {
+			errChan <- xerrors.Errorf("failed to open data object %q with replica token: %w", irodsPath, taskErr)
+			return
+		}
+		defer func() {
+			errClose := irodsclient_irodsfs.CloseDataObjectReplica(taskConn, taskHandle)
+			if errClose != nil {
+				errChan <- errClose
+			}
+		}()
+
+		f, taskErr := os.Open(localPath)
+		if taskErr != nil {
+			errChan <- xerrors.Errorf("failed to open file %q: %w", localPath, taskErr)
+			return
+		}
+		defer f.Close()
+
+		taskOffset := chunk.StartOffset + chunk.CompletedLength
+		taskNewOffset, taskErr := irodsclient_irodsfs.SeekDataObject(taskConn, taskHandle, taskOffset, irodsclient_types.SeekSet)
+		if taskErr != nil {
+			errChan <- xerrors.Errorf("failed to seek data object %q: %w", irodsPath, taskErr)
+			return
+		}
+
+		if taskNewOffset != taskOffset {
+			errChan <- xerrors.Errorf("failed to seek to target offset %d", taskOffset)
+			return
+		}
+
+		buffer := make([]byte, irodsclient_common.ReadWriteBufferSize)
+		for chunk.CompletedLength < chunk.Length {
+			bufferLen := int64(len(buffer))
+			if remain := chunk.Length - chunk.CompletedLength; remain < bufferLen {
+				bufferLen = remain
+			}
+
+			bytesRead, taskReadErr := f.ReadAt(buffer[:bufferLen], chunk.StartOffset+chunk.CompletedLength)
+			if bytesRead > 0 {
+				taskErr = irodsclient_irodsfs.WriteDataObjectWithTrackerCallBack(taskConn, taskHandle, buffer[:bytesRead], nil)
+				if taskErr != nil {
+					errChan <- xerrors.Errorf("failed to write data object %q: %w", irodsPath, taskErr)
+					return
+				}
+
+				chunk.CompletedLength += int64(bytesRead)
+
+				taskErr = status.WriteStatus(&irodsclient_irodsfs.DataObjectTransferStatusEntry{
+					StartOffset:     chunk.StartOffset,
+					Length:          chunk.Length,
+					CompletedLength: chunk.CompletedLength,
+				})
+				if taskErr != nil {
+					errChan <- taskErr
+					return
+				}
+
+				uploaded := atomic.AddInt64(&totalBytesUploaded, int64(bytesRead))
+				if callback != nil {
+					callback(uploaded, fileLength)
+				}
+			}
+
+			if taskReadErr != nil {
+				if taskReadErr == io.EOF {
+					break
+				}
+
+				errChan <- xerrors.Errorf("failed to read file %q: %w", localPath, taskReadErr)
+				return
+			}
+		}
+	}
+
+	// upload incomplete chunks, chunks are in offset order
+	for _, chunk := range chunks {
+		if chunk.CompletedLength >= chunk.Length {
+			continue
+		}
+
+		taskWaitGroup.Add(1)
+		go uploadTask(chunk)
+	}
+
+	taskWaitGroup.Wait()
+
+	if len(errChan) > 0 {
+		irodsclient_irodsfs.CloseDataObject(conn, handle)
+		return fileTransferResult, <-errChan
+	}
+
+	err = irodsclient_irodsfs.CloseDataObject(conn, handle)
+	if err != nil {
+		return fileTransferResult, xerrors.Errorf("failed to close data object %q: %w", irodsPath, err)
+	}
+
+	// completed
+	status.CloseStatusFile()
+	status.DeleteStatusFile()
+
+	// query the data object directly, the filesystem cache does not know about data written through low-level API
+	dataObject, err := irodsclient_irodsfs.GetDataObjectWithoutCollection(conn, irodsPath)
+	if err != nil {
+		return fileTransferResult, xerrors.Errorf("failed to get data object %q: %w", irodsPath, err)
+	}
+
+	if len(dataObject.Replicas) > 0 && dataObject.Replicas[0].Checksum != nil && len(dataObject.Replicas[0].Checksum.Checksum) > 0 {
+		fileTransferResult.CheckSumAlgorithm = dataObject.Replicas[0].Checksum.Algorithm
+		fileTransferResult.IRODSCheckSum = dataObject.Replicas[0].Checksum.Checksum
+	}
+
+	fileTransferResult.IRODSSize = dataObject.Size
+	fileTransferResult.EndTime = time.Now()
+
+	return fileTransferResult, nil
+}
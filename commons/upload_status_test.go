@@ -0,0 +1,157 @@
+package commons
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	irodsclient_irodsfs "github.com/cyverse/go-irodsclient/irods/fs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadStatus(t *testing.T) {
+	t.Run("test ReadUploadTransferStatus", testReadUploadTransferStatus)
+	t.Run("test ValidateUploadTransferStatus", testValidateUploadTransferStatus)
+	t.Run("test UploadTransferStatusFile", testUploadTransferStatusFile)
+	t.Run("test MakeUploadChunks", testMakeUploadChunks)
+}
+
+func testReadUploadTransferStatus(t *testing.T) {
+	content := strings.Join([]string{
+		`{"local_path":"/data/a.bin","irods_path":"/zone/home/user/a.bin","size":100,"mod_time":"2024-10-16T12:00:00Z","threads":2}`,
+		`{"start_offset":0,"length":50,"completed_length":10}`,
+		`{"start_offset":50,"length":50,"completed_length":20}`,
+		`{"start_offset":0,"length":50,"completed_length":30}`,
+		// partially written by a killed process
+		`{"start_offset":50,"length":50,"comp`,
+		`{"start_offset":100,"length":50,"completed_length":5}          `,
+	}, "\n")
+
+	status, err := readUploadTransferStatus(strings.NewReader(content))
+	assert.NoError(t, err)
+	assert.Equal(t, "/data/a.bin", status.LocalPath)
+	assert.Equal(t, "/zone/home/user/a.bin", status.IRODSPath)
+	assert.Equal(t, int64(100), status.Size)
+	assert.Equal(t, 2, status.Threads)
+	assert.Len(t, status.StatusMap, 3)
+
+	// later entries override earlier ones
+	assert.Equal(t, int64(30), status.StatusMap[0].CompletedLength)
+	assert.Equal(t, int64(20), status.StatusMap[50].CompletedLength)
+	assert.Equal(t, int64(5), status.StatusMap[100].CompletedLength)
+	assert.Equal(t, int64(55), status.GetCompletedLength())
+
+	_, err = readUploadTransferStatus(strings.NewReader("not a status"))
+	assert.Error(t, err)
+
+	_, err = readUploadTransferStatus(strings.NewReader(""))
+	assert.Error(t, err)
+}
+
+func testValidateUploadTransferStatus(t *testing.T) {
+	modTime := time.Date(2024, 10, 16, 12, 0, 0, 0, time.UTC)
+	status := NewUploadTransferStatus("/data/a.bin", "/zone/home/user/a.bin", 100, modTime, 2)
+
+	assert.True(t, status.Validate("/data/a.bin", "/zone/home/user/a.bin", 100, modTime))
+	assert.False(t, status.Validate("/data/b.bin", "/zone/home/user/a.bin", 100, modTime))
+	assert.False(t, status.Validate("/data/a.bin", "/zone/home/user/b.bin", 100, modTime))
+	assert.False(t, status.Validate("/data/a.bin", "/zone/home/user/a.bin", 101, modTime))
+	assert.False(t, status.Validate("/data/a.bin", "/zone/home/user/a.bin", 100, modTime.Add(time.Second)))
+
+	status.Threads = 0
+	assert.False(t, status.Validate("/data/a.bin", "/zone/home/user/a.bin", 100, modTime))
+}
+
+func testUploadTransferStatusFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	localPath := filepath.Join(t.TempDir(), "a.bin")
+	err := os.WriteFile(localPath, make([]byte, 100), 0644)
+	assert.NoError(t, err)
+
+	stat, err := os.Stat(localPath)
+	assert.NoError(t, err)
+
+	irodsPath := "/zone/home/user/a.bin"
+	assert.False(t, HasUploadTransferStatusFile(localPath, irodsPath))
+
+	status := NewUploadTransferStatus(localPath, irodsPath, stat.Size(), stat.ModTime(), 2)
+	status.StatusMap[0] = &irodsclient_irodsfs.DataObjectTransferStatusEntry{StartOffset: 0, Length: 50, CompletedLength: 50}
+	status.StatusMap[50] = &irodsclient_irodsfs.DataObjectTransferStatusEntry{StartOffset: 50, Length: 50, CompletedLength: 0}
+
+	err = status.CreateStatusFile()
+	assert.NoError(t, err)
+
+	createdStat, err := os.Stat(status.StatusFilePath)
+	assert.NoError(t, err)
+
+	// each chunk has a line that is overwritten, the file does not grow
+	for completed := int64(1); completed <= 25; completed++ {
+		err = status.WriteStatus(&irodsclient_irodsfs.DataObjectTransferStatusEntry{StartOffset: 50, Length: 50, CompletedLength: completed})
+		assert.NoError(t, err)
+	}
+
+	writtenStat, err := os.Stat(status.StatusFilePath)
+	assert.NoError(t, err)
+	assert.Equal(t, createdStat.Size(), writtenStat.Size())
+
+	// chunks not in the status file are rejected
+	err = status.WriteStatus(&irodsclient_irodsfs.DataObjectTransferStatusEntry{StartOffset: 25, Length: 50, CompletedLength: 25})
+	assert.Error(t, err)
+	assert.NoError(t, status.CloseStatusFile())
+
+	assert.True(t, HasUploadTransferStatusFile(localPath, irodsPath))
+	assert.False(t, HasUploadTransferStatusFile(localPath, "/zone/home/user/b.bin"))
+
+	readStatus, err := GetUploadTransferStatus(localPath, irodsPath)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(75), readStatus.GetCompletedLength())
+
+	// modified source files are not resumed
+	err = os.WriteFile(localPath, make([]byte, 120), 0644)
+	assert.NoError(t, err)
+	assert.False(t, HasUploadTransferStatusFile(localPath, irodsPath))
+
+	DeleteUploadTransferStatusFile(localPath, irodsPath)
+	_, err = GetUploadTransferStatus(localPath, irodsPath)
+	assert.Error(t, err)
+}
+
+func testMakeUploadChunks(t *testing.T) {
+	chunks := makeUploadChunks(100, 3, nil)
+	assert.Len(t, chunks, 3)
+	assert.Equal(t, int64(0), chunks[0].StartOffset)
+	assert.Equal(t, int64(34), chunks[0].Length)
+	assert.Equal(t, int64(34), chunks[1].StartOffset)
+	assert.Equal(t, int64(68), chunks[2].StartOffset)
+	assert.Equal(t, int64(32), chunks[2].Length)
+
+	for _, chunk := range chunks {
+		assert.Equal(t, int64(0), chunk.CompletedLength)
+	}
+
+	// progress is kept only for chunks of the same offset and length
+	prevStatusMap := map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry{
+		0:  {StartOffset: 0, Length: 34, CompletedLength: 34},
+		34: {StartOffset: 34, Length: 34, CompletedLength: 10},
+		68: {StartOffset: 68, Length: 40, CompletedLength: 40},
+	}
+
+	chunks = makeUploadChunks(100, 3, prevStatusMap)
+	assert.Equal(t, int64(34), chunks[0].CompletedLength)
+	assert.Equal(t, int64(10), chunks[1].CompletedLength)
+	assert.Equal(t, int64(0), chunks[2].CompletedLength)
+
+	// invalid progress is not resumed
+	prevStatusMap = map[int64]*irodsclient_irodsfs.DataObjectTransferStatusEntry{
+		0: {StartOffset: 0, Length: 34, CompletedLength: 50},
+	}
+
+	chunks = makeUploadChunks(100, 3, prevStatusMap)
+	assert.Equal(t, int64(0), chunks[0].CompletedLength)
+
+	assert.Empty(t, makeUploadChunks(0, 3, nil))
+	assert.Len(t, makeUploadChunks(10, 0, nil), 1)
+}
@@ -0,0 +1,222 @@
+package commons
+
+import (
+	"path"
+	"sort"
+	"strconv"
+
+	irodsclient_common "github.com/cyverse/go-irodsclient/irods/common"
+	irodsclient_conn "github.com/cyverse/go-irodsclient/irods/connection"
+	"golang.org/x/xerrors"
+)
+
+type UsageGroupBy string
+
+const (
+	UsageGroupByNone     UsageGroupBy = ""
+	UsageGroupByResource UsageGroupBy = "resource"
+	UsageGroupByOwner    UsageGroupBy = "owner"
+)
+
+// CollectionUsage is a storage usage of a collection
+// Group is a resource or an owner name if usages are grouped
+type CollectionUsage struct {
+	Path        string
+	Group       string
+	Size        int64
+	ObjectCount int64
+}
+
+// collectionUsageKey identifies a usage of a collection in a group
+type collectionUsageKey struct {
+	path  string
+	group string
+}
+
+// dataObjectCounter counts data objects per collection and group
+// replicas of a data object are counted once
+type dataObjectCounter struct {
+	dataIDs map[collectionUsageKey]map[string]bool
+}
+
+func newDataObjectCounter() *dataObjectCounter {
+	return &dataObjectCounter{
+		dataIDs: map[collectionUsageKey]map[string]bool{},
+	}
+}
+
+// Add adds a data object, or its replica
+func (counter *dataObjectCounter) Add(path string, group string, dataID string) {
+	key := collectionUsageKey{path: path, group: group}
+
+	dataIDs, ok := counter.dataIDs[key]
+	if !ok {
+		dataIDs = map[string]bool{}
+		counter.dataIDs[key] = dataIDs
+	}
+
+	dataIDs[dataID] = true
+}
+
+// Count returns the number of distinct data objects in the collection and the group
+func (counter *dataObjectCounter) Count(path string, group string) int64 {
+	return int64(len(counter.dataIDs[collectionUsageKey{path: path, group: group}]))
+}
+
+// GetCollectionUsages returns usages of data objects directly in each collection under the given collection
+// sizes of all replicas are summed up, the same as iRODS quotas, but a data object with multiple replicas is counted once
+func GetCollectionUsages(conn *irodsclient_conn.IRODSConnection, collectionPath string, groupBy UsageGroupBy) ([]*CollectionUsage, error) {
+	if !isQuerySafe(collectionPath) {
+		return nil, xerrors.Errorf("collection path %q containing a single quote is not supported", collectionPath)
+	}
+
+	selects := map[irodsclient_common.ICATColumnNumber]int{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME: icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_DATA_SIZE: icatSelectSum,
+	}
+
+	// a resource has a replica of a data object at most, so data objects per resource can be counted in the query
+	// otherwise, count counts replicas, data objects are counted separately
+	countInQuery := groupBy == UsageGroupByResource
+	if countInQuery {
+		selects[irodsclient_common.ICAT_COLUMN_D_DATA_ID] = icatSelectCount
+	}
+
+	// non-aggregated columns are used for grouping
+	groupColumn := irodsclient_common.ICATColumnNumber(0)
+	switch groupBy {
+	case UsageGroupByResource:
+		groupColumn = irodsclient_common.ICAT_COLUMN_D_RESC_NAME
+	case UsageGroupByOwner:
+		groupColumn = irodsclient_common.ICAT_COLUMN_D_OWNER_NAME
+	}
+
+	if groupColumn > 0 {
+		selects[groupColumn] = icatSelectNormal
+	}
+
+	conditions := map[irodsclient_common.ICATColumnNumber]string{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME: makeQueryCollectionCondition(collectionPath),
+	}
+
+	usages := []*CollectionUsage{}
+	err := runICATQuery(conn, selects, conditions, func(values map[irodsclient_common.ICATColumnNumber]string) error {
+		usage := &CollectionUsage{
+			Path: values[irodsclient_common.ICAT_COLUMN_COLL_NAME],
+		}
+
+		if !IsSubIRODSPath(collectionPath, usage.Path) {
+			return nil
+		}
+
+		if groupColumn > 0 {
+			usage.Group = values[groupColumn]
+		}
+
+		if sizeString := values[irodsclient_common.ICAT_COLUMN_DATA_SIZE]; len(sizeString) > 0 {
+			size, err := strconv.ParseInt(sizeString, 10, 64)
+			if err != nil {
+				return xerrors.Errorf("failed to parse size %q: %w", sizeString, err)
+			}
+			usage.Size = size
+		}
+
+		if countString := values[irodsclient_common.ICAT_COLUMN_D_DATA_ID]; len(countString) > 0 {
+			count, err := strconv.ParseInt(countString, 10, 64)
+			if err != nil {
+				return xerrors.Errorf("failed to parse count %q: %w", countString, err)
+			}
+			usage.ObjectCount = count
+		}
+
+		usages = append(usages, usage)
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get usages of %q: %w", collectionPath, err)
+	}
+
+	if countInQuery {
+		return usages, nil
+	}
+
+	// count distinct data objects
+	// this returns a row per data object, or per data object and owner, as counting distinct values is not supported
+	// in queries, so it takes longer than the query of sizes for collections with many data objects
+	countSelects := map[irodsclient_common.ICATColumnNumber]int{
+		irodsclient_common.ICAT_COLUMN_COLL_NAME: icatSelectNormal,
+		irodsclient_common.ICAT_COLUMN_D_DATA_ID: icatSelectNormal,
+	}
+
+	if groupColumn > 0 {
+		countSelects[groupColumn] = icatSelectNormal
+	}
+
+	counter := newDataObjectCounter()
+	err = runICATQuery(conn, countSelects, conditions, func(values map[irodsclient_common.ICATColumnNumber]string) error {
+		group := ""
+		if groupColumn > 0 {
+			group = values[groupColumn]
+		}
+
+		counter.Add(values[irodsclient_common.ICAT_COLUMN_COLL_NAME], group, values[irodsclient_common.ICAT_COLUMN_D_DATA_ID])
+		return nil
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to count data objects in %q: %w", collectionPath, err)
+	}
+
+	for _, usage := range usages {
+		usage.ObjectCount = counter.Count(usage.Path, usage.Group)
+	}
+
+	return usages, nil
+}
+
+// SumCollectionUsages sums up usages of each collection under the root collection and its sub-collections per group
+// a usage is added to all of its parent collections up to the root, so this takes a single pass over usages
+// collections without data objects are not in the returned map, usages of a collection are sorted by groups
+func SumCollectionUsages(rootPath string, usages []*CollectionUsage) map[string][]*CollectionUsage {
+	groupUsages := map[collectionUsageKey]*CollectionUsage{}
+	for _, usage := range usages {
+		if !IsSubIRODSPath(rootPath, usage.Path) {
+			continue
+		}
+
+		collectionPath := usage.Path
+		for {
+			key := collectionUsageKey{path: collectionPath, group: usage.Group}
+
+			groupUsage, ok := groupUsages[key]
+			if !ok {
+				groupUsage = &CollectionUsage{
+					Path:  collectionPath,
+					Group: usage.Group,
+				}
+				groupUsages[key] = groupUsage
+			}
+
+			groupUsage.Size += usage.Size
+			groupUsage.ObjectCount += usage.ObjectCount
+
+			if collectionPath == rootPath || collectionPath == "/" {
+				break
+			}
+
+			collectionPath = path.Dir(collectionPath)
+		}
+	}
+
+	sums := map[string][]*CollectionUsage{}
+	for key, groupUsage := range groupUsages {
+		sums[key.path] = append(sums[key.path], groupUsage)
+	}
+
+	for _, collectionSums := range sums {
+		sort.Slice(collectionSums, func(i int, j int) bool {
+			return collectionSums[i].Group < collectionSums[j].Group
+		})
+	}
+
+	return sums
+}
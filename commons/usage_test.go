@@ -0,0 +1,80 @@
+package commons
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsage(t *testing.T) {
+	t.Run("test SumCollectionUsages", testSumCollectionUsages)
+	t.Run("test DataObjectCounter", testDataObjectCounter)
+}
+
+func testSumCollectionUsages(t *testing.T) {
+	usages := []*CollectionUsage{
+		{Path: "/zone/home/user", Group: "res1", Size: 10, ObjectCount: 1},
+		{Path: "/zone/home/user/a", Group: "res1", Size: 20, ObjectCount: 2},
+		{Path: "/zone/home/user/a", Group: "res2", Size: 20, ObjectCount: 2},
+		{Path: "/zone/home/user/a/b/c", Group: "res1", Size: 5, ObjectCount: 1},
+		{Path: "/zone/home/user/ab", Group: "res1", Size: 40, ObjectCount: 4},
+		// outside of the root
+		{Path: "/zone/home/other", Group: "res1", Size: 80, ObjectCount: 8},
+	}
+
+	sums := SumCollectionUsages("/zone/home/user", usages)
+
+	rootSums := sums["/zone/home/user"]
+	assert.Len(t, rootSums, 2)
+	assert.Equal(t, "res1", rootSums[0].Group)
+	assert.Equal(t, int64(75), rootSums[0].Size)
+	assert.Equal(t, int64(8), rootSums[0].ObjectCount)
+	assert.Equal(t, "res2", rootSums[1].Group)
+	assert.Equal(t, int64(20), rootSums[1].Size)
+
+	// sibling with the same prefix is not included
+	aSums := sums["/zone/home/user/a"]
+	assert.Len(t, aSums, 2)
+	assert.Equal(t, int64(25), aSums[0].Size)
+	assert.Equal(t, int64(3), aSums[0].ObjectCount)
+
+	// collections without data objects directly in them have sums of sub-collections
+	bSums := sums["/zone/home/user/a/b"]
+	assert.Len(t, bSums, 1)
+	assert.Equal(t, int64(5), bSums[0].Size)
+
+	assert.Empty(t, sums["/zone/home/user/b"])
+	assert.Empty(t, sums["/zone/home/other"])
+	assert.Empty(t, sums["/zone/home"])
+
+	// root of the zone
+	sums = SumCollectionUsages("/", usages)
+	assert.Equal(t, int64(175), sums["/"][0].Size+sums["/"][1].Size)
+	assert.Equal(t, int64(80), sums["/zone/home/other"][0].Size)
+}
+
+func testDataObjectCounter(t *testing.T) {
+	counter := newDataObjectCounter()
+
+	// a data object with three replicas, rows are returned per replica
+	counter.Add("/zone/home/user", "", "100")
+	counter.Add("/zone/home/user", "", "100")
+	counter.Add("/zone/home/user", "", "100")
+	counter.Add("/zone/home/user", "", "101")
+	counter.Add("/zone/home/user/a", "", "102")
+	counter.Add("/zone/home/user/a", "", "102")
+
+	assert.Equal(t, int64(2), counter.Count("/zone/home/user", ""))
+	assert.Equal(t, int64(1), counter.Count("/zone/home/user/a", ""))
+	assert.Equal(t, int64(0), counter.Count("/zone/home/user/b", ""))
+
+	// replicas owned by different users are counted for each owner
+	counter = newDataObjectCounter()
+	counter.Add("/zone/home/user", "alice", "100")
+	counter.Add("/zone/home/user", "bob", "100")
+	counter.Add("/zone/home/user", "alice", "101")
+	counter.Add("/zone/home/user", "alice", "101")
+
+	assert.Equal(t, int64(2), counter.Count("/zone/home/user", "alice"))
+	assert.Equal(t, int64(1), counter.Count("/zone/home/user", "bob"))
+}
@@ -0,0 +1,72 @@
+package commons
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/xerrors"
+)
+
+const (
+	// XattrUserPrefix is a namespace of extended attributes that are mapped to metadata
+	XattrUserPrefix string = "user."
+)
+
+var (
+	// ErrXattrNotSupported is returned if the local filesystem or the platform does not support extended attributes
+	ErrXattrNotSupported = errors.New("extended attributes are not supported")
+)
+
+// IsXattrNotSupportedError checks if the error is ErrXattrNotSupported
+func IsXattrNotSupportedError(err error) bool {
+	return errors.Is(err, ErrXattrNotSupported)
+}
+
+// ReadUserXattrs returns "user." extended attributes of the local file as metadata records
+// the "user." prefix is removed from attribute names, attributes with empty or non-text values are ignored
+func ReadUserXattrs(p string) ([]PathMetaRecord, error) {
+	names, err := listXattrs(p)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list extended attributes of %q: %w", p, err)
+	}
+
+	records := []PathMetaRecord{}
+	for _, name := range names {
+		if !strings.HasPrefix(name, XattrUserPrefix) || len(name) == len(XattrUserPrefix) {
+			continue
+		}
+
+		value, err := getXattr(p, name)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to get extended attribute %q of %q: %w", name, p, err)
+		}
+
+		if len(value) == 0 || !utf8.Valid(value) {
+			continue
+		}
+
+		records = append(records, PathMetaRecord{
+			Attribute: strings.TrimPrefix(name, XattrUserPrefix),
+			Value:     string(value),
+			Operation: MetaOperationAdd,
+		})
+	}
+
+	sort.SliceStable(records, func(i int, j int) bool {
+		return records[i].Attribute < records[j].Attribute
+	})
+
+	return records, nil
+}
+
+// WriteUserXattr sets "user." extended attribute of the local file
+func WriteUserXattr(p string, attribute string, value string) error {
+	err := setXattr(p, XattrUserPrefix+attribute, []byte(value))
+	if err != nil {
+		return xerrors.Errorf("failed to set extended attribute %q of %q: %w", XattrUserPrefix+attribute, p, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux && !darwin
+
+package commons
+
+func listXattrs(p string) ([]string, error) {
+	return nil, ErrXattrNotSupported
+}
+
+func getXattr(p string, name string) ([]byte, error) {
+	return nil, ErrXattrNotSupported
+}
+
+func setXattr(p string, name string, value []byte) error {
+	return ErrXattrNotSupported
+}
@@ -0,0 +1,35 @@
+package commons
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestXattr(t *testing.T) {
+	t.Run("test UserXattrs", testUserXattrs)
+}
+
+func testUserXattrs(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "data.txt")
+	err := os.WriteFile(p, []byte("data"), 0644)
+	assert.NoError(t, err)
+
+	err = WriteUserXattr(p, "sample_id", "S001")
+	if IsXattrNotSupportedError(err) {
+		t.Skip("extended attributes are not supported")
+	}
+	assert.NoError(t, err)
+
+	err = WriteUserXattr(p, "checksum", "sha2:abc")
+	assert.NoError(t, err)
+
+	records, err := ReadUserXattrs(p)
+	assert.NoError(t, err)
+	assert.Equal(t, []PathMetaRecord{
+		{Attribute: "checksum", Value: "sha2:abc", Operation: MetaOperationAdd},
+		{Attribute: "sample_id", Value: "S001", Operation: MetaOperationAdd},
+	}, records)
+}
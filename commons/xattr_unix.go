@@ -0,0 +1,73 @@
+//go:build linux || darwin
+
+package commons
+
+import (
+	"bytes"
+	"errors"
+
+	"golang.org/x/sys/unix"
+)
+
+func convertXattrError(err error) error {
+	if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EOPNOTSUPP) {
+		return ErrXattrNotSupported
+	}
+
+	return err
+}
+
+func listXattrs(p string) ([]string, error) {
+	size, err := unix.Listxattr(p, nil)
+	if err != nil {
+		return nil, convertXattrError(err)
+	}
+
+	if size == 0 {
+		return []string{}, nil
+	}
+
+	buf := make([]byte, size)
+	size, err = unix.Listxattr(p, buf)
+	if err != nil {
+		return nil, convertXattrError(err)
+	}
+
+	// names are null-terminated
+	names := []string{}
+	for _, name := range bytes.Split(buf[:size], []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+
+	return names, nil
+}
+
+func getXattr(p string, name string) ([]byte, error) {
+	size, err := unix.Getxattr(p, name, nil)
+	if err != nil {
+		return nil, convertXattrError(err)
+	}
+
+	if size == 0 {
+		return []byte{}, nil
+	}
+
+	buf := make([]byte, size)
+	size, err = unix.Getxattr(p, name, buf)
+	if err != nil {
+		return nil, convertXattrError(err)
+	}
+
+	return buf[:size], nil
+}
+
+func setXattr(p string, name string, value []byte) error {
+	err := unix.Setxattr(p, name, value, 0)
+	if err != nil {
+		return convertXattrError(err)
+	}
+
+	return nil
+}